@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuditCategory is a risk category n8n's security audit can check
+// +kubebuilder:validation:Enum=credentials;database;nodes;filesystem;instance
+type AuditCategory string
+
+// N8nAuditSpec defines the desired state of N8nAudit
+type N8nAuditSpec struct {
+	// InstanceRef references an N8nInstance by name
+	// The N8nInstance must exist in the operator namespace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	InstanceRef string `json:"instanceRef"`
+
+	// Categories limits the audit to these risk categories. Empty audits all of them.
+	// +optional
+	Categories []AuditCategory `json:"categories,omitempty"`
+
+	// DaysAbandonedWorkflow is how many days without executions make a
+	// workflow count as abandoned. Defaults to n8n's own setting.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DaysAbandonedWorkflow int32 `json:"daysAbandonedWorkflow,omitempty"`
+
+	// Interval re-runs the audit periodically, e.g. "24h". When unset the
+	// audit runs once per generation.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m')",message="interval must be at least 1m"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// ReportConfigMap names a ConfigMap in the N8nAudit's namespace to write the
+	// full JSON report to, under report.json. Reports are truncated to 256KiB.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	ReportConfigMap string `json:"reportConfigMap,omitempty"`
+}
+
+// AuditRiskSummary counts the findings of one risk category
+type AuditRiskSummary struct {
+	// Risk is the category, e.g. credentials or nodes
+	Risk string `json:"risk"`
+
+	// Sections is the number of kinds of finding reported
+	Sections int32 `json:"sections"`
+
+	// Findings is the number of places a problem was found
+	Findings int32 `json:"findings"`
+}
+
+// N8nAuditStatus defines the observed state of N8nAudit
+type N8nAuditStatus struct {
+	// The generation the last audit was run for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAuditTime is when the last successful audit finished
+	// +optional
+	LastAuditTime *metav1.Time `json:"lastAuditTime,omitempty"`
+
+	// Risks counts the findings of each risk category with any
+	// +optional
+	Risks []AuditRiskSummary `json:"risks,omitempty"`
+
+	// TotalFindings is the number of findings across all categories
+	// +optional
+	TotalFindings int32 `json:"totalFindings,omitempty"`
+
+	// ReportTruncated is set when the report written to reportConfigMap was cut short
+	// +optional
+	ReportTruncated bool `json:"reportTruncated,omitempty"`
+
+	// Conditions of the audit
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Condition reasons for N8nAudit
+const (
+	ReasonAuditSucceeded = "AuditSucceeded"
+	ReasonAuditFailed    = "AuditFailed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=n8naudit
+// +kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.instanceRef`
+// +kubebuilder:printcolumn:name="Findings",type=integer,JSONPath=`.status.totalFindings`
+// +kubebuilder:printcolumn:name="Last Audit",type=date,JSONPath=`.status.lastAuditTime`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// N8nAudit is the Schema for the n8naudits API. It runs n8n's security audit
+// against an instance and summarizes the findings.
+type N8nAudit struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   N8nAuditSpec   `json:"spec"`
+	Status N8nAuditStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// N8nAuditList contains a list of N8nAudit
+type N8nAuditList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []N8nAudit `json:"items"`
+}
+
+// NextAuditIn returns how long until the audit is due at now, zero when it is
+// due already. ok is false when it won't run again until the spec changes.
+func (a *N8nAudit) NextAuditIn(now time.Time) (wait time.Duration, ok bool) {
+	if a.Status.ObservedGeneration != a.Generation || a.Status.LastAuditTime == nil {
+		return 0, true
+	}
+	if a.Spec.Interval == nil {
+		return 0, false
+	}
+	return max(a.Spec.Interval.Duration-now.Sub(a.Status.LastAuditTime.Time), 0), true
+}
+
+func init() {
+	SchemeBuilder.Register(&N8nAudit{}, &N8nAuditList{})
+}
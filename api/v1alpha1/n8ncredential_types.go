@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CredentialDataSource references the Secret holding a credential's data
+type CredentialDataSource struct {
+	// SecretName is the name of a Secret in the N8nCredential's namespace.
+	// Each key becomes a data field holding the key's value as a string.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+
+	// Key, when set, reads the whole credential data as a JSON object from this
+	// key of the Secret instead, for credential types with non-string fields
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// N8nCredentialSpec defines the desired state of N8nCredential
+type N8nCredentialSpec struct {
+	// InstanceRef references an N8nInstance by name
+	// The N8nInstance must exist in the operator namespace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	InstanceRef string `json:"instanceRef"`
+
+	// Name is the credential name shown in n8n
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type is the n8n credential type, e.g. "httpHeaderAuth" or "slackApi"
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Data references the Secret the credential data is read from, so no
+	// secret values are kept in the resource itself
+	// +kubebuilder:validation:Required
+	Data CredentialDataSource `json:"data"`
+}
+
+// N8nCredentialStatus defines the observed state of N8nCredential
+type N8nCredentialStatus struct {
+	// CredentialID is the ID of the credential in n8n. It changes when the
+	// credential was deleted in n8n and had to be recreated.
+	// +optional
+	CredentialID string `json:"credentialId,omitempty"`
+
+	// DataHash is the hash of the name, type and data last written to n8n.
+	// n8n never returns credential data, so a change is detected against it.
+	// +optional
+	DataHash string `json:"dataHash,omitempty"`
+
+	// The generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions of the credential
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=n8ncred;cred
+// +kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.instanceRef`
+// +kubebuilder:printcolumn:name="Credential Name",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Credential ID",type=string,JSONPath=`.status.credentialId`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// N8nCredential is the Schema for the n8ncredentials API
+type N8nCredential struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   N8nCredentialSpec   `json:"spec"`
+	Status N8nCredentialStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// N8nCredentialList contains a list of N8nCredential
+type N8nCredentialList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []N8nCredential `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&N8nCredential{}, &N8nCredentialList{})
+}
@@ -18,8 +18,13 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // ServiceRef references a Kubernetes service for n8n
@@ -38,17 +43,294 @@ type ServiceRef struct {
 	Port int `json:"port,omitempty"`
 }
 
-// CredentialsRef references the credentials for n8n API authentication
+// CredentialsRef references the credentials for n8n API authentication, kept
+// either in a Secret or in files mounted into the operator pod
+// +kubebuilder:validation:XValidation:rule="has(self.secretName) != has(self.filePath)",message="exactly one of secretName or filePath must be set"
 type CredentialsRef struct {
 	// SecretName is the name of the secret containing the API key
 	// The secret must be in the same namespace as the N8nInstance (operator namespace)
-	// +kubebuilder:validation:Required
-	SecretName string `json:"secretName"`
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// FilePath is the absolute path of a file in the operator pod holding the
+	// API key, such as one written by a Vault agent sidecar or a mounted
+	// Secret volume. It is re-read on every reconcile, so rotated keys are
+	// picked up. The Basic auth and bearer token values are read from the files
+	// named by usernameKey, passwordKey and tokenKey in the same directory.
+	// The file must lie within the directory the operator was started with
+	// as --credentials-dir; without that flag filePath is rejected.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	FilePath string `json:"filePath,omitempty"`
 
 	// SecretKey is the key in the secret containing the API key
 	// +kubebuilder:default=api-key
 	// +optional
 	SecretKey string `json:"secretKey,omitempty"`
+
+	// AuthType adds an Authorization header for an auth proxy in front of n8n,
+	// such as an ingress enforcing its own auth. The API key is always sent.
+	// +kubebuilder:default=ApiKey
+	// +optional
+	AuthType AuthType `json:"authType,omitempty"`
+
+	// UsernameKey is the key in the secret containing the Basic auth username
+	// +kubebuilder:default=username
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key in the secret containing the Basic auth password
+	// +kubebuilder:default=password
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+
+	// TokenKey is the key in the secret containing the bearer token
+	// +kubebuilder:default=token
+	// +optional
+	TokenKey string `json:"tokenKey,omitempty"`
+}
+
+// AuthType selects the Authorization header sent alongside the n8n API key
+// +kubebuilder:validation:Enum=ApiKey;Basic;Bearer
+type AuthType string
+
+const (
+	// AuthTypeAPIKey sends only the n8n API key
+	AuthTypeAPIKey AuthType = "ApiKey"
+
+	// AuthTypeBasic also sends HTTP Basic credentials
+	AuthTypeBasic AuthType = "Basic"
+
+	// AuthTypeBearer also sends a bearer token
+	AuthTypeBearer AuthType = "Bearer"
+)
+
+// AdoptTagSelector selects existing n8n workflows to bring under operator management
+type AdoptTagSelector struct {
+	// Tags a workflow must carry (all of them) to be adopted
+	// +kubebuilder:validation:MinItems=1
+	Tags []string `json:"tags"`
+
+	// Namespace in which the generated N8nWorkflow resources are created
+	// +kubebuilder:validation:MinLength=1
+	TargetNamespace string `json:"targetNamespace"`
+}
+
+// OrphanAction is what happens to orphaned workflows
+// +kubebuilder:validation:Enum=Report;Delete
+type OrphanAction string
+
+const (
+	// OrphanActionReport only lists orphaned workflows in status
+	OrphanActionReport OrphanAction = "Report"
+
+	// OrphanActionDelete deletes orphaned workflows from n8n
+	OrphanActionDelete OrphanAction = "Delete"
+)
+
+// OrphanCleanupSpec configures the search for orphaned workflows: workflows
+// in n8n carrying the operator's managed-by marker and the UID of an
+// N8nWorkflow that no longer exists, e.g. because its finalizer was removed
+// before the workflow could be deleted
+type OrphanCleanupSpec struct {
+	// Action is Report, which only lists orphaned workflows in
+	// status.orphanedWorkflows, or Delete, which also removes them from n8n
+	// +kubebuilder:default=Report
+	// +optional
+	Action OrphanAction `json:"action,omitempty"`
+}
+
+// OrphanedWorkflow is an n8n workflow whose N8nWorkflow no longer exists
+type OrphanedWorkflow struct {
+	// ID is the n8n workflow ID
+	ID string `json:"id"`
+
+	// Name is the n8n workflow name
+	Name string `json:"name"`
+
+	// Owner is the namespace/name of the deleted N8nWorkflow, as recorded in
+	// the workflow's meta
+	// +optional
+	Owner string `json:"owner,omitempty"`
+}
+
+// APIKeyScope describes what the instance API key is permitted to do
+// +kubebuilder:validation:Enum=read;readwrite
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead means the key can list and read workflows but not change them
+	APIKeyScopeRead APIKeyScope = "read"
+
+	// APIKeyScopeReadWrite means the key can create, update and delete workflows
+	APIKeyScopeReadWrite APIKeyScope = "readwrite"
+)
+
+// AdoptionMode controls how a workflow without a known n8n ID finds an existing
+// n8n workflow to manage before creating a new one
+// +kubebuilder:validation:Enum=ByMarkerOnly;ByName;Never
+type AdoptionMode string
+
+const (
+	// AdoptionModeByMarkerOnly only manages workflows explicitly linked to the
+	// resource by ID (status.workflowId or the adopted-workflow-id annotation)
+	// or same-named workflows carrying the operator's managed-by marker
+	AdoptionModeByMarkerOnly AdoptionMode = "ByMarkerOnly"
+
+	// AdoptionModeByName also takes over an existing workflow with the same name
+	AdoptionModeByName AdoptionMode = "ByName"
+
+	// AdoptionModeNever always creates a new workflow, only tracking the one it created
+	AdoptionModeNever AdoptionMode = "Never"
+)
+
+// SecretDetectionMode controls what happens when a workflow's node parameters
+// contain values that look like hard-coded secrets
+// +kubebuilder:validation:Enum=Disabled;Warn;Block
+type SecretDetectionMode string
+
+const (
+	// SecretDetectionDisabled skips the scan
+	SecretDetectionDisabled SecretDetectionMode = "Disabled"
+
+	// SecretDetectionWarn syncs the workflow but emits a warning event
+	SecretDetectionWarn SecretDetectionMode = "Warn"
+
+	// SecretDetectionBlock refuses to sync the workflow until the secret is removed
+	SecretDetectionBlock SecretDetectionMode = "Block"
+)
+
+// BackpressureSpec configures adaptive slow-down of workflow syncs while n8n is busy
+type BackpressureSpec struct {
+	// MetricsPath is the Prometheus metrics endpoint read on each health check
+	// +kubebuilder:default=/metrics
+	// +optional
+	MetricsPath string `json:"metricsPath,omitempty"`
+
+	// Metric is the name of the load indicator, summed across its label sets
+	// +kubebuilder:default=n8n_scaling_mode_queue_jobs_waiting
+	// +optional
+	Metric string `json:"metric,omitempty"`
+
+	// Threshold at or above which the instance is considered under high load
+	// +kubebuilder:validation:Minimum=1
+	Threshold int64 `json:"threshold"`
+
+	// RequeueMultiplier scales the requeue interval of dependent workflows
+	// while the instance is under high load
+	// +kubebuilder:default=4
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequeueMultiplier int32 `json:"requeueMultiplier,omitempty"`
+}
+
+// InstanceLimits are limits enforced by the n8n instance, checked before
+// calling the API so violations fail with a clear reason
+type InstanceLimits struct {
+	// MaxNodesPerWorkflow is the largest number of nodes n8n accepts in one workflow
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxNodesPerWorkflow int32 `json:"maxNodesPerWorkflow,omitempty"`
+}
+
+// RateLimitSpec is the client-side request budget for an instance, e.g. to
+// stay within the API quota of an n8n Cloud plan
+type RateLimitSpec struct {
+	// RequestsPerMinute is the sustained number of requests sent to the instance
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerMinute int32 `json:"requestsPerMinute"`
+
+	// Burst is how many requests may be sent at once after a quiet period.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// ConnectionPoolSpec tunes the idle connections kept open to an instance, so
+// requests reuse them instead of opening new ones
+type ConnectionPoolSpec struct {
+	// MaxIdleConns caps the idle connections kept to the instance. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConns int32 `json:"maxIdleConns,omitempty"`
+
+	// MaxIdleConnsPerHost caps the idle connections kept to each host of the
+	// instance. Defaults to 16.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConnsPerHost int32 `json:"maxIdleConnsPerHost,omitempty"`
+
+	// IdleConnTimeout is how long an idle connection is kept open, e.g. "30s".
+	// Defaults to 90s.
+	// +optional
+	IdleConnTimeout *metav1.Duration `json:"idleConnTimeout,omitempty"`
+}
+
+// TLSConfig configures verification of the n8n server certificate
+type TLSConfig struct {
+	// CA selects a ConfigMap or Secret key in this N8nInstance's namespace
+	// holding PEM-encoded CA certificates, trusted in addition to the system roots
+	// +optional
+	CA *CABundleSource `json:"ca,omitempty"`
+
+	// InsecureSkipVerify disables verification of the server certificate.
+	// Only meant for development clusters.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// CABundleSource selects a ConfigMap or Secret key holding a PEM CA bundle.
+// Exactly one of the references must be set.
+type CABundleSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// ExecutionTimeoutAction is what happens to a workflow whose executionTimeout
+// setting exceeds the ceiling
+// +kubebuilder:validation:Enum=Clamp;Reject
+type ExecutionTimeoutAction string
+
+const (
+	// ExecutionTimeoutClamp lowers the setting to the ceiling and syncs the workflow
+	ExecutionTimeoutClamp ExecutionTimeoutAction = "Clamp"
+
+	// ExecutionTimeoutReject refuses to sync the workflow
+	ExecutionTimeoutReject ExecutionTimeoutAction = "Reject"
+)
+
+// ExecutionTimeoutPolicy caps the executionTimeout setting of workflows
+type ExecutionTimeoutPolicy struct {
+	// Max is the longest executionTimeout a workflow may set, e.g. "30m". A
+	// setting of -1, which disables the timeout in n8n, exceeds any ceiling.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="max must be at least 1s"
+	Max metav1.Duration `json:"max"`
+
+	// Action is what happens to a workflow exceeding Max
+	// +kubebuilder:default=Clamp
+	// +optional
+	Action ExecutionTimeoutAction `json:"action,omitempty"`
+}
+
+// URLMigrationSpec paces the re-sync of dependent workflows after the instance URL changes
+type URLMigrationSpec struct {
+	// BatchSize is the number of workflows released to sync against the new URL per batch
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// BatchIntervalSeconds is the minimum time between batches
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BatchIntervalSeconds int32 `json:"batchIntervalSeconds,omitempty"`
 }
 
 // N8nInstanceSpec defines the desired state of N8nInstance
@@ -66,10 +348,192 @@ type N8nInstanceSpec struct {
 	// +optional
 	ServiceRef *ServiceRef `json:"serviceRef,omitempty"`
 
-	// Credentials references the secret containing the n8n API key
+	// WebhookBaseURL is the public base URL webhooks are called under, e.g.
+	// "https://hooks.example.com", for deployments that serve webhooks through
+	// an ingress on another host than the API. Defaults to the API URL.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	WebhookBaseURL string `json:"webhookBaseURL,omitempty"`
+
+	// Credentials references the secret or file containing the n8n API key
 	// The secret must be in the same namespace as this N8nInstance
 	// +kubebuilder:validation:Required
 	Credentials CredentialsRef `json:"credentials"`
+
+	// Timeout bounds each HTTP request to the n8n API, e.g. "10s" or "2m".
+	// Defaults to 30s when unset.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// HealthCheckInterval is how often the instance is health-checked, e.g.
+	// "1m". Defaults to 5m; must be at least 10s.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('10s')",message="healthCheckInterval must be at least 10s"
+	// +optional
+	HealthCheckInterval *metav1.Duration `json:"healthCheckInterval,omitempty"`
+
+	// HealthCheckFailureThreshold is how many health checks in a row must fail
+	// before the instance turns Ready=false. Earlier failures only set the
+	// Degraded condition, so a brief network blip doesn't stop dependent workflows.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HealthCheckFailureThreshold int32 `json:"healthCheckFailureThreshold,omitempty"`
+
+	// RateLimit overrides the operator-wide request budget for this instance.
+	// All clients of the instance share it.
+	// +optional
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
+
+	// TLS configures how the n8n server certificate is verified, e.g. to trust
+	// an internal CA
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// ConnectionPool tunes how many idle connections to the instance are kept
+	// for reuse and for how long. All clients of the instance share them.
+	// +optional
+	ConnectionPool *ConnectionPoolSpec `json:"connectionPool,omitempty"`
+
+	// AdoptTagSelector, when set, generates an N8nWorkflow resource for every
+	// workflow in n8n carrying the selected tags so it becomes operator-managed
+	// +optional
+	AdoptTagSelector *AdoptTagSelector `json:"adoptTagSelector,omitempty"`
+
+	// OrphanCleanup, when set, looks on each health check for workflows in n8n
+	// left behind by deleted N8nWorkflow resources and reports them, or deletes
+	// them with action Delete
+	// +optional
+	OrphanCleanup *OrphanCleanupSpec `json:"orphanCleanup,omitempty"`
+
+	// Backpressure, when set, reads a load indicator on each health check and
+	// lengthens dependent workflow requeue intervals while the load is high
+	// +optional
+	Backpressure *BackpressureSpec `json:"backpressure,omitempty"`
+
+	// ProbeWriteScope makes each health check verify that the API key can write,
+	// by creating and immediately deleting an empty workflow, and records the
+	// result in status.apiKeyScope
+	// +optional
+	ProbeWriteScope bool `json:"probeWriteScope,omitempty"`
+
+	// PartialUpdates sends only the changed fields when updating a workflow,
+	// e.g. just the name after a rename, using PATCH. n8n versions without
+	// PATCH support get the full workflow instead.
+	// +optional
+	PartialUpdates bool `json:"partialUpdates,omitempty"`
+
+	// MaxClockSkewSeconds is the clock difference between n8n and the operator
+	// beyond which a ClockSkew warning is raised, since skew makes cron triggers misfire
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxClockSkewSeconds int32 `json:"maxClockSkewSeconds,omitempty"`
+
+	// AdoptionMode controls whether workflows referencing this instance take over
+	// existing n8n workflows before creating new ones
+	// +kubebuilder:default=ByName
+	// +optional
+	AdoptionMode AdoptionMode `json:"adoptionMode,omitempty"`
+
+	// SecretDetection scans node parameters of workflows referencing this instance
+	// for literal passwords, tokens and other secret-looking values, which belong
+	// in n8n credentials instead
+	// +kubebuilder:default=Warn
+	// +optional
+	SecretDetection SecretDetectionMode `json:"secretDetection,omitempty"`
+
+	// URLMigration paces how dependent workflows move over when the URL changes
+	// +optional
+	URLMigration *URLMigrationSpec `json:"urlMigration,omitempty"`
+
+	// Limits are the instance's known workflow limits, such as the maximum node
+	// count enforced by n8n Enterprise. n8n doesn't report them through its
+	// API, so they are only known when set here. Unset limits are left for n8n
+	// to enforce.
+	// +optional
+	Limits *InstanceLimits `json:"limits,omitempty"`
+
+	// AllowedSyncPolicies restricts the sync policies workflows referencing this
+	// instance may use, e.g. to forbid Manual in production. A workflow requesting
+	// another policy is synced under the first listed one. All policies are
+	// allowed when empty.
+	// +listType=set
+	// +optional
+	AllowedSyncPolicies []SyncPolicy `json:"allowedSyncPolicies,omitempty"`
+
+	// ProtectedWorkflowNames are regular expressions matched against the whole
+	// workflow name. Workflows with a matching name are not managed unless the
+	// N8nWorkflow carries the n8n.slys.dev/allow-protected-name: "true"
+	// annotation, guarding critical workflows against a mistyped name.
+	// +optional
+	ProtectedWorkflowNames []string `json:"protectedWorkflowNames,omitempty"`
+
+	// BlockedNodeTypes are globs of node types, e.g. "n8n-nodes-base.code",
+	// that workflows referencing this instance may not use. When set it
+	// replaces the operator's --blocked-node-types list for this instance.
+	// +listType=set
+	// +optional
+	BlockedNodeTypes []string `json:"blockedNodeTypes,omitempty"`
+
+	// ExecutionTimeoutPolicy caps the executionTimeout setting of workflows
+	// referencing this instance. When set it replaces the operator's
+	// --max-execution-timeout for this instance.
+	// +optional
+	ExecutionTimeoutPolicy *ExecutionTimeoutPolicy `json:"executionTimeoutPolicy,omitempty"`
+
+	// DeactivateSelector keeps the workflows referencing this instance whose
+	// labels match it deactivated in n8n, whatever their spec.active, e.g. for a
+	// maintenance window. An empty selector matches every workflow. Removing it
+	// lets the workflows activate again.
+	// +optional
+	DeactivateSelector *metav1.LabelSelector `json:"deactivateSelector,omitempty"`
+}
+
+// URLMigrationPhase is the progress of a URL migration
+type URLMigrationPhase string
+
+const (
+	// URLMigrationInProgress means some dependent workflows still wait to move over
+	URLMigrationInProgress URLMigrationPhase = "InProgress"
+
+	// URLMigrationCompleted means every dependent workflow was released to the new URL
+	URLMigrationCompleted URLMigrationPhase = "Completed"
+)
+
+// URLMigrationStatus records the most recent instance URL change and how far
+// dependent workflows have moved to the new URL
+type URLMigrationStatus struct {
+	// FromURL is the previously validated URL
+	FromURL string `json:"fromURL"`
+
+	// ToURL is the new URL, validated by a health check before the migration started
+	ToURL string `json:"toURL"`
+
+	// Phase is InProgress until every dependent workflow has been released
+	Phase URLMigrationPhase `json:"phase"`
+
+	// StartedAt is when the URL change was detected and validated
+	StartedAt metav1.Time `json:"startedAt"`
+
+	// LastBatchTime is when the most recent batch of workflows was released
+	// +optional
+	LastBatchTime *metav1.Time `json:"lastBatchTime,omitempty"`
+
+	// CompletedAt is when the last workflow was released
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+
+	// Total is the number of workflows referencing the instance
+	Total int32 `json:"total"`
+
+	// Migrated is the number of workflows released to sync against the new URL
+	Migrated int32 `json:"migrated"`
+
+	// ReleasedThrough is the namespace/name of the last workflow released.
+	// Workflows are released in namespace/name order, so every workflow up to
+	// and including it syncs against the new URL.
+	// +optional
+	ReleasedThrough string `json:"releasedThrough,omitempty"`
 }
 
 // N8nInstanceStatus defines the observed state of N8nInstance
@@ -86,10 +550,95 @@ type N8nInstanceStatus struct {
 	// +optional
 	LastHealthCheck *metav1.Time `json:"lastHealthCheck,omitempty"`
 
+	// LastHealthCheckAttempt is the last time the instance was health-checked,
+	// whether or not the check passed
+	// +optional
+	LastHealthCheckAttempt *metav1.Time `json:"lastHealthCheckAttempt,omitempty"`
+
+	// ConsecutiveHealthCheckFailures is the number of health checks that have
+	// failed in a row since the last one passed
+	// +optional
+	ConsecutiveHealthCheckFailures int32 `json:"consecutiveHealthCheckFailures,omitempty"`
+
+	// Version is the n8n release reported by the instance, empty if it
+	// doesn't expose one
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Edition is the n8n license plan, e.g. Community or Enterprise
+	// +optional
+	Edition string `json:"edition,omitempty"`
+
+	// VersionSupported is whether Version lies within the range of n8n releases
+	// the operator is tested against, unset while the version is unknown
+	// +optional
+	VersionSupported *bool `json:"versionSupported,omitempty"`
+
+	// APIKeyScope is the access level detected for the API key when
+	// probeWriteScope is enabled
+	// +optional
+	APIKeyScope APIKeyScope `json:"apiKeyScope,omitempty"`
+
+	// ClockSkewSeconds is how far the n8n clock is ahead of the operator's
+	// (negative if behind), estimated from the Date response header
+	// +optional
+	ClockSkewSeconds int64 `json:"clockSkewSeconds,omitempty"`
+
+	// RateLimitRemaining is the request budget n8n reported as remaining in the
+	// rate limit headers of the last health check
+	// +optional
+	RateLimitRemaining *int64 `json:"rateLimitRemaining,omitempty"`
+
+	// RateLimitLimit is the request budget per window n8n reported alongside
+	// RateLimitRemaining
+	// +optional
+	RateLimitLimit int64 `json:"rateLimitLimit,omitempty"`
+
 	// The generation observed by the controller
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// AdoptedWorkflows is the number of N8nWorkflow resources generated by adoptTagSelector
+	// +optional
+	AdoptedWorkflows int32 `json:"adoptedWorkflows,omitempty"`
+
+	// OrphanedWorkflowCount is the number of orphaned workflows found in n8n
+	// by the last search, not counting those deleted by it
+	// +optional
+	OrphanedWorkflowCount int32 `json:"orphanedWorkflowCount,omitempty"`
+
+	// OrphanedWorkflows lists the first orphaned workflows counted in
+	// OrphanedWorkflowCount
+	// +optional
+	OrphanedWorkflows []OrphanedWorkflow `json:"orphanedWorkflows,omitempty"`
+
+	// ActiveWorkflows is the number of workflows referencing this instance that
+	// were last seen active in n8n
+	// +optional
+	ActiveWorkflows int32 `json:"activeWorkflows,omitempty"`
+
+	// DesiredActiveWorkflows is the number of workflows referencing this
+	// instance that should be active, leaving out those held by deactivateSelector
+	// +optional
+	DesiredActiveWorkflows int32 `json:"desiredActiveWorkflows,omitempty"`
+
+	// HeldInactiveWorkflows is the number of workflows deactivateSelector keeps
+	// deactivated
+	// +optional
+	HeldInactiveWorkflows int32 `json:"heldInactiveWorkflows,omitempty"`
+
+	// Load is the last value read for the backpressure metric
+	// +optional
+	Load int64 `json:"load,omitempty"`
+
+	// HighLoad is true while Load is at or above the backpressure threshold
+	// +optional
+	HighLoad bool `json:"highLoad,omitempty"`
+
+	// URLMigration tracks the most recent URL change
+	// +optional
+	URLMigration *URLMigrationStatus `json:"urlMigration,omitempty"`
+
 	// Conditions of the n8n instance
 	// +listType=map
 	// +listMapKey=type
@@ -101,14 +650,26 @@ type N8nInstanceStatus struct {
 const (
 	// InstanceConditionTypeReady indicates the instance is ready and connected
 	InstanceConditionTypeReady = "Ready"
+
+	// InstanceConditionTypeDegraded indicates recent health checks failed but
+	// not yet healthCheckFailureThreshold in a row, so the instance is still Ready
+	InstanceConditionTypeDegraded = "Degraded"
+
+	// InstanceConditionTypeUnsupported indicates the n8n release is outside the
+	// range the operator is tested against, so API changes may break syncing
+	InstanceConditionTypeUnsupported = "Unsupported"
 )
 
 // Condition reasons for N8nInstance
 const (
-	InstanceReasonConnected       = "Connected"
-	InstanceReasonConnectionError = "ConnectionError"
-	InstanceReasonAuthError       = "AuthenticationError"
-	InstanceReasonInvalidConfig   = "InvalidConfiguration"
+	InstanceReasonConnected          = "Connected"
+	InstanceReasonConnectionError    = "ConnectionError"
+	InstanceReasonAuthError          = "AuthenticationError"
+	InstanceReasonInvalidConfig      = "InvalidConfiguration"
+	InstanceReasonTLSConfigError     = "TLSConfigError"
+	InstanceReasonReconcileTimeout   = "ReconcileTimeout"
+	InstanceReasonHealthCheckFailing = "HealthCheckFailing"
+	InstanceReasonVersionOutOfRange  = "VersionOutOfRange"
 )
 
 // +kubebuilder:object:root=true
@@ -116,6 +677,7 @@ const (
 // +kubebuilder:resource:shortName=n8ni;instance
 // +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`
 // +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
 // +kubebuilder:printcolumn:name="Last Check",type=date,JSONPath=`.status.lastHealthCheck`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
@@ -139,6 +701,18 @@ type N8nInstanceList struct {
 	Items           []N8nInstance `json:"items"`
 }
 
+// GetWebhookBaseURL returns the base URL webhooks are called under:
+// spec.webhookBaseURL when set, otherwise the URL used to connect to the instance
+func (i *N8nInstance) GetWebhookBaseURL() string {
+	if i.Spec.WebhookBaseURL != "" {
+		return i.Spec.WebhookBaseURL
+	}
+	if i.Status.URL != "" {
+		return i.Status.URL
+	}
+	return i.GetResolvedURL()
+}
+
 // GetResolvedURL returns the URL to use for connecting to this n8n instance
 func (i *N8nInstance) GetResolvedURL() string {
 	if i.Spec.URL != "" {
@@ -157,6 +731,190 @@ func (i *N8nInstance) GetResolvedURL() string {
 	return ""
 }
 
+// GetMetricsPath returns the backpressure metrics endpoint, defaulting to /metrics
+func (b *BackpressureSpec) GetMetricsPath() string {
+	if b.MetricsPath != "" {
+		return b.MetricsPath
+	}
+	return "/metrics"
+}
+
+// GetMetric returns the backpressure load metric name
+func (b *BackpressureSpec) GetMetric() string {
+	if b.Metric != "" {
+		return b.Metric
+	}
+	return "n8n_scaling_mode_queue_jobs_waiting"
+}
+
+// GetRequeueMultiplier returns the factor applied to dependent requeue intervals
+// while the instance is under high load, or 1 when backpressure is not in effect
+func (i *N8nInstance) GetRequeueMultiplier() int32 {
+	if i.Spec.Backpressure == nil || !i.Status.HighLoad {
+		return 1
+	}
+	if i.Spec.Backpressure.RequeueMultiplier < 1 {
+		return 4
+	}
+	return i.Spec.Backpressure.RequeueMultiplier
+}
+
+// GetOrphanAction returns the orphaned workflow action, defaulting to Report,
+// or "" when orphan cleanup is not configured
+func (i *N8nInstance) GetOrphanAction() OrphanAction {
+	switch {
+	case i.Spec.OrphanCleanup == nil:
+		return ""
+	case i.Spec.OrphanCleanup.Action != "":
+		return i.Spec.OrphanCleanup.Action
+	}
+	return OrphanActionReport
+}
+
+// GetMaxClockSkew returns the clock skew tolerated before warning
+func (i *N8nInstance) GetMaxClockSkew() time.Duration {
+	if i.Spec.MaxClockSkewSeconds > 0 {
+		return time.Duration(i.Spec.MaxClockSkewSeconds) * time.Second
+	}
+	return 30 * time.Second
+}
+
+// GetAdoptionMode returns the adoption mode, defaulting to ByName
+func (i *N8nInstance) GetAdoptionMode() AdoptionMode {
+	if i.Spec.AdoptionMode != "" {
+		return i.Spec.AdoptionMode
+	}
+	return AdoptionModeByName
+}
+
+// GetSecretDetection returns the secret detection mode, defaulting to Warn
+func (i *N8nInstance) GetSecretDetection() SecretDetectionMode {
+	if i.Spec.SecretDetection != "" {
+		return i.Spec.SecretDetection
+	}
+	return SecretDetectionWarn
+}
+
+// GetMigrationBatchSize returns the URL migration batch size, defaulting to 10
+func (i *N8nInstance) GetMigrationBatchSize() int {
+	if i.Spec.URLMigration != nil && i.Spec.URLMigration.BatchSize > 0 {
+		return int(i.Spec.URLMigration.BatchSize)
+	}
+	return 10
+}
+
+// GetMigrationBatchInterval returns the minimum time between URL migration
+// batches, defaulting to 30 seconds
+func (i *N8nInstance) GetMigrationBatchInterval() time.Duration {
+	if i.Spec.URLMigration != nil && i.Spec.URLMigration.BatchIntervalSeconds > 0 {
+		return time.Duration(i.Spec.URLMigration.BatchIntervalSeconds) * time.Second
+	}
+	return 30 * time.Second
+}
+
+// GetMaxNodesPerWorkflow returns the node limit per workflow, or 0 when unknown
+func (i *N8nInstance) GetMaxNodesPerWorkflow() int {
+	if i.Spec.Limits != nil {
+		return int(i.Spec.Limits.MaxNodesPerWorkflow)
+	}
+	return 0
+}
+
+// GetSyncPolicy returns the sync policy to apply for the requested one, and
+// whether the requested policy is allowed. A disallowed policy falls back to the
+// first allowed one.
+func (i *N8nInstance) GetSyncPolicy(requested SyncPolicy) (SyncPolicy, bool) {
+	if requested == "" {
+		requested = SyncPolicyAlways
+	}
+	if len(i.Spec.AllowedSyncPolicies) == 0 || slices.Contains(i.Spec.AllowedSyncPolicies, requested) {
+		return requested, true
+	}
+	return i.Spec.AllowedSyncPolicies[0], false
+}
+
+// IsProtectedWorkflowName reports whether name matches one of the instance's
+// protected workflow name patterns
+func (i *N8nInstance) IsProtectedWorkflowName(name string) (bool, error) {
+	for _, pattern := range i.Spec.ProtectedWorkflowNames {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid protected workflow name pattern %q: %w", pattern, err)
+		}
+		if re.MatchString(name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetBlockedNodeTypes returns the instance's blocked node type globs, or
+// defaults when it sets none
+func (i *N8nInstance) GetBlockedNodeTypes(defaults []string) []string {
+	if len(i.Spec.BlockedNodeTypes) > 0 {
+		return i.Spec.BlockedNodeTypes
+	}
+	return defaults
+}
+
+// GetExecutionTimeoutPolicy returns the instance's execution timeout policy, or
+// defaults when it sets none. A zero Max sets no ceiling; an empty Action is Clamp.
+func (i *N8nInstance) GetExecutionTimeoutPolicy(defaults ExecutionTimeoutPolicy) ExecutionTimeoutPolicy {
+	policy := defaults
+	if i.Spec.ExecutionTimeoutPolicy != nil {
+		policy = *i.Spec.ExecutionTimeoutPolicy
+	}
+	if policy.Action == "" {
+		policy.Action = ExecutionTimeoutClamp
+	}
+	return policy
+}
+
+// HoldsInactive reports whether deactivateSelector matches a workflow with the
+// given labels, so it must be kept deactivated
+func (i *N8nInstance) HoldsInactive(workflowLabels map[string]string) (bool, error) {
+	if i.Spec.DeactivateSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(i.Spec.DeactivateSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid deactivateSelector: %w", err)
+	}
+	return selector.Matches(labels.Set(workflowLabels)), nil
+}
+
+// GetTimeout returns the HTTP request timeout, defaulting to 30 seconds
+func (i *N8nInstance) GetTimeout() time.Duration {
+	if i.Spec.Timeout != nil && i.Spec.Timeout.Duration > 0 {
+		return i.Spec.Timeout.Duration
+	}
+	return 30 * time.Second
+}
+
+// GetRateLimit returns the instance's request budget in requests per second
+// and burst, with ok false when it uses the operator-wide budget
+func (i *N8nInstance) GetRateLimit() (requestsPerSecond float64, burst int, ok bool) {
+	if i.Spec.RateLimit == nil || i.Spec.RateLimit.RequestsPerMinute < 1 {
+		return 0, 0, false
+	}
+	return float64(i.Spec.RateLimit.RequestsPerMinute) / 60, max(int(i.Spec.RateLimit.Burst), 1), true
+}
+
+// GetHealthCheckFailureThreshold returns how many health checks in a row must
+// fail before the instance is no longer Ready, defaulting to 1
+func (i *N8nInstance) GetHealthCheckFailureThreshold() int32 {
+	if i.Spec.HealthCheckFailureThreshold > 0 {
+		return i.Spec.HealthCheckFailureThreshold
+	}
+	return 1
+}
+
+// GetHealthCheckInterval returns how long to wait between health checks,
+// defaulting to DefaultReconcileInterval and never below MinReconcileInterval
+func (i *N8nInstance) GetHealthCheckInterval() time.Duration {
+	return intervalOrDefault(i.Spec.HealthCheckInterval)
+}
+
 // GetSecretKey returns the key to use when reading the API key from the secret
 func (i *N8nInstance) GetSecretKey() string {
 	if i.Spec.Credentials.SecretKey != "" {
@@ -165,6 +923,38 @@ func (i *N8nInstance) GetSecretKey() string {
 	return "api-key"
 }
 
+// GetAuthType returns the auth type, defaulting to ApiKey
+func (i *N8nInstance) GetAuthType() AuthType {
+	if i.Spec.Credentials.AuthType != "" {
+		return i.Spec.Credentials.AuthType
+	}
+	return AuthTypeAPIKey
+}
+
+// GetUsernameKey returns the secret key of the Basic auth username, defaulting to "username"
+func (i *N8nInstance) GetUsernameKey() string {
+	if i.Spec.Credentials.UsernameKey != "" {
+		return i.Spec.Credentials.UsernameKey
+	}
+	return "username"
+}
+
+// GetPasswordKey returns the secret key of the Basic auth password, defaulting to "password"
+func (i *N8nInstance) GetPasswordKey() string {
+	if i.Spec.Credentials.PasswordKey != "" {
+		return i.Spec.Credentials.PasswordKey
+	}
+	return "password"
+}
+
+// GetTokenKey returns the secret key of the bearer token, defaulting to "token"
+func (i *N8nInstance) GetTokenKey() string {
+	if i.Spec.Credentials.TokenKey != "" {
+		return i.Spec.Credentials.TokenKey
+	}
+	return "token"
+}
+
 func init() {
 	SchemeBuilder.Register(&N8nInstance{}, &N8nInstanceList{})
 }
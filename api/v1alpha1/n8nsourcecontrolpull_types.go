@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// N8nSourceControlPullSpec defines the desired state of N8nSourceControlPull
+type N8nSourceControlPullSpec struct {
+	// InstanceRef references an N8nInstance by name
+	// The N8nInstance must exist in the operator namespace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	InstanceRef string `json:"instanceRef"`
+
+	// Force overwrites changes made in n8n since the last push. Without it a
+	// pull that would overwrite them fails with PullConflict.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// PulledItem is a workflow, credential or tag imported by a pull
+type PulledItem struct {
+	// ID of the item in n8n
+	ID string `json:"id"`
+
+	// Name of the item
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Type is the credential type, only set for credentials
+	// +optional
+	Type string `json:"type,omitempty"`
+}
+
+// N8nSourceControlPullStatus defines the observed state of N8nSourceControlPull
+type N8nSourceControlPullStatus struct {
+	// The generation the last pull was made for. Each generation is pulled once.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// CompletionTime is when the last pull finished, successfully or not
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Workflows imported by the pull
+	// +optional
+	Workflows []PulledItem `json:"workflows,omitempty"`
+
+	// Credentials imported by the pull
+	// +optional
+	Credentials []PulledItem `json:"credentials,omitempty"`
+
+	// AddedVariables are the keys of the variables the pull created
+	// +optional
+	AddedVariables []string `json:"addedVariables,omitempty"`
+
+	// ChangedVariables are the keys of the variables the pull changed
+	// +optional
+	ChangedVariables []string `json:"changedVariables,omitempty"`
+
+	// Tags imported by the pull
+	// +optional
+	Tags []PulledItem `json:"tags,omitempty"`
+
+	// Conditions of the pull
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Condition types for N8nSourceControlPull
+const (
+	// ConditionTypeCompleted indicates whether the pull has finished
+	ConditionTypeCompleted = "Completed"
+)
+
+// Condition reasons for N8nSourceControlPull
+const (
+	ReasonPullSucceeded            = "PullSucceeded"
+	ReasonPullFailed               = "PullFailed"
+	ReasonPullConflict             = "PullConflict"
+	ReasonSourceControlUnavailable = "SourceControlUnavailable"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=n8npull
+// +kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.instanceRef`
+// +kubebuilder:printcolumn:name="Completed",type=string,JSONPath=`.status.conditions[?(@.type=="Completed")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Completed")].reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// N8nSourceControlPull is the Schema for the n8nsourcecontrolpulls API. It
+// pulls the git repository connected to an n8n instance's source control,
+// once per generation, like a Job.
+type N8nSourceControlPull struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   N8nSourceControlPullSpec   `json:"spec"`
+	Status N8nSourceControlPullStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// N8nSourceControlPullList contains a list of N8nSourceControlPull
+type N8nSourceControlPullList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []N8nSourceControlPull `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&N8nSourceControlPull{}, &N8nSourceControlPullList{})
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// N8nTagSpec defines the desired state of N8nTag
+type N8nTagSpec struct {
+	// InstanceRef references an N8nInstance by name
+	// The N8nInstance must exist in the operator namespace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	InstanceRef string `json:"instanceRef"`
+
+	// Name is the tag name in n8n, as used in spec.workflow.tags of
+	// N8nWorkflows. Changing it renames the tag.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=24
+	Name string `json:"name"`
+}
+
+// N8nTagStatus defines the observed state of N8nTag
+type N8nTagStatus struct {
+	// TagID is the ID of the tag in n8n
+	// +optional
+	TagID string `json:"tagId,omitempty"`
+
+	// The generation observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions of the tag
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=n8ntag
+// +kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.instanceRef`
+// +kubebuilder:printcolumn:name="Tag Name",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Tag ID",type=string,JSONPath=`.status.tagId`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// N8nTag is the Schema for the n8ntags API
+type N8nTag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   N8nTagSpec   `json:"spec"`
+	Status N8nTagStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// N8nTagList contains a list of N8nTag
+type N8nTagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []N8nTag `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&N8nTag{}, &N8nTagList{})
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // SyncPolicy defines how the operator syncs workflows with n8n
-// +kubebuilder:validation:Enum=Always;CreateOnly;Manual
+// +kubebuilder:validation:Enum=Always;CreateOnly;Manual;Report
 type SyncPolicy string
 
 const (
@@ -37,8 +40,194 @@ const (
 	// SyncPolicyManual pauses all sync operations
 	// Useful during active development in the UI
 	SyncPolicyManual SyncPolicy = "Manual"
+
+	// SyncPolicyReport continuously reports drift from the spec in status
+	// without ever writing to n8n, as an audit mode
+	SyncPolicyReport SyncPolicy = "Report"
+)
+
+// DeletionPolicy defines what happens to the n8n workflow when its N8nWorkflow
+// is deleted
+// +kubebuilder:validation:Enum=Delete;Orphan;Deactivate
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the workflow from n8n (default)
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyOrphan leaves the workflow in n8n untouched
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+
+	// DeletionPolicyDeactivate deactivates the workflow and leaves it in n8n,
+	// so its triggers stop firing but it can still be inspected or restored
+	DeletionPolicyDeactivate DeletionPolicy = "Deactivate"
+)
+
+// CallerPolicy controls which workflows may call this workflow as a sub-workflow
+// +kubebuilder:validation:Enum=any;none;workflowsFromSameOwner;workflowsFromAList
+type CallerPolicy string
+
+const (
+	// CallerPolicyAny allows any workflow to call this workflow
+	CallerPolicyAny CallerPolicy = "any"
+
+	// CallerPolicyNone prevents other workflows from calling this workflow
+	CallerPolicyNone CallerPolicy = "none"
+
+	// CallerPolicyWorkflowsFromSameOwner only allows workflows with the same owner
+	CallerPolicyWorkflowsFromSameOwner CallerPolicy = "workflowsFromSameOwner"
+
+	// CallerPolicyWorkflowsFromAList only allows the workflows listed in CallerIDs
+	CallerPolicyWorkflowsFromAList CallerPolicy = "workflowsFromAList"
 )
 
+// WebhookAuthType is the authentication method of an n8n webhook node
+// +kubebuilder:validation:Enum=none;basicAuth;headerAuth;jwtAuth
+type WebhookAuthType string
+
+const (
+	// WebhookAuthNone accepts unauthenticated webhook calls
+	WebhookAuthNone WebhookAuthType = "none"
+
+	// WebhookAuthBasic requires HTTP basic auth (httpBasicAuth credential)
+	WebhookAuthBasic WebhookAuthType = "basicAuth"
+
+	// WebhookAuthHeader requires a fixed header value (httpHeaderAuth credential)
+	WebhookAuthHeader WebhookAuthType = "headerAuth"
+
+	// WebhookAuthJWT requires a signed JWT (jwtAuth credential)
+	WebhookAuthJWT WebhookAuthType = "jwtAuth"
+)
+
+// CredentialReference identifies an n8n credential, either by its ID or
+// through the N8nCredential managing it
+// +kubebuilder:validation:XValidation:rule="has(self.id) != has(self.credentialRef)",message="exactly one of id or credentialRef must be set"
+type CredentialReference struct {
+	// ID is the n8n credential ID
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the credential's display name in n8n
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// CredentialRef is the name of an N8nCredential in the workflow's namespace
+	// whose status.credentialId is used. The workflow waits until the
+	// credential is Ready.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	CredentialRef string `json:"credentialRef,omitempty"`
+}
+
+// WebhookAuth declares the authentication applied to webhook nodes
+type WebhookAuth struct {
+	// Type is the authentication method
+	Type WebhookAuthType `json:"type"`
+
+	// Credential holds the secret values checked by n8n. Required unless type is none.
+	// +optional
+	Credential *CredentialReference `json:"credential,omitempty"`
+
+	// Nodes limits the configuration to the named webhook nodes. All webhook
+	// nodes are configured when empty.
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// SubWorkflowRef points an executeWorkflow node at another N8nWorkflow, so the
+// environment-specific n8n workflow ID doesn't have to be hard-coded
+type SubWorkflowRef struct {
+	// Node is the name of the executeWorkflow node to rewrite
+	// +kubebuilder:validation:MinLength=1
+	Node string `json:"node"`
+
+	// WorkflowRef is the name of an N8nWorkflow in the same namespace whose
+	// status.workflowId is injected into the node's workflowId parameter
+	// +kubebuilder:validation:MinLength=1
+	WorkflowRef string `json:"workflowRef"`
+}
+
+// JSONPatchOperation is a single RFC 6902 JSON Patch operation
+type JSONPatchOperation struct {
+	// Op is the operation to perform
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+	Op string `json:"op"`
+
+	// Path is the JSON Pointer to the target location, e.g. /nodes/0/parameters/url
+	Path string `json:"path"`
+
+	// From is the source location for move and copy operations
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// Value for add, replace and test operations
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Value *runtime.RawExtension `json:"value,omitempty"`
+}
+
+// PinDataSource selects a ConfigMap or Secret key holding pinData JSON.
+// Exactly one of the references must be set.
+type PinDataSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// VariableSource loads workflow variables from every key of a ConfigMap or
+// Secret, like a container's envFrom. Exactly one of the references must be set.
+type VariableSource struct {
+	// ConfigMapRef selects a ConfigMap in the workflow's namespace
+	// +optional
+	ConfigMapRef *corev1.ConfigMapEnvSource `json:"configMapRef,omitempty"`
+
+	// SecretRef selects a Secret in the workflow's namespace. Its values are
+	// never written to status or events.
+	// +optional
+	SecretRef *corev1.SecretEnvSource `json:"secretRef,omitempty"`
+}
+
+// BaseWorkflowSource selects a ConfigMap key holding an n8n workflow export
+// the workflow is built from
+type BaseWorkflowSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the workflow's namespace.
+	// The sync waits until the key exists, even if it is marked optional.
+	// +kubebuilder:validation:Required
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// WorkflowTemplateReference selects the N8nWorkflowTemplate a workflow is
+// rendered from
+type WorkflowTemplateReference struct {
+	// Name of an N8nWorkflowTemplate in the workflow's namespace
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// SyncWindow is a recurring period during which updates are pushed to n8n
+type SyncWindow struct {
+	// Schedule is a five-field cron expression (minute hour day-of-month month
+	// day-of-week) for when each window opens, e.g. "0 9 * * 1-5" for 09:00 on
+	// weekdays
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration is how long each window stays open, e.g. "8h"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1m') && duration(self) <= duration('168h')",message="duration must be between 1m and 168h"
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g.
+	// "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // WorkflowSpec defines the n8n workflow specification
 type WorkflowSpec struct {
 	// Name of the workflow (must be unique in n8n)
@@ -69,40 +258,312 @@ type WorkflowSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
 	PinData *runtime.RawExtension `json:"pinData,omitempty"`
+
+	// PinDataFrom loads the pinned data JSON from a ConfigMap or Secret key in the
+	// workflow's namespace, for fixtures too large to keep in the resource.
+	// Mutually exclusive with pinData. Changes to the source trigger a re-sync.
+	// +optional
+	PinDataFrom *PinDataSource `json:"pinDataFrom,omitempty"`
+
+	// CallerPolicy restricts which workflows may call this one as a sub-workflow
+	// Injected into settings.callerPolicy, overriding any value set there
+	// +optional
+	CallerPolicy CallerPolicy `json:"callerPolicy,omitempty"`
+
+	// CallerIDs lists the n8n workflow IDs allowed to call this workflow
+	// Required when callerPolicy is workflowsFromAList, ignored otherwise
+	// +optional
+	CallerIDs []string `json:"callerIds,omitempty"`
+
+	// SubWorkflowRefs rewrite the workflowId of executeWorkflow nodes to the n8n
+	// ID of the referenced N8nWorkflow. Sync waits until every referenced
+	// workflow has been created in n8n.
+	// +optional
+	SubWorkflowRefs []SubWorkflowRef `json:"subWorkflowRefs,omitempty"`
+
+	// WebhookAuth sets the authentication parameter and credential of webhook
+	// nodes, overriding whatever the node JSON contains
+	// +optional
+	WebhookAuth *WebhookAuth `json:"webhookAuth,omitempty"`
+
+	// BaseFrom loads the nodes, connections and settings from an n8n workflow
+	// export kept in a ConfigMap, so the resource only holds the patches that
+	// tweak it. Nodes and connections must then be empty; settings, when set,
+	// replace the base's. Changes to the ConfigMap trigger a re-sync.
+	// +optional
+	BaseFrom *BaseWorkflowSource `json:"baseFrom,omitempty"`
+
+	// Patches are RFC 6902 JSON Patch operations applied, in order, to the
+	// assembled n8n workflow JSON before it is synced. Use them to tweak a
+	// shared base workflow per environment.
+	// +optional
+	Patches []JSONPatchOperation `json:"patches,omitempty"`
+
+	// Tags are the names of the n8n tags attached to the workflow. Missing tags
+	// are created in n8n. When unset, tags in n8n are left alone unless the
+	// operator attached them before, in which case they are removed. Tags
+	// starting with "folder:" are reserved for spec.folder and ignored here.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Variables replace ${NAME} placeholders in string values of the node JSON
+	// before the workflow is sent to n8n. They take precedence over variablesFrom.
+	// Placeholders are only replaced when variables or variablesFrom is set, and
+	// $${NAME} yields a literal ${NAME}. An undefined placeholder fails the sync.
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// VariablesFrom loads variables from ConfigMaps and Secrets in the workflow's
+	// namespace. Later sources override earlier ones. Changes to a source
+	// trigger a re-sync.
+	// +optional
+	VariablesFrom []VariableSource `json:"variablesFrom,omitempty"`
 }
 
 // N8nWorkflowSpec defines the desired state of N8nWorkflow
 type N8nWorkflowSpec struct {
 	// InstanceRef references an N8nInstance by name
-	// The N8nInstance must exist in the operator namespace
-	// +kubebuilder:validation:Required
+	// The N8nInstance must exist in the operator namespace. When unset, the
+	// N8nInstance in the workflow's namespace labeled
+	// n8n.slys.dev/default-instance: "true" is used, else the only N8nInstance
+	// in that namespace, else the operator's --default-instance.
 	// +kubebuilder:validation:MinLength=1
-	InstanceRef string `json:"instanceRef"`
+	// +optional
+	InstanceRef string `json:"instanceRef,omitempty"`
 
 	// SyncPolicy defines how the operator handles synchronization with n8n
 	// - Always: Continuously sync, overwriting UI changes (default)
 	// - CreateOnly: Create workflow but never update, allowing UI edits
 	// - Manual: Pause all sync operations
+	// - Report: Report drift from the spec without writing to n8n
 	// +kubebuilder:default=Always
 	// +optional
 	SyncPolicy SyncPolicy `json:"syncPolicy,omitempty"`
 
+	// DeletionPolicy defines what happens to the workflow in n8n when this
+	// resource is deleted
+	// - Delete: Delete the workflow from n8n (default)
+	// - Orphan: Leave the workflow in n8n as it is
+	// - Deactivate: Deactivate the workflow and leave it in n8n
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
 	// Whether the workflow should be active
 	// +kubebuilder:default=true
 	// +optional
 	Active bool `json:"active,omitempty"`
 
+	// ActiveIn limits activation to matching environments. Each entry is a glob
+	// matched against the operator's environment name and the workflow's
+	// namespace; the workflow is only activated when active is set and an entry
+	// matches. Empty means every environment.
+	// +optional
+	ActiveIn []string `json:"activeIn,omitempty"`
+
+	// ImportFromID is the n8n ID of an existing workflow to bring under
+	// management. While workflow.nodes is empty, the workflow is exported once
+	// to a ConfigMap for copying into this spec instead of being synced. Once
+	// nodes are set, that workflow is the one updated.
+	// +optional
+	ImportFromID string `json:"importFromId,omitempty"`
+
+	// ExecutionFailureThreshold is the number of consecutive failed executions
+	// after which the ExecutionHealthy condition turns False
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExecutionFailureThreshold int32 `json:"executionFailureThreshold,omitempty"`
+
+	// ProjectID is the ID of the n8n project the workflow belongs in. The
+	// workflow is transferred there after it is created or adopted. Projects
+	// require an n8n enterprise license.
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+
+	// Folder groups the workflow without enterprise projects. It is attached
+	// as the reserved tag "folder:<folder>", replacing any other folder tag,
+	// so workflows can be filtered by folder in n8n.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=17
+	// +optional
+	Folder string `json:"folder,omitempty"`
+
+	// ReconcileInterval is how often the workflow is re-checked against n8n
+	// after a reconcile, e.g. "1m" for tight drift detection or "1h" for
+	// workflows that rarely change. Defaults to 5m; must be at least 10s.
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('10s')",message="reconcileInterval must be at least 10s"
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// SyncWindow limits when changes are pushed to an existing workflow. Outside
+	// the window, updates and drift corrections wait until it next opens, while
+	// creating the workflow and changing its activation proceed. The force-sync
+	// and create-only-override annotations still update it at once.
+	// +optional
+	SyncWindow *SyncWindow `json:"syncWindow,omitempty"`
+
+	// BackupBeforeUpdate copies the workflow in n8n to an inactive workflow
+	// named "<name>-backup-<timestamp>" before each update, including drift
+	// corrections, so the overwritten version can be recovered. Backups are
+	// never deleted by the operator.
+	// +optional
+	BackupBeforeUpdate bool `json:"backupBeforeUpdate,omitempty"`
+
+	// TemplateRef renders the nodes, connections and settings of the workflow
+	// from an N8nWorkflowTemplate in the same namespace. workflow.nodes and
+	// workflow.connections must be empty; workflow.settings, when set, replaces
+	// the template's. The rest of workflow applies as usual.
+	// +optional
+	TemplateRef *WorkflowTemplateReference `json:"templateRef,omitempty"`
+
+	// Parameters replace the ${params.NAME} placeholders of the template. Every
+	// parameter the template declares without a default must be set, and
+	// parameters the template doesn't declare are rejected.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
 	// The n8n workflow definition
 	// +kubebuilder:validation:Required
 	Workflow WorkflowSpec `json:"workflow"`
 }
 
+// ReconcileTimings records how long each phase of the last successful reconcile
+// took, in milliseconds. Phases that did not run in that pass are reported as 0.
+type ReconcileTimings struct {
+	// Time spent resolving the N8nInstance and reading the API key secret
+	SecretFetchMillis int64 `json:"secretFetchMillis"`
+
+	// Time spent looking up the existing workflow in n8n
+	ListMillis int64 `json:"listMillis"`
+
+	// Time spent converting the CRD spec into an n8n workflow
+	ConvertMillis int64 `json:"convertMillis"`
+
+	// Time spent creating or updating the workflow in n8n
+	UpdateMillis int64 `json:"updateMillis"`
+
+	// Time spent activating or deactivating the workflow in n8n
+	ActivateMillis int64 `json:"activateMillis"`
+
+	// Total wall-clock time of the reconcile
+	TotalMillis int64 `json:"totalMillis"`
+}
+
+// OverrideRecord audits a one-shot update applied under the CreateOnly sync policy
+type OverrideRecord struct {
+	// Token is the annotation value that triggered the update
+	Token string `json:"token"`
+
+	// Time the update was applied
+	Time metav1.Time `json:"time"`
+
+	// SpecHash of the workflow spec that was pushed
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+}
+
+// ImportRecord records the export of an existing n8n workflow requested by
+// spec.importFromId
+type ImportRecord struct {
+	// WorkflowID is the n8n ID of the imported workflow
+	WorkflowID string `json:"workflowId"`
+
+	// ConfigMap is the name of the ConfigMap in the workflow's namespace
+	// holding the workflow definition under the workflow.json key
+	ConfigMap string `json:"configMap"`
+
+	// Time the workflow was imported
+	Time metav1.Time `json:"time"`
+}
+
+// SyncRecord is one sync attempt in status.syncHistory
+type SyncRecord struct {
+	// Time the attempt finished
+	Time metav1.Time `json:"time"`
+
+	// Action lists the steps taken in n8n, comma-separated, e.g.
+	// "update,activate", or "none" when the workflow already matched the spec.
+	// The last step of a failed attempt is the one that failed.
+	Action string `json:"action"`
+
+	// Result is Succeeded or Failed
+	// +kubebuilder:validation:Enum=Succeeded;Failed
+	Result string `json:"result"`
+
+	// Message is the error of a failed attempt
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Results of a SyncRecord
+const (
+	SyncResultSucceeded = "Succeeded"
+	SyncResultFailed    = "Failed"
+)
+
+// WorkflowCredential is a credential referenced by a node of the workflow in n8n
+type WorkflowCredential struct {
+	// ID is the n8n credential ID, empty when n8n only recorded the name
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the credential name as saved in the node
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Type is the credential type, e.g. httpBasicAuth
+	Type string `json:"type"`
+}
+
+// WorkflowWebhook is an endpoint a webhook or form trigger node of the
+// workflow listens on in n8n
+type WorkflowWebhook struct {
+	// Node is the name of the trigger node
+	Node string `json:"node"`
+
+	// Type is the node type, e.g. n8n-nodes-base.webhook
+	Type string `json:"type"`
+
+	// Methods are the HTTP methods the node accepts
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+
+	// ResponseMode is when the node responds: onReceived, lastNode or responseNode
+	// +optional
+	ResponseMode string `json:"responseMode,omitempty"`
+
+	// ProductionURL is called while the workflow is active
+	ProductionURL string `json:"productionUrl"`
+
+	// TestURL is called while the editor listens for a test event
+	TestURL string `json:"testUrl"`
+}
+
 // N8nWorkflowStatus defines the observed state of N8nWorkflow
 type N8nWorkflowStatus struct {
 	// The n8n internal workflow ID
 	// +optional
 	WorkflowID string `json:"workflowId,omitempty"`
 
+	// DefaultInstance is the namespace/name of the N8nInstance resolved for a
+	// workflow without instanceRef. It is kept once the workflow exists in n8n,
+	// so a new default doesn't move the workflow.
+	// +optional
+	DefaultInstance string `json:"defaultInstance,omitempty"`
+
+	// EffectiveName is the name the workflow is synced under in n8n when the
+	// n8n.slys.dev/unique-name annotation is set. It differs from
+	// spec.workflow.name when that name is taken by a workflow the resource
+	// doesn't own.
+	// +optional
+	EffectiveName string `json:"effectiveName,omitempty"`
+
+	// BackupWorkflowID is the n8n ID of the latest copy made before an update
+	// under spec.backupBeforeUpdate
+	// +optional
+	BackupWorkflowID string `json:"backupWorkflowId,omitempty"`
+
 	// Whether the workflow is currently active in n8n
 	// +optional
 	Active bool `json:"active,omitempty"`
@@ -111,10 +572,31 @@ type N8nWorkflowStatus struct {
 	// +optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
-	// The webhook URL if the workflow has a webhook trigger
+	// Last time the operator actually changed the workflow in n8n
+	// (create, update, activate, deactivate or delete). Unlike LastSyncTime,
+	// no-op reconciles leave this untouched.
+	// +optional
+	LastMutationTime *metav1.Time `json:"lastMutationTime,omitempty"`
+
+	// The production URL of the first webhook in Webhooks, if any
 	// +optional
 	WebhookURL string `json:"webhookUrl,omitempty"`
 
+	// Webhooks lists the endpoints of every enabled webhook and form trigger
+	// node of the workflow in n8n, under the instance's webhook base URL
+	// +optional
+	Webhooks []WorkflowWebhook `json:"webhooks,omitempty"`
+
+	// NodeCount is the number of nodes of the workflow in n8n, not counting
+	// sticky notes
+	// +optional
+	NodeCount int32 `json:"nodeCount"`
+
+	// TriggerTypes lists the node types of the enabled trigger nodes of the
+	// workflow in n8n, e.g. n8n-nodes-base.webhook, sorted and without duplicates
+	// +optional
+	TriggerTypes []string `json:"triggerTypes,omitempty"`
+
 	// The generation observed by the controller
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -124,6 +606,76 @@ type N8nWorkflowStatus struct {
 	// +optional
 	SpecHash string `json:"specHash,omitempty"`
 
+	// Per-phase durations of the last successful reconcile, for diagnosing
+	// slow syncs without enabling debug logging
+	// +optional
+	Timings *ReconcileTimings `json:"timings,omitempty"`
+
+	// SyncHistory lists the most recent sync attempts, newest first. Syncs in
+	// a row that changed nothing share one entry with the time of the latest.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	SyncHistory []SyncRecord `json:"syncHistory,omitempty"`
+
+	// LastOverride records the most recent update forced under CreateOnly by
+	// changing the n8n.slys.dev/create-only-override annotation
+	// +optional
+	LastOverride *OverrideRecord `json:"lastOverride,omitempty"`
+
+	// LastForceSync records the most recent update forced by setting the
+	// n8n.slys.dev/force-sync annotation. An annotation value equal to its
+	// token has already been handled.
+	// +optional
+	LastForceSync *OverrideRecord `json:"lastForceSync,omitempty"`
+
+	// Import records the export of the workflow named by spec.importFromId
+	// +optional
+	Import *ImportRecord `json:"import,omitempty"`
+
+	// DriftDetails lists how the workflow in n8n differs from the spec, as found
+	// by the last reconcile under the Report sync policy, or how it differed
+	// before being overwritten under the Always sync policy
+	// +optional
+	DriftDetails []string `json:"driftDetails,omitempty"`
+
+	// DriftDetected is set once the workflow in n8n was found to differ from an
+	// unchanged spec, i.e. it was edited outside the operator
+	// +optional
+	DriftDetected bool `json:"driftDetected,omitempty"`
+
+	// LastDriftTime is when the workflow was last found to differ from the spec
+	// +optional
+	LastDriftTime *metav1.Time `json:"lastDriftTime,omitempty"`
+
+	// TagIDs maps each tag name in the spec to the ID of the n8n tag attached
+	// to the workflow
+	// +optional
+	TagIDs map[string]string `json:"tagIds,omitempty"`
+
+	// LastExecutionID is the ID of the most recent execution of the workflow,
+	// checked while the workflow is active
+	// +optional
+	LastExecutionID string `json:"lastExecutionId,omitempty"`
+
+	// LastExecutionStatus is the status of the most recent execution, e.g.
+	// success, error, running or waiting
+	// +optional
+	LastExecutionStatus string `json:"lastExecutionStatus,omitempty"`
+
+	// LastExecutionTime is when the most recent execution started
+	// +optional
+	LastExecutionTime *metav1.Time `json:"lastExecutionTime,omitempty"`
+
+	// ProjectID is the ID of the n8n project the workflow was last seen in or
+	// transferred to
+	// +optional
+	ProjectID string `json:"projectId,omitempty"`
+
+	// Credentials lists the credentials the workflow's nodes reference in n8n,
+	// so reviewers can check it only uses approved credentials
+	// +optional
+	Credentials []WorkflowCredential `json:"credentials,omitempty"`
+
 	// Conditions of the workflow
 	// +listType=map
 	// +listMapKey=type
@@ -138,17 +690,108 @@ const (
 
 	// ConditionTypeSynced indicates the workflow has been synced to n8n
 	ConditionTypeSynced = "Synced"
+
+	// ConditionTypeDrift indicates whether the workflow in n8n differs from the spec
+	ConditionTypeDrift = "Drift"
+
+	// ConditionTypePolicyNotAllowed indicates the requested sync policy is not
+	// allowed by the instance and a fallback policy is in effect
+	ConditionTypePolicyNotAllowed = "PolicyNotAllowed"
+
+	// ConditionTypeProtectedName indicates the workflow name is protected by the
+	// instance and the workflow is not managed
+	ConditionTypeProtectedName = "ProtectedName"
+
+	// ConditionTypeExecutionHealthy indicates whether the recent executions of
+	// an active workflow are succeeding
+	ConditionTypeExecutionHealthy = "ExecutionHealthy"
+
+	// ConditionTypeWaiting indicates the workflow is waiting for its instance to
+	// become Ready before syncing
+	ConditionTypeWaiting = "Waiting"
+
+	// ConditionTypeConflict indicates another N8nWorkflow targets the same n8n
+	// workflow and this one is not synced
+	ConditionTypeConflict = "Conflict"
+
+	// ConditionTypePolicyViolation indicates the workflow breaks the operator's
+	// or instance's policy, e.g. by using blocked node types or exceeding the
+	// execution timeout ceiling, and is not synced
+	ConditionTypePolicyViolation = "PolicyViolation"
+
+	// ConditionTypeSettingsAdjusted indicates the operator changed the
+	// workflow's settings to comply with policy before syncing it
+	ConditionTypeSettingsAdjusted = "SettingsAdjusted"
+
+	// ConditionTypeGraphInvalid indicates the workflow's connections reference
+	// missing nodes, form a cycle with no way out or leave nodes unreachable
+	ConditionTypeGraphInvalid = "GraphInvalid"
+
+	// ConditionTypeActivationDrift indicates whether the last sync had to
+	// activate or deactivate a workflow whose state was changed in n8n, e.g.
+	// deactivated in the UI, to match spec.active
+	ConditionTypeActivationDrift = "ActivationDrift"
+
+	// ConditionTypeOutsideSyncWindow indicates the time is outside the
+	// workflow's sync window, so updates wait until the window next opens
+	ConditionTypeOutsideSyncWindow = "OutsideSyncWindow"
+
+	// ConditionTypeReadOnlyAPIKey indicates the instance's API key was found
+	// to be read-only, so changes to the workflow can't be synced
+	ConditionTypeReadOnlyAPIKey = "ReadOnlyAPIKey"
 )
 
 // Condition reasons
 const (
-	ReasonSyncSucceeded   = "SyncSucceeded"
-	ReasonSyncFailed      = "SyncFailed"
-	ReasonActivated       = "Activated"
-	ReasonDeactivated     = "Deactivated"
-	ReasonActivationError = "ActivationError"
-	ReasonAPIError        = "APIError"
-	ReasonDeleting        = "Deleting"
+	ReasonSyncSucceeded             = "SyncSucceeded"
+	ReasonSyncFailed                = "SyncFailed"
+	ReasonActivated                 = "Activated"
+	ReasonDeactivated               = "Deactivated"
+	ReasonActivationError           = "ActivationError"
+	ReasonAPIError                  = "APIError"
+	ReasonDeleting                  = "Deleting"
+	ReasonServerReadOnly            = "ServerReadOnly"
+	ReasonSubWorkflowPending        = "SubWorkflowPending"
+	ReasonCredentialPending         = "CredentialPending"
+	ReasonSecretDetected            = "SecretDetected"
+	ReasonURLMigrationPending       = "URLMigrationPending"
+	ReasonExceedsNodeLimit          = "ExceedsNodeLimit"
+	ReasonInsufficientScope         = "InsufficientScope"
+	ReasonReadOnlyAPIKey            = "ReadOnlyAPIKey"
+	ReasonPolicyNotAllowed          = "PolicyNotAllowed"
+	ReasonDriftDetected             = "DriftDetected"
+	ReasonInSync                    = "InSync"
+	ReasonReportOnly                = "ReportOnly"
+	ReasonReconcileTimeout          = "ReconcileTimeout"
+	ReasonPinDataUnavailable        = "PinDataUnavailable"
+	ReasonProtectedName             = "ProtectedName"
+	ReasonSecretUnavailable         = "SecretUnavailable"
+	ReasonInvalidCredentialType     = "InvalidCredentialType"
+	ReasonInvalidCredentialData     = "InvalidCredentialData"
+	ReasonInstanceNotFound          = "InstanceNotFound"
+	ReasonInstanceAmbiguous         = "InstanceAmbiguous"
+	ReasonInstanceNotReady          = "InstanceNotReady"
+	ReasonImported                  = "Imported"
+	ReasonVariablesUnavailable      = "VariablesUnavailable"
+	ReasonExecutionsFailing         = "ExecutionsFailing"
+	ReasonExecutionsSucceeding      = "ExecutionsSucceeding"
+	ReasonProjectsUnavailable       = "ProjectsUnavailable"
+	ReasonDuplicateTarget           = "DuplicateTarget"
+	ReasonNodeTypeNotAllowed        = "NodeTypeNotAllowed"
+	ReasonNodeTypePolicyUnavailable = "NodeTypePolicyUnavailable"
+	ReasonBlockedNodeType           = "BlockedNodeType"
+	ReasonGraphInvalid              = "GraphInvalid"
+	ReasonUnreachableNodes          = "UnreachableNodes"
+	ReasonExecutionTimeoutExceeded  = "ExecutionTimeoutExceeded"
+	ReasonExecutionTimeoutClamped   = "ExecutionTimeoutClamped"
+	ReasonActivationDrift           = "ActivationDrift"
+	ReasonTemplateUnavailable       = "TemplateUnavailable"
+	ReasonTemplateParametersInvalid = "TemplateParametersInvalid"
+	ReasonBaseWorkflowUnavailable   = "BaseWorkflowUnavailable"
+	ReasonPatchFailed               = "PatchFailed"
+	ReasonSyncWindowClosed          = "SyncWindowClosed"
+	ReasonSyncDeferred              = "SyncDeferred"
+	ReasonInvalidSyncWindow         = "InvalidSyncWindow"
 )
 
 // +kubebuilder:object:root=true
@@ -156,9 +799,14 @@ const (
 // +kubebuilder:resource:shortName=n8nwf;wf
 // +kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.instanceRef`
 // +kubebuilder:printcolumn:name="Workflow Name",type=string,JSONPath=`.spec.workflow.name`
+// +kubebuilder:printcolumn:name="Folder",type=string,JSONPath=`.spec.folder`
 // +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="Last Execution",type=string,JSONPath=`.status.lastExecutionStatus`
 // +kubebuilder:printcolumn:name="Sync Policy",type=string,JSONPath=`.spec.syncPolicy`
 // +kubebuilder:printcolumn:name="Workflow ID",type=string,JSONPath=`.status.workflowId`
+// +kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.nodeCount`
+// +kubebuilder:printcolumn:name="Drift",type=boolean,JSONPath=`.status.driftDetected`
+// +kubebuilder:printcolumn:name="Webhook URL",type=string,JSONPath=`.status.webhookUrl`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // N8nWorkflow is the Schema for the n8nworkflows API
@@ -183,3 +831,46 @@ type N8nWorkflowList struct {
 func init() {
 	SchemeBuilder.Register(&N8nWorkflow{}, &N8nWorkflowList{})
 }
+
+// GetExecutionFailureThreshold returns the number of consecutive failed
+// executions that mark the workflow unhealthy
+func (w *N8nWorkflow) GetExecutionFailureThreshold() int {
+	if w.Spec.ExecutionFailureThreshold < 1 {
+		return 3
+	}
+	return int(w.Spec.ExecutionFailureThreshold)
+}
+
+// GetDeletionPolicy returns the deletion policy, defaulting to Delete
+func (w *N8nWorkflow) GetDeletionPolicy() DeletionPolicy {
+	if w.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return w.Spec.DeletionPolicy
+}
+
+const (
+	// DefaultReconcileInterval is the reconcile and health check interval of
+	// resources that don't set their own
+	DefaultReconcileInterval = 5 * time.Minute
+
+	// MinReconcileInterval is the shortest reconcile or health check interval
+	// allowed, to protect n8n from being polled constantly
+	MinReconcileInterval = 10 * time.Second
+)
+
+// GetReconcileInterval returns how long to wait before re-checking the
+// workflow, defaulting to DefaultReconcileInterval and never below
+// MinReconcileInterval
+func (w *N8nWorkflow) GetReconcileInterval() time.Duration {
+	return intervalOrDefault(w.Spec.ReconcileInterval)
+}
+
+// intervalOrDefault returns interval when set, raised to MinReconcileInterval,
+// and DefaultReconcileInterval otherwise
+func intervalOrDefault(interval *metav1.Duration) time.Duration {
+	if interval == nil || interval.Duration <= 0 {
+		return DefaultReconcileInterval
+	}
+	return max(interval.Duration, MinReconcileInterval)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// WorkflowTemplateParameter declares a parameter of an N8nWorkflowTemplate
+type WorkflowTemplateParameter struct {
+	// Name is referenced as ${params.NAME} in the template
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Description tells users of the template what to pass
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Default is used when a workflow doesn't set the parameter. Parameters
+	// without a default are required.
+	// +optional
+	Default *string `json:"default,omitempty"`
+}
+
+// N8nWorkflowTemplateSpec defines a parameterized workflow body. ${params.NAME}
+// placeholders in any string of the nodes, connections and settings, including
+// node names, are replaced by the parameters of the N8nWorkflow using the
+// template; $${params.NAME} yields a literal ${params.NAME}.
+type N8nWorkflowTemplateSpec struct {
+	// Parameters the template accepts. Placeholders must reference a declared
+	// parameter.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Parameters []WorkflowTemplateParameter `json:"parameters,omitempty"`
+
+	// Nodes in the workflow
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Nodes []runtime.RawExtension `json:"nodes,omitempty"`
+
+	// Connections between nodes
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Connections *runtime.RawExtension `json:"connections,omitempty"`
+
+	// Settings used for workflows that set none in spec.workflow.settings
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Settings *runtime.RawExtension `json:"settings,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=n8nwft
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// N8nWorkflowTemplate is the Schema for the n8nworkflowtemplates API. It holds
+// a workflow that N8nWorkflows in the same namespace stamp out with
+// spec.templateRef, each passing its own parameters.
+type N8nWorkflowTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec N8nWorkflowTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// N8nWorkflowTemplateList contains a list of N8nWorkflowTemplate
+type N8nWorkflowTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []N8nWorkflowTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&N8nWorkflowTemplate{}, &N8nWorkflowTemplateList{})
+}
@@ -21,10 +21,156 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptTagSelector) DeepCopyInto(out *AdoptTagSelector) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptTagSelector.
+func (in *AdoptTagSelector) DeepCopy() *AdoptTagSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptTagSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditRiskSummary) DeepCopyInto(out *AuditRiskSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditRiskSummary.
+func (in *AuditRiskSummary) DeepCopy() *AuditRiskSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditRiskSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackpressureSpec) DeepCopyInto(out *BackpressureSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackpressureSpec.
+func (in *BackpressureSpec) DeepCopy() *BackpressureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackpressureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseWorkflowSource) DeepCopyInto(out *BaseWorkflowSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseWorkflowSource.
+func (in *BaseWorkflowSource) DeepCopy() *BaseWorkflowSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseWorkflowSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionPoolSpec) DeepCopyInto(out *ConnectionPoolSpec) {
+	*out = *in
+	if in.IdleConnTimeout != nil {
+		in, out := &in.IdleConnTimeout, &out.IdleConnTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionPoolSpec.
+func (in *ConnectionPoolSpec) DeepCopy() *ConnectionPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialDataSource) DeepCopyInto(out *CredentialDataSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialDataSource.
+func (in *CredentialDataSource) DeepCopy() *CredentialDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialReference) DeepCopyInto(out *CredentialReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialReference.
+func (in *CredentialReference) DeepCopy() *CredentialReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialsRef) DeepCopyInto(out *CredentialsRef) {
 	*out = *in
@@ -41,7 +187,74 @@ func (in *CredentialsRef) DeepCopy() *CredentialsRef {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nInstance) DeepCopyInto(out *N8nInstance) {
+func (in *ExecutionTimeoutPolicy) DeepCopyInto(out *ExecutionTimeoutPolicy) {
+	*out = *in
+	out.Max = in.Max
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionTimeoutPolicy.
+func (in *ExecutionTimeoutPolicy) DeepCopy() *ExecutionTimeoutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionTimeoutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportRecord) DeepCopyInto(out *ImportRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportRecord.
+func (in *ImportRecord) DeepCopy() *ImportRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceLimits) DeepCopyInto(out *InstanceLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceLimits.
+func (in *InstanceLimits) DeepCopy() *InstanceLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONPatchOperation) DeepCopyInto(out *JSONPatchOperation) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatchOperation.
+func (in *JSONPatchOperation) DeepCopy() *JSONPatchOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPatchOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nAudit) DeepCopyInto(out *N8nAudit) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -49,18 +262,18 @@ func (in *N8nInstance) DeepCopyInto(out *N8nInstance) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstance.
-func (in *N8nInstance) DeepCopy() *N8nInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nAudit.
+func (in *N8nAudit) DeepCopy() *N8nAudit {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nInstance)
+	out := new(N8nAudit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *N8nInstance) DeepCopyObject() runtime.Object {
+func (in *N8nAudit) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -68,31 +281,31 @@ func (in *N8nInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nInstanceList) DeepCopyInto(out *N8nInstanceList) {
+func (in *N8nAuditList) DeepCopyInto(out *N8nAuditList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]N8nInstance, len(*in))
+		*out = make([]N8nAudit, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstanceList.
-func (in *N8nInstanceList) DeepCopy() *N8nInstanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nAuditList.
+func (in *N8nAuditList) DeepCopy() *N8nAuditList {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nInstanceList)
+	out := new(N8nAuditList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *N8nInstanceList) DeepCopyObject() runtime.Object {
+func (in *N8nAuditList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -100,73 +313,82 @@ func (in *N8nInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nInstanceSpec) DeepCopyInto(out *N8nInstanceSpec) {
+func (in *N8nAuditSpec) DeepCopyInto(out *N8nAuditSpec) {
 	*out = *in
-	if in.ServiceRef != nil {
-		in, out := &in.ServiceRef, &out.ServiceRef
-		*out = new(ServiceRef)
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]AuditCategory, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	out.Credentials = in.Credentials
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstanceSpec.
-func (in *N8nInstanceSpec) DeepCopy() *N8nInstanceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nAuditSpec.
+func (in *N8nAuditSpec) DeepCopy() *N8nAuditSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nInstanceSpec)
+	out := new(N8nAuditSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nInstanceStatus) DeepCopyInto(out *N8nInstanceStatus) {
+func (in *N8nAuditStatus) DeepCopyInto(out *N8nAuditStatus) {
 	*out = *in
-	if in.LastHealthCheck != nil {
-		in, out := &in.LastHealthCheck, &out.LastHealthCheck
+	if in.LastAuditTime != nil {
+		in, out := &in.LastAuditTime, &out.LastAuditTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Risks != nil {
+		in, out := &in.Risks, &out.Risks
+		*out = make([]AuditRiskSummary, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstanceStatus.
-func (in *N8nInstanceStatus) DeepCopy() *N8nInstanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nAuditStatus.
+func (in *N8nAuditStatus) DeepCopy() *N8nAuditStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nInstanceStatus)
+	out := new(N8nAuditStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nWorkflow) DeepCopyInto(out *N8nWorkflow) {
+func (in *N8nCredential) DeepCopyInto(out *N8nCredential) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflow.
-func (in *N8nWorkflow) DeepCopy() *N8nWorkflow {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nCredential.
+func (in *N8nCredential) DeepCopy() *N8nCredential {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nWorkflow)
+	out := new(N8nCredential)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *N8nWorkflow) DeepCopyObject() runtime.Object {
+func (in *N8nCredential) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -174,31 +396,31 @@ func (in *N8nWorkflow) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nWorkflowList) DeepCopyInto(out *N8nWorkflowList) {
+func (in *N8nCredentialList) DeepCopyInto(out *N8nCredentialList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]N8nWorkflow, len(*in))
+		*out = make([]N8nCredential, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowList.
-func (in *N8nWorkflowList) DeepCopy() *N8nWorkflowList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nCredentialList.
+func (in *N8nCredentialList) DeepCopy() *N8nCredentialList {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nWorkflowList)
+	out := new(N8nCredentialList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *N8nWorkflowList) DeepCopyObject() runtime.Object {
+func (in *N8nCredentialList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -206,100 +428,1309 @@ func (in *N8nWorkflowList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nWorkflowSpec) DeepCopyInto(out *N8nWorkflowSpec) {
+func (in *N8nCredentialSpec) DeepCopyInto(out *N8nCredentialSpec) {
 	*out = *in
-	in.Workflow.DeepCopyInto(&out.Workflow)
+	out.Data = in.Data
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowSpec.
-func (in *N8nWorkflowSpec) DeepCopy() *N8nWorkflowSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nCredentialSpec.
+func (in *N8nCredentialSpec) DeepCopy() *N8nCredentialSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nWorkflowSpec)
+	out := new(N8nCredentialSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *N8nWorkflowStatus) DeepCopyInto(out *N8nWorkflowStatus) {
+func (in *N8nCredentialStatus) DeepCopyInto(out *N8nCredentialStatus) {
 	*out = *in
-	if in.LastSyncTime != nil {
-		in, out := &in.LastSyncTime, &out.LastSyncTime
-		*out = (*in).DeepCopy()
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowStatus.
-func (in *N8nWorkflowStatus) DeepCopy() *N8nWorkflowStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nCredentialStatus.
+func (in *N8nCredentialStatus) DeepCopy() *N8nCredentialStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(N8nWorkflowStatus)
+	out := new(N8nCredentialStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceRef) DeepCopyInto(out *ServiceRef) {
+func (in *N8nInstance) DeepCopyInto(out *N8nInstance) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRef.
-func (in *ServiceRef) DeepCopy() *ServiceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstance.
+func (in *N8nInstance) DeepCopy() *N8nInstance {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceRef)
+	out := new(N8nInstance)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
+func (in *N8nInstanceList) DeepCopyInto(out *N8nInstanceList) {
 	*out = *in
-	if in.Nodes != nil {
-		in, out := &in.Nodes, &out.Nodes
-		*out = make([]runtime.RawExtension, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]N8nInstance, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Connections != nil {
-		in, out := &in.Connections, &out.Connections
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Settings != nil {
-		in, out := &in.Settings, &out.Settings
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.StaticData != nil {
-		in, out := &in.StaticData, &out.StaticData
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PinData != nil {
-		in, out := &in.PinData, &out.PinData
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
-func (in *WorkflowSpec) DeepCopy() *WorkflowSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstanceList.
+func (in *N8nInstanceList) DeepCopy() *N8nInstanceList {
 	if in == nil {
 		return nil
 	}
-	out := new(WorkflowSpec)
+	out := new(N8nInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nInstanceSpec) DeepCopyInto(out *N8nInstanceSpec) {
+	*out = *in
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(ServiceRef)
+		**out = **in
+	}
+	out.Credentials = in.Credentials
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HealthCheckInterval != nil {
+		in, out := &in.HealthCheckInterval, &out.HealthCheckInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitSpec)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(ConnectionPoolSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdoptTagSelector != nil {
+		in, out := &in.AdoptTagSelector, &out.AdoptTagSelector
+		*out = new(AdoptTagSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanCleanup != nil {
+		in, out := &in.OrphanCleanup, &out.OrphanCleanup
+		*out = new(OrphanCleanupSpec)
+		**out = **in
+	}
+	if in.Backpressure != nil {
+		in, out := &in.Backpressure, &out.Backpressure
+		*out = new(BackpressureSpec)
+		**out = **in
+	}
+	if in.URLMigration != nil {
+		in, out := &in.URLMigration, &out.URLMigration
+		*out = new(URLMigrationSpec)
+		**out = **in
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(InstanceLimits)
+		**out = **in
+	}
+	if in.AllowedSyncPolicies != nil {
+		in, out := &in.AllowedSyncPolicies, &out.AllowedSyncPolicies
+		*out = make([]SyncPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedWorkflowNames != nil {
+		in, out := &in.ProtectedWorkflowNames, &out.ProtectedWorkflowNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockedNodeTypes != nil {
+		in, out := &in.BlockedNodeTypes, &out.BlockedNodeTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecutionTimeoutPolicy != nil {
+		in, out := &in.ExecutionTimeoutPolicy, &out.ExecutionTimeoutPolicy
+		*out = new(ExecutionTimeoutPolicy)
+		**out = **in
+	}
+	if in.DeactivateSelector != nil {
+		in, out := &in.DeactivateSelector, &out.DeactivateSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstanceSpec.
+func (in *N8nInstanceSpec) DeepCopy() *N8nInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nInstanceStatus) DeepCopyInto(out *N8nInstanceStatus) {
+	*out = *in
+	if in.LastHealthCheck != nil {
+		in, out := &in.LastHealthCheck, &out.LastHealthCheck
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHealthCheckAttempt != nil {
+		in, out := &in.LastHealthCheckAttempt, &out.LastHealthCheckAttempt
+		*out = (*in).DeepCopy()
+	}
+	if in.VersionSupported != nil {
+		in, out := &in.VersionSupported, &out.VersionSupported
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RateLimitRemaining != nil {
+		in, out := &in.RateLimitRemaining, &out.RateLimitRemaining
+		*out = new(int64)
+		**out = **in
+	}
+	if in.OrphanedWorkflows != nil {
+		in, out := &in.OrphanedWorkflows, &out.OrphanedWorkflows
+		*out = make([]OrphanedWorkflow, len(*in))
+		copy(*out, *in)
+	}
+	if in.URLMigration != nil {
+		in, out := &in.URLMigration, &out.URLMigration
+		*out = new(URLMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nInstanceStatus.
+func (in *N8nInstanceStatus) DeepCopy() *N8nInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nSourceControlPull) DeepCopyInto(out *N8nSourceControlPull) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nSourceControlPull.
+func (in *N8nSourceControlPull) DeepCopy() *N8nSourceControlPull {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nSourceControlPull)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nSourceControlPull) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nSourceControlPullList) DeepCopyInto(out *N8nSourceControlPullList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]N8nSourceControlPull, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nSourceControlPullList.
+func (in *N8nSourceControlPullList) DeepCopy() *N8nSourceControlPullList {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nSourceControlPullList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nSourceControlPullList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nSourceControlPullSpec) DeepCopyInto(out *N8nSourceControlPullSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nSourceControlPullSpec.
+func (in *N8nSourceControlPullSpec) DeepCopy() *N8nSourceControlPullSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nSourceControlPullSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nSourceControlPullStatus) DeepCopyInto(out *N8nSourceControlPullStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Workflows != nil {
+		in, out := &in.Workflows, &out.Workflows
+		*out = make([]PulledItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]PulledItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddedVariables != nil {
+		in, out := &in.AddedVariables, &out.AddedVariables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedVariables != nil {
+		in, out := &in.ChangedVariables, &out.ChangedVariables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]PulledItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nSourceControlPullStatus.
+func (in *N8nSourceControlPullStatus) DeepCopy() *N8nSourceControlPullStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nSourceControlPullStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nTag) DeepCopyInto(out *N8nTag) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nTag.
+func (in *N8nTag) DeepCopy() *N8nTag {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nTag) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nTagList) DeepCopyInto(out *N8nTagList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]N8nTag, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nTagList.
+func (in *N8nTagList) DeepCopy() *N8nTagList {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nTagList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nTagList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nTagSpec) DeepCopyInto(out *N8nTagSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nTagSpec.
+func (in *N8nTagSpec) DeepCopy() *N8nTagSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nTagSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nTagStatus) DeepCopyInto(out *N8nTagStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nTagStatus.
+func (in *N8nTagStatus) DeepCopy() *N8nTagStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nTagStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nVariable) DeepCopyInto(out *N8nVariable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nVariable.
+func (in *N8nVariable) DeepCopy() *N8nVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nVariable) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nVariableList) DeepCopyInto(out *N8nVariableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]N8nVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nVariableList.
+func (in *N8nVariableList) DeepCopy() *N8nVariableList {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nVariableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nVariableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nVariableSpec) DeepCopyInto(out *N8nVariableSpec) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nVariableSpec.
+func (in *N8nVariableSpec) DeepCopy() *N8nVariableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nVariableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nVariableStatus) DeepCopyInto(out *N8nVariableStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nVariableStatus.
+func (in *N8nVariableStatus) DeepCopy() *N8nVariableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nVariableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nWorkflow) DeepCopyInto(out *N8nWorkflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflow.
+func (in *N8nWorkflow) DeepCopy() *N8nWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nWorkflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nWorkflowList) DeepCopyInto(out *N8nWorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]N8nWorkflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowList.
+func (in *N8nWorkflowList) DeepCopy() *N8nWorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nWorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nWorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nWorkflowSpec) DeepCopyInto(out *N8nWorkflowSpec) {
+	*out = *in
+	if in.ActiveIn != nil {
+		in, out := &in.ActiveIn, &out.ActiveIn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SyncWindow != nil {
+		in, out := &in.SyncWindow, &out.SyncWindow
+		*out = new(SyncWindow)
+		**out = **in
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(WorkflowTemplateReference)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Workflow.DeepCopyInto(&out.Workflow)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowSpec.
+func (in *N8nWorkflowSpec) DeepCopy() *N8nWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nWorkflowStatus) DeepCopyInto(out *N8nWorkflowStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastMutationTime != nil {
+		in, out := &in.LastMutationTime, &out.LastMutationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]WorkflowWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TriggerTypes != nil {
+		in, out := &in.TriggerTypes, &out.TriggerTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timings != nil {
+		in, out := &in.Timings, &out.Timings
+		*out = new(ReconcileTimings)
+		**out = **in
+	}
+	if in.SyncHistory != nil {
+		in, out := &in.SyncHistory, &out.SyncHistory
+		*out = make([]SyncRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastOverride != nil {
+		in, out := &in.LastOverride, &out.LastOverride
+		*out = new(OverrideRecord)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastForceSync != nil {
+		in, out := &in.LastForceSync, &out.LastForceSync
+		*out = new(OverrideRecord)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(ImportRecord)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftDetails != nil {
+		in, out := &in.DriftDetails, &out.DriftDetails
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TagIDs != nil {
+		in, out := &in.TagIDs, &out.TagIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastExecutionTime != nil {
+		in, out := &in.LastExecutionTime, &out.LastExecutionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]WorkflowCredential, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowStatus.
+func (in *N8nWorkflowStatus) DeepCopy() *N8nWorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nWorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nWorkflowTemplate) DeepCopyInto(out *N8nWorkflowTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowTemplate.
+func (in *N8nWorkflowTemplate) DeepCopy() *N8nWorkflowTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nWorkflowTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nWorkflowTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nWorkflowTemplateList) DeepCopyInto(out *N8nWorkflowTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]N8nWorkflowTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowTemplateList.
+func (in *N8nWorkflowTemplateList) DeepCopy() *N8nWorkflowTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nWorkflowTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *N8nWorkflowTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *N8nWorkflowTemplateSpec) DeepCopyInto(out *N8nWorkflowTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]WorkflowTemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Connections != nil {
+		in, out := &in.Connections, &out.Connections
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new N8nWorkflowTemplateSpec.
+func (in *N8nWorkflowTemplateSpec) DeepCopy() *N8nWorkflowTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(N8nWorkflowTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRef) DeepCopyInto(out *ServiceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRef.
+func (in *ServiceRef) DeepCopy() *ServiceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanCleanupSpec) DeepCopyInto(out *OrphanCleanupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanCleanupSpec.
+func (in *OrphanCleanupSpec) DeepCopy() *OrphanCleanupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanCleanupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedWorkflow) DeepCopyInto(out *OrphanedWorkflow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedWorkflow.
+func (in *OrphanedWorkflow) DeepCopy() *OrphanedWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverrideRecord) DeepCopyInto(out *OverrideRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideRecord.
+func (in *OverrideRecord) DeepCopy() *OverrideRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(OverrideRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinDataSource) DeepCopyInto(out *PinDataSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinDataSource.
+func (in *PinDataSource) DeepCopy() *PinDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PinDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PulledItem) DeepCopyInto(out *PulledItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PulledItem.
+func (in *PulledItem) DeepCopy() *PulledItem {
+	if in == nil {
+		return nil
+	}
+	out := new(PulledItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileTimings) DeepCopyInto(out *ReconcileTimings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileTimings.
+func (in *ReconcileTimings) DeepCopy() *ReconcileTimings {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileTimings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubWorkflowRef) DeepCopyInto(out *SubWorkflowRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubWorkflowRef.
+func (in *SubWorkflowRef) DeepCopy() *SubWorkflowRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SubWorkflowRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncRecord) DeepCopyInto(out *SyncRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncRecord.
+func (in *SyncRecord) DeepCopy() *SyncRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncWindow) DeepCopyInto(out *SyncWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncWindow.
+func (in *SyncWindow) DeepCopy() *SyncWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CABundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLMigrationSpec) DeepCopyInto(out *URLMigrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLMigrationSpec.
+func (in *URLMigrationSpec) DeepCopy() *URLMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(URLMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLMigrationStatus) DeepCopyInto(out *URLMigrationStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.LastBatchTime != nil {
+		in, out := &in.LastBatchTime, &out.LastBatchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLMigrationStatus.
+func (in *URLMigrationStatus) DeepCopy() *URLMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(URLMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariableSource) DeepCopyInto(out *VariableSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.ConfigMapEnvSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretEnvSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariableSource.
+func (in *VariableSource) DeepCopy() *VariableSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VariableSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAuth) DeepCopyInto(out *WebhookAuth) {
+	*out = *in
+	if in.Credential != nil {
+		in, out := &in.Credential, &out.Credential
+		*out = new(CredentialReference)
+		**out = **in
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAuth.
+func (in *WebhookAuth) DeepCopy() *WebhookAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowCredential) DeepCopyInto(out *WorkflowCredential) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowCredential.
+func (in *WorkflowCredential) DeepCopy() *WorkflowCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Connections != nil {
+		in, out := &in.Connections, &out.Connections
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaticData != nil {
+		in, out := &in.StaticData, &out.StaticData
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PinData != nil {
+		in, out := &in.PinData, &out.PinData
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PinDataFrom != nil {
+		in, out := &in.PinDataFrom, &out.PinDataFrom
+		*out = new(PinDataSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CallerIDs != nil {
+		in, out := &in.CallerIDs, &out.CallerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubWorkflowRefs != nil {
+		in, out := &in.SubWorkflowRefs, &out.SubWorkflowRefs
+		*out = make([]SubWorkflowRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookAuth != nil {
+		in, out := &in.WebhookAuth, &out.WebhookAuth
+		*out = new(WebhookAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BaseFrom != nil {
+		in, out := &in.BaseFrom, &out.BaseFrom
+		*out = new(BaseWorkflowSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]JSONPatchOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VariablesFrom != nil {
+		in, out := &in.VariablesFrom, &out.VariablesFrom
+		*out = make([]VariableSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
+func (in *WorkflowSpec) DeepCopy() *WorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowTemplateParameter) DeepCopyInto(out *WorkflowTemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowTemplateParameter.
+func (in *WorkflowTemplateParameter) DeepCopy() *WorkflowTemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowTemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowTemplateReference) DeepCopyInto(out *WorkflowTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowTemplateReference.
+func (in *WorkflowTemplateReference) DeepCopy() *WorkflowTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowWebhook) DeepCopyInto(out *WorkflowWebhook) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowWebhook.
+func (in *WorkflowWebhook) DeepCopy() *WorkflowWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowWebhook)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -29,6 +30,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -37,6 +39,8 @@ import (
 
 	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
 	"github.com/jspanos/n8n-resource-operator/internal/controller"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+	webhookv1alpha1 "github.com/jspanos/n8n-resource-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -61,7 +65,32 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableWebhooks bool
+	var defaultWorkflowSettings string
 	var operatorNamespace string
+	var auditLogFile string
+	var eventVerbosity string
+	var n8nRequestsPerSecond float64
+	var n8nBurst int
+	var n8nMaxAttempts int
+	var n8nRetryBaseDelay time.Duration
+	var heartbeatLeaseName string
+	var heartbeatLeaseNamespace string
+	var heartbeatInterval time.Duration
+	var managedByKey, managedByValue string
+	var nodeOrderSignificant bool
+	var environment string
+	var nodeTypeVersions string
+	var nodeTypePolicyConfigMap string
+	var nodeTypePolicyRefresh time.Duration
+	var blockedNodeTypes string
+	var maxExecutionTimeout time.Duration
+	var executionTimeoutAction string
+	var reconcileTimeout time.Duration
+	var workflowCacheTTL time.Duration
+	var maxErrorBackoff time.Duration
+	var defaultInstance string
+	var credentialsDir string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -72,6 +101,71 @@ func main() {
 	flag.StringVar(&operatorNamespace, "operator-namespace", "",
 		"The namespace where N8nInstance resources and secrets are stored. "+
 			"Defaults to POD_NAMESPACE environment variable.")
+	flag.StringVar(&defaultInstance, "default-instance", "",
+		"Name of the N8nInstance in the operator namespace used by workflows without spec.instanceRef "+
+			"whose namespace has no default N8nInstance of its own.")
+	flag.StringVar(&credentialsDir, "credentials-dir", "",
+		"Directory in the operator pod that N8nInstance credentials.filePath may point into, e.g. /vault/secrets. "+
+			"Empty rejects credentials.filePath, so tenants can't have other files of the pod sent as API keys.")
+	flag.StringVar(&auditLogFile, "audit-log-file", "",
+		"Path of a file to append the n8n API audit log to (JSON lines). "+
+			"Defaults to the operator log under the \"audit\" logger name.")
+	flag.StringVar(&eventVerbosity, "event-verbosity", string(controller.EventVerbosityAll),
+		"Which Normal events to emit: All, ChangesOnly (only when n8n was changed) or ErrorsOnly. "+
+			"Warning events are always emitted.")
+	flag.Float64Var(&n8nRequestsPerSecond, "n8n-requests-per-second", 10,
+		"Maximum sustained request rate to each n8n instance, shared by all workflows using it. "+
+			"Zero disables rate limiting.")
+	flag.IntVar(&n8nBurst, "n8n-burst", 20, "Maximum burst of requests to each n8n instance.")
+	flag.IntVar(&n8nMaxAttempts, "n8n-max-attempts", n8n.DefaultMaxAttempts,
+		"Attempts per idempotent n8n request before a 5xx response or network error fails the reconcile.")
+	flag.DurationVar(&n8nRetryBaseDelay, "n8n-retry-base-delay", n8n.DefaultRetryBaseDelay,
+		"Delay before the first retry of an n8n request, doubled with jitter on each further retry.")
+	flag.StringVar(&heartbeatLeaseName, "heartbeat-lease-name", "",
+		"Name of a Lease renewed after successful reconciles, for external watchdogs. Empty disables the heartbeat.")
+	flag.StringVar(&heartbeatLeaseNamespace, "heartbeat-lease-namespace", "",
+		"Namespace of the heartbeat Lease. Defaults to the operator namespace.")
+	flag.DurationVar(&heartbeatInterval, "heartbeat-interval", 30*time.Second,
+		"Minimum time between heartbeat Lease renewals.")
+	flag.StringVar(&managedByKey, "managed-by-key", controller.DefaultManagedByKey,
+		"Workflow meta key of the marker identifying workflows managed by this operator.")
+	flag.StringVar(&managedByValue, "managed-by-value", controller.DefaultManagedByValue,
+		"Value of the managed-by marker. Give each operator sharing an n8n instance a distinct value.")
+	flag.BoolVar(&nodeOrderSignificant, "node-order-significant", false,
+		"Treat reordering spec.workflow.nodes as a change that is pushed to n8n.")
+	flag.StringVar(&environment, "environment", "",
+		"Name of the environment this operator runs in, such as prod. Workflows with spec.activeIn "+
+			"are only activated when an entry matches it or the workflow's namespace.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", 2*time.Minute,
+		"Deadline for a whole workflow or instance reconcile, including every n8n call. Reconciles still "+
+			"running at the deadline are abandoned and requeued.")
+	flag.DurationVar(&workflowCacheTTL, "workflow-cache-ttl", 10*time.Minute,
+		"How long the workflow ID a name resolved to is remembered per n8n instance, letting lookups by "+
+			"name skip listing workflows. Zero disables the cache.")
+	flag.DurationVar(&maxErrorBackoff, "max-error-backoff", controller.DefaultMaxErrorBackoff,
+		"Longest delay before retrying a workflow or instance that keeps failing. The delay starts at 30s "+
+			"and doubles with each failure in a row; 30s retries every failure after 30s.")
+	flag.StringVar(&nodeTypeVersions, "node-type-versions", "",
+		"Comma-separated type=typeVersion pairs, e.g. n8n-nodes-base.httpRequest=4.2. Nodes of a listed "+
+			"type below that typeVersion are upgraded when synced; higher versions are kept.")
+	flag.StringVar(&nodeTypePolicyConfigMap, "node-type-policy-configmap", "",
+		"Name of a ConfigMap in the operator namespace whose allowed and denied keys list the node types "+
+			"workflows may use. Workflows using other types are not synced. Empty disables the check.")
+	flag.DurationVar(&nodeTypePolicyRefresh, "node-type-policy-refresh", controller.DefaultNodeTypePolicyRefresh,
+		"How often the node type policy ConfigMap is re-read.")
+	flag.StringVar(&blockedNodeTypes, "blocked-node-types", "",
+		"Comma-separated node type globs no workflow may use, e.g. n8n-nodes-base.executeCommand,n8n-nodes-base.code. "+
+			"Workflows using them are not synced. An N8nInstance's spec.blockedNodeTypes replaces this list.")
+	flag.DurationVar(&maxExecutionTimeout, "max-execution-timeout", 0,
+		"Longest executionTimeout workflow settings may set, e.g. 1h. 0 disables the ceiling. "+
+			"An N8nInstance's spec.executionTimeoutPolicy replaces it.")
+	flag.StringVar(&executionTimeoutAction, "execution-timeout-action", string(n8nv1alpha1.ExecutionTimeoutClamp),
+		"What happens to workflows above --max-execution-timeout: Clamp lowers the setting, Reject refuses to sync them.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the N8nWorkflow admission webhooks. Requires a serving certificate, see --webhook-cert-path.")
+	flag.StringVar(&defaultWorkflowSettings, "default-workflow-settings", webhookv1alpha1.DefaultWorkflowSettings,
+		"JSON object the defaulting webhook sets as spec.workflow.settings on workflows without settings. "+
+			"Empty disables defaulting settings.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
@@ -101,6 +195,40 @@ func main() {
 	}
 	setupLog.Info("Using operator namespace", "namespace", operatorNamespace)
 
+	verbosity, err := controller.ParseEventVerbosity(eventVerbosity)
+	if err != nil {
+		setupLog.Error(err, "invalid --event-verbosity")
+		os.Exit(1)
+	}
+
+	typeVersions, err := controller.ParseNodeTypeVersions(nodeTypeVersions)
+	if err != nil {
+		setupLog.Error(err, "invalid --node-type-versions")
+		os.Exit(1)
+	}
+	blocked, err := controller.ParseBlockedNodeTypes(blockedNodeTypes)
+	if err != nil {
+		setupLog.Error(err, "invalid --blocked-node-types")
+		os.Exit(1)
+	}
+	timeoutPolicy, err := controller.NewExecutionTimeoutPolicy(maxExecutionTimeout, executionTimeoutAction)
+	if err != nil {
+		setupLog.Error(err, "invalid --max-execution-timeout or --execution-timeout-action")
+		os.Exit(1)
+	}
+
+	// Mutating n8n API calls are audited to a dedicated logger, optionally backed by its own file
+	auditLog := ctrl.Log.WithName("audit")
+	if auditLogFile != "" {
+		f, err := os.OpenFile(auditLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			setupLog.Error(err, "unable to open audit log file", "path", auditLogFile)
+			os.Exit(1)
+		}
+		defer f.Close()
+		auditLog = zap.New(zap.WriteTo(f), zap.JSONEncoder()).WithName("audit")
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -192,24 +320,177 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The controllers draw from the same per-instance request budget, reuse
+	// the same connections to each instance and share what they learn about it
+	rateLimiters := n8n.NewRateLimiterRegistry(n8nRequestsPerSecond, n8nBurst)
+	transports := n8n.NewTransportRegistry()
+	instanceStates := n8n.NewInstanceStateRegistry()
+
+	// Successful reconciles renew a Lease so external watchdogs can detect stalls.
+	// It uses an uncached client to avoid watching every Lease in the cluster.
+	var heartbeat *controller.LeaseHeartbeat
+	if heartbeatLeaseName != "" {
+		if heartbeatLeaseNamespace == "" {
+			heartbeatLeaseNamespace = operatorNamespace
+		}
+		heartbeatClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+		if err != nil {
+			setupLog.Error(err, "unable to create heartbeat client")
+			os.Exit(1)
+		}
+		identity, _ := os.Hostname()
+		heartbeat = &controller.LeaseHeartbeat{
+			Client:      heartbeatClient,
+			Name:        heartbeatLeaseName,
+			Namespace:   heartbeatLeaseNamespace,
+			Identity:    identity,
+			MinInterval: heartbeatInterval,
+		}
+	}
+
+	// The node type policy is read with the API reader so only the one
+	// ConfigMap is fetched, on each refresh
+	var nodeTypePolicy *controller.NodeTypePolicySource
+	if nodeTypePolicyConfigMap != "" {
+		nodeTypePolicy = &controller.NodeTypePolicySource{
+			Client:          mgr.GetAPIReader(),
+			Name:            nodeTypePolicyConfigMap,
+			Namespace:       operatorNamespace,
+			RefreshInterval: nodeTypePolicyRefresh,
+		}
+	}
+
+	managedBy := controller.ManagedByMarker{Key: managedByKey, Value: managedByValue}
+	retry := controller.RetryConfig{MaxAttempts: n8nMaxAttempts, BaseDelay: n8nRetryBaseDelay}
+
 	if err := (&controller.N8nInstanceReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("n8ninstance-controller"),
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		Recorder:         controller.NewFilteredRecorder(mgr.GetEventRecorderFor("n8ninstance-controller"), verbosity),
+		AuditLogger:      auditLog,
+		RateLimiters:     rateLimiters,
+		Transports:       transports,
+		InstanceStates:   instanceStates,
+		CredentialsDir:   credentialsDir,
+		Retry:            retry,
+		Heartbeat:        heartbeat,
+		ManagedBy:        managedBy,
+		Environment:      environment,
+		ReconcileTimeout: reconcileTimeout,
+		Backoff:          controller.NewFailureBackoff(maxErrorBackoff),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "N8nInstance")
 		os.Exit(1)
 	}
 
 	if err := (&controller.N8nWorkflowReconciler{
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		Recorder:               controller.NewFilteredRecorder(mgr.GetEventRecorderFor("n8nworkflow-controller"), verbosity),
+		OperatorNamespace:      operatorNamespace,
+		DefaultInstance:        defaultInstance,
+		AuditLogger:            auditLog,
+		RateLimiters:           rateLimiters,
+		Transports:             transports,
+		InstanceStates:         instanceStates,
+		CredentialsDir:         credentialsDir,
+		Retry:                  retry,
+		Heartbeat:              heartbeat,
+		ManagedBy:              managedBy,
+		NodeOrderSignificant:   nodeOrderSignificant,
+		Environment:            environment,
+		NodeTypeVersions:       typeVersions,
+		NodeTypePolicy:         nodeTypePolicy,
+		BlockedNodeTypes:       blocked,
+		ExecutionTimeoutPolicy: timeoutPolicy,
+		ReconcileTimeout:       reconcileTimeout,
+		WorkflowCache:          n8n.NewWorkflowCache(workflowCacheTTL),
+		Backoff:                controller.NewFailureBackoff(maxErrorBackoff),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "N8nWorkflow")
+		os.Exit(1)
+	}
+	if err := (&controller.N8nCredentialReconciler{
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
-		Recorder:          mgr.GetEventRecorderFor("n8nworkflow-controller"),
+		Recorder:          controller.NewFilteredRecorder(mgr.GetEventRecorderFor("n8ncredential-controller"), verbosity),
 		OperatorNamespace: operatorNamespace,
+		AuditLogger:       auditLog,
+		RateLimiters:      rateLimiters,
+		Transports:        transports,
+		InstanceStates:    instanceStates,
+		CredentialsDir:    credentialsDir,
+		Retry:             retry,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "N8nWorkflow")
+		setupLog.Error(err, "unable to create controller", "controller", "N8nCredential")
+		os.Exit(1)
+	}
+	if err := (&controller.N8nVariableReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          controller.NewFilteredRecorder(mgr.GetEventRecorderFor("n8nvariable-controller"), verbosity),
+		OperatorNamespace: operatorNamespace,
+		AuditLogger:       auditLog,
+		RateLimiters:      rateLimiters,
+		Transports:        transports,
+		InstanceStates:    instanceStates,
+		CredentialsDir:    credentialsDir,
+		Retry:             retry,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "N8nVariable")
+		os.Exit(1)
+	}
+	if err := (&controller.N8nTagReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          controller.NewFilteredRecorder(mgr.GetEventRecorderFor("n8ntag-controller"), verbosity),
+		OperatorNamespace: operatorNamespace,
+		AuditLogger:       auditLog,
+		RateLimiters:      rateLimiters,
+		Transports:        transports,
+		InstanceStates:    instanceStates,
+		CredentialsDir:    credentialsDir,
+		Retry:             retry,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "N8nTag")
 		os.Exit(1)
 	}
+	if err := (&controller.N8nSourceControlPullReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          controller.NewFilteredRecorder(mgr.GetEventRecorderFor("n8nsourcecontrolpull-controller"), verbosity),
+		OperatorNamespace: operatorNamespace,
+		AuditLogger:       auditLog,
+		RateLimiters:      rateLimiters,
+		Transports:        transports,
+		InstanceStates:    instanceStates,
+		CredentialsDir:    credentialsDir,
+		Retry:             retry,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "N8nSourceControlPull")
+		os.Exit(1)
+	}
+	if err := (&controller.N8nAuditReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		Recorder:          controller.NewFilteredRecorder(mgr.GetEventRecorderFor("n8naudit-controller"), verbosity),
+		OperatorNamespace: operatorNamespace,
+		AuditLogger:       auditLog,
+		RateLimiters:      rateLimiters,
+		Transports:        transports,
+		InstanceStates:    instanceStates,
+		CredentialsDir:    credentialsDir,
+		Retry:             retry,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "N8nAudit")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err := webhookv1alpha1.SetupN8nWorkflowWebhookWithManager(mgr, defaultWorkflowSettings); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "N8nWorkflow")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -221,7 +502,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	setupLog.Info("starting manager")
+	setupLog.Info("starting manager", "version", n8n.Version)
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
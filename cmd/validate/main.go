@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command validate checks N8nWorkflow manifests offline, for gating them in CI.
+// It reads the YAML or JSON files and directories given as arguments, or
+// standard input for "-", runs controller.ValidateWorkflow on every
+// N8nWorkflow found and skips other kinds. It exits 1 when any workflow has
+// errors and 2 when a manifest can't be read.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/controller"
+)
+
+func main() {
+	var output string
+	flag.StringVar(&output, "output", "text", "Output format: text or json")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [--output text|json] FILE|DIR|- ...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || (output != "text" && output != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	results := []*controller.ValidationResult{}
+	for _, arg := range flag.Args() {
+		found, err := validatePath(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(2)
+		}
+		results = append(results, found...)
+	}
+
+	valid := true
+	for _, result := range results {
+		valid = valid && result.Valid()
+	}
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(2)
+		}
+	} else {
+		printResults(os.Stdout, results)
+	}
+	if !valid {
+		os.Exit(1)
+	}
+}
+
+// validatePath validates the manifests in a file, in the .yaml, .yml and .json
+// files below a directory, or on standard input for "-"
+func validatePath(path string) ([]*controller.ValidationResult, error) {
+	if path == "-" {
+		return validateManifests(os.Stdin, "<stdin>")
+	}
+
+	var results []*controller.ValidationResult
+	err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		// Files named explicitly are read whatever their extension
+		if file != path {
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".yaml", ".yml", ".json":
+			default:
+				return nil
+			}
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		found, err := validateManifests(f, file)
+		if err != nil {
+			return err
+		}
+		results = append(results, found...)
+		return nil
+	})
+	return results, err
+}
+
+// validateManifests validates every N8nWorkflow in a stream of YAML documents
+// or JSON objects
+func validateManifests(r io.Reader, source string) ([]*controller.ValidationResult, error) {
+	var results []*controller.ValidationResult
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := json.Unmarshal(raw, &typeMeta); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		if typeMeta.Kind != "N8nWorkflow" || typeMeta.GroupVersionKind().Group != n8nv1alpha1.GroupVersion.Group {
+			continue
+		}
+
+		workflow := &n8nv1alpha1.N8nWorkflow{}
+		if err := json.Unmarshal(raw, workflow); err != nil {
+			return nil, fmt.Errorf("%s: N8nWorkflow is malformed: %w", source, err)
+		}
+		results = append(results, controller.ValidateWorkflow(workflow))
+	}
+}
+
+// printResults writes one line per issue and a summary line per workflow
+func printResults(w io.Writer, results []*controller.ValidationResult) {
+	for _, result := range results {
+		name := result.Name
+		if result.Namespace != "" {
+			name = result.Namespace + "/" + result.Name
+		}
+		for _, issue := range result.Issues {
+			_, _ = fmt.Fprintf(w, "%s: %s: %s: %s\n", name, issue.Severity, issue.Field, issue.Message)
+		}
+		if result.Valid() {
+			_, _ = fmt.Fprintf(w, "%s: valid\n", name)
+		} else {
+			_, _ = fmt.Fprintf(w, "%s: invalid\n", name)
+		}
+	}
+}
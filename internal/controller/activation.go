@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// countWorkflowActivation records in status how many workflows referencing the
+// instance are active, should be active and are held inactive by
+// deactivateSelector, emitting an event whenever the counts change
+func (r *N8nInstanceReconciler) countWorkflowActivation(ctx context.Context, instance *n8nv1alpha1.N8nInstance) error {
+	workflows := &n8nv1alpha1.N8nWorkflowList{}
+	if err := r.List(ctx, workflows); err != nil {
+		return fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	var active, desired, held int32
+	for i := range workflows.Items {
+		workflow := &workflows.Items[i]
+		if !workflowUsesInstance(workflow, instance) {
+			continue
+		}
+		if workflow.Status.Active {
+			active++
+		}
+		if !desiredActiveIn(workflow, r.Environment) {
+			continue
+		}
+		holds, err := instance.HoldsInactive(workflow.Labels)
+		if err != nil {
+			return err
+		}
+		if holds {
+			held++
+		} else {
+			desired++
+		}
+	}
+
+	status := &instance.Status
+	if status.ActiveWorkflows == active && status.DesiredActiveWorkflows == desired && status.HeldInactiveWorkflows == held {
+		return nil
+	}
+	status.ActiveWorkflows, status.DesiredActiveWorkflows, status.HeldInactiveWorkflows = active, desired, held
+	message := fmt.Sprintf("%d of %d desired workflows active", active, desired)
+	if held > 0 {
+		message += fmt.Sprintf(", %d held inactive by deactivateSelector", held)
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, "WorkflowActivation", message)
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+const (
+	// adoptedLabel marks N8nWorkflow resources generated by adoptTagSelector
+	adoptedLabel = "n8n.slys.dev/adopted"
+
+	// adoptedInstanceLabel records the N8nInstance that adopted the workflow
+	adoptedInstanceLabel = "n8n.slys.dev/instance"
+
+	// adoptedWorkflowIDAnnotation records the n8n ID of the adopted workflow
+	adoptedWorkflowIDAnnotation = "n8n.slys.dev/adopted-workflow-id"
+
+	// maxAdoptedNamePrefix leaves room for the ID hash suffix within the
+	// 63 character limit that label values and most names share
+	maxAdoptedNamePrefix = 50
+)
+
+// adoptTaggedWorkflows generates an N8nWorkflow for every n8n workflow carrying all
+// of the instance's adoptTagSelector tags. Workflows that already have a generated
+// resource are left alone, so the operation is safe to repeat on every health check.
+// It returns the number of adopted workflows, whether created now or previously.
+func (r *N8nInstanceReconciler) adoptTaggedWorkflows(ctx context.Context, instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client) (int32, error) {
+	log := logf.FromContext(ctx)
+	selector := instance.Spec.AdoptTagSelector
+	managedBy := r.ManagedBy.orDefault()
+
+	workflows, err := n8nClient.ListWorkflows(ctx, &n8n.ListWorkflowsOptions{Tags: selector.Tags})
+	if err != nil {
+		return 0, err
+	}
+
+	var adopted int32
+	for i := range workflows {
+		wf := &workflows[i]
+		if !hasAllTags(wf.TagNames(), selector.Tags) {
+			continue
+		}
+		// Leave workflows another operator manages alone
+		if managedBy.foreign(wf) {
+			log.V(1).Info("Skipping workflow managed by another operator", "workflowId", wf.ID, "name", wf.Name)
+			continue
+		}
+
+		resource, err := adoptedWorkflowResource(instance, wf)
+		if err != nil {
+			return adopted, fmt.Errorf("failed to build N8nWorkflow for %q: %w", wf.Name, err)
+		}
+		// A workflow we already manage only counts if it was adopted before,
+		// otherwise a hand-written N8nWorkflow owns it
+		if managedBy.marks(wf) {
+			err := r.Get(ctx, client.ObjectKeyFromObject(resource), &n8nv1alpha1.N8nWorkflow{})
+			switch {
+			case err == nil:
+				adopted++
+			case !errors.IsNotFound(err):
+				return adopted, fmt.Errorf("failed to get N8nWorkflow for %q: %w", wf.Name, err)
+			}
+			continue
+		}
+		if err := r.Create(ctx, resource); err != nil {
+			if errors.IsAlreadyExists(err) {
+				adopted++
+				continue
+			}
+			return adopted, fmt.Errorf("failed to create N8nWorkflow for %q: %w", wf.Name, err)
+		}
+		log.Info("Adopted n8n workflow", "workflowId", wf.ID, "name", wf.Name,
+			"resource", resource.Namespace+"/"+resource.Name)
+		recordChange(r.Recorder, instance, "WorkflowAdopted",
+			fmt.Sprintf("Adopted workflow %q as %s/%s", wf.Name, resource.Namespace, resource.Name))
+		adopted++
+	}
+
+	return adopted, nil
+}
+
+// hasAllTags reports whether every wanted tag is present in tags
+func hasAllTags(tags, wanted []string) bool {
+	present := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		present[tag] = true
+	}
+	for _, tag := range wanted {
+		if !present[tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// adoptedWorkflowResource builds the N8nWorkflow that takes over management of wf
+func adoptedWorkflowResource(instance *n8nv1alpha1.N8nInstance, wf *n8n.Workflow) (*n8nv1alpha1.N8nWorkflow, error) {
+	spec, err := workflowSpecFromN8n(wf)
+	if err != nil {
+		return nil, err
+	}
+	folder, tags := splitFolderTag(spec.Tags)
+	spec.Tags = tags
+
+	return &n8nv1alpha1.N8nWorkflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      adoptedResourceName(wf),
+			Namespace: instance.Spec.AdoptTagSelector.TargetNamespace,
+			Labels: map[string]string{
+				adoptedLabel:         "true",
+				adoptedInstanceLabel: instance.Name,
+			},
+			Annotations: map[string]string{
+				adoptedWorkflowIDAnnotation: wf.ID,
+			},
+		},
+		Spec: n8nv1alpha1.N8nWorkflowSpec{
+			InstanceRef: instance.Name,
+			SyncPolicy:  n8nv1alpha1.SyncPolicyAlways,
+			Active:      wf.Active,
+			Folder:      folder,
+			Workflow:    spec,
+		},
+	}, nil
+}
+
+// workflowSpecFromN8n converts an n8n workflow back into the spec that would
+// produce it, the reverse of convertToN8nWorkflow
+func workflowSpecFromN8n(wf *n8n.Workflow) (n8nv1alpha1.WorkflowSpec, error) {
+	spec := n8nv1alpha1.WorkflowSpec{Name: wf.Name, Tags: wf.TagNames()}
+
+	for _, node := range wf.Nodes {
+		raw, err := json.Marshal(node)
+		if err != nil {
+			return spec, fmt.Errorf("failed to marshal node: %w", err)
+		}
+		spec.Nodes = append(spec.Nodes, runtime.RawExtension{Raw: raw})
+	}
+
+	var err error
+	if spec.Connections, err = toRawExtension(wf.Connections); err != nil {
+		return spec, fmt.Errorf("failed to marshal connections: %w", err)
+	}
+	if spec.Settings, err = toRawExtension(wf.Settings); err != nil {
+		return spec, fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	if spec.StaticData, err = toRawExtension(wf.StaticData); err != nil {
+		return spec, fmt.Errorf("failed to marshal staticData: %w", err)
+	}
+	if spec.PinData, err = toRawExtension(wf.PinData); err != nil {
+		return spec, fmt.Errorf("failed to marshal pinData: %w", err)
+	}
+	return spec, nil
+}
+
+// toRawExtension marshals a JSON object, returning nil for empty maps
+func toRawExtension(value map[string]any) (*runtime.RawExtension, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
+
+// adoptedResourceName derives a stable DNS-1123 name from the workflow name,
+// suffixed with a hash of its n8n ID so workflows with similar names don't collide
+func adoptedResourceName(wf *n8n.Workflow) string {
+	var b strings.Builder
+	lastDash := true
+	for _, c := range strings.ToLower(wf.Name) {
+		switch {
+		case (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'):
+			b.WriteRune(c)
+			lastDash = false
+		case !lastDash:
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	prefix := strings.Trim(b.String(), "-")
+	if len(prefix) > maxAdoptedNamePrefix {
+		prefix = strings.TrimRight(prefix[:maxAdoptedNamePrefix], "-")
+	}
+	if prefix == "" {
+		prefix = "workflow"
+	}
+
+	sum := sha256.Sum256([]byte(wf.ID))
+	return prefix + "-" + hex.EncodeToString(sum[:])[:8]
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// instanceCredentials reads the API key from the instance's credentials
+// secret or file, along with the Basic auth username and password or the
+// bearer token its auth type needs. The returned option makes a client send
+// the latter. A file is only read from within credentialsDir.
+func instanceCredentials(ctx context.Context, reader client.Reader, instance *n8nv1alpha1.N8nInstance,
+	credentialsDir string) (string, n8n.Option, error) {
+	var source string
+	var value func(key string) (string, error)
+	apiKeyName := instance.GetSecretKey()
+	if path := instance.Spec.Credentials.FilePath; path != "" {
+		dir, err := credentialFileDir(path, credentialsDir)
+		if err != nil {
+			return "", nil, err
+		}
+		source, apiKeyName = fmt.Sprintf("credentials directory %q", dir), filepath.Base(path)
+		value = func(key string) (string, error) {
+			return readCredentialFile(dir, key)
+		}
+	} else {
+		secret := &corev1.Secret{}
+		secretKey := types.NamespacedName{
+			Name:      instance.Spec.Credentials.SecretName,
+			Namespace: instance.Namespace, // Secret must be in same namespace as N8nInstance
+		}
+		if err := reader.Get(ctx, secretKey, secret); err != nil {
+			return "", nil, fmt.Errorf("failed to get API key secret %q: %w", secretKey, err)
+		}
+		source = fmt.Sprintf("secret %q", secretKey)
+		value = func(key string) (string, error) {
+			data, ok := secret.Data[key]
+			if !ok {
+				return "", fmt.Errorf("secret %q does not contain key %q", secretKey, key)
+			}
+			return string(data), nil
+		}
+	}
+
+	apiKey, err := value(apiKeyName)
+	if err != nil {
+		return "", nil, err
+	}
+	// An agent may create the file before rendering the key into it
+	if apiKey == "" && instance.Spec.Credentials.FilePath != "" {
+		return "", nil, fmt.Errorf("%s file %q is empty", source, apiKeyName)
+	}
+
+	switch authType := instance.GetAuthType(); authType {
+	case n8nv1alpha1.AuthTypeAPIKey:
+		return apiKey, func(*n8n.Client) {}, nil
+	case n8nv1alpha1.AuthTypeBasic:
+		username, err := value(instance.GetUsernameKey())
+		if err != nil {
+			return "", nil, err
+		}
+		if username == "" || strings.Contains(username, ":") {
+			return "", nil, fmt.Errorf("%s key %q must hold a non-empty username without a colon",
+				source, instance.GetUsernameKey())
+		}
+		password, err := value(instance.GetPasswordKey())
+		if err != nil {
+			return "", nil, err
+		}
+		return apiKey, n8n.WithBasicAuth(username, password), nil
+	case n8nv1alpha1.AuthTypeBearer:
+		token, err := value(instance.GetTokenKey())
+		if err != nil {
+			return "", nil, err
+		}
+		if token == "" {
+			return "", nil, fmt.Errorf("%s key %q is empty", source, instance.GetTokenKey())
+		}
+		return apiKey, n8n.WithBearerToken(token), nil
+	default:
+		return "", nil, fmt.Errorf("unsupported credentials.authType %q", authType)
+	}
+}
+
+// credentialFileDir returns the directory of the credentials file at path,
+// which must lie within credentialsDir. N8nInstances can be created by tenants,
+// so without this any file in the operator pod, such as its service account
+// token, could be sent to a URL of their choosing as the API key. An empty
+// credentialsDir allows no files.
+func credentialFileDir(path, credentialsDir string) (string, error) {
+	if credentialsDir == "" {
+		return "", fmt.Errorf("credentials.filePath is not allowed: the operator was started without --credentials-dir")
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("credentials.filePath must be absolute, got %q", path)
+	}
+	dir := filepath.Dir(filepath.Clean(path))
+	rel, err := filepath.Rel(filepath.Clean(credentialsDir), dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("credentials.filePath %q is outside the credentials directory %q", path, credentialsDir)
+	}
+	return dir, nil
+}
+
+// readCredentialFile reads the credential file key in dir, as written by a
+// Vault agent or a mounted Secret volume. A trailing newline, which such
+// files often end with, is dropped.
+func readCredentialFile(dir, key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key == "." || key == ".." {
+		return "", fmt.Errorf("credentials key %q must be a file name in %q", key, dir)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("credentials directory %q does not contain file %q", dir, key)
+		}
+		return "", fmt.Errorf("failed to read credentials file %q: %w", filepath.Join(dir, key), err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
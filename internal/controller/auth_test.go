@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential files", func() {
+	DescribeTable("should only allow files within the credentials directory",
+		func(path, credentialsDir, problem string) {
+			_, err := credentialFileDir(path, credentialsDir)
+			if problem == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(problem)))
+			}
+		},
+		Entry("a file in the directory", "/vault/secrets/n8n-api-key", "/vault/secrets", ""),
+		Entry("a file in a subdirectory", "/vault/secrets/team-a/n8n-api-key", "/vault/secrets/", ""),
+		Entry("no credentials directory", "/vault/secrets/n8n-api-key", "", "--credentials-dir"),
+		Entry("the service account token", "/var/run/secrets/kubernetes.io/serviceaccount/token",
+			"/vault/secrets", "outside"),
+		Entry("a sibling with the same prefix", "/vault/secrets-other/key", "/vault/secrets", "outside"),
+		Entry("a path escaping with ..", "/vault/secrets/../../etc/passwd", "/vault/secrets", "outside"),
+		Entry("a relative path", "n8n-api-key", "/vault/secrets", "must be absolute"),
+	)
+})
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultMaxErrorBackoff caps the delay before retrying a resource that keeps failing
+const DefaultMaxErrorBackoff = 15 * time.Minute
+
+// FailureBackoff spaces out the retries of resources that keep failing: the
+// first failure in a row is retried after the controller's error interval and
+// each further one after twice as long, up to Max, so a broken resource stops
+// warning every 30 seconds while a fixed one recovers on its next retry. A nil
+// backoff always retries after the error interval.
+//
+// Failures are counted per namespace/name, since that is all a reconcile
+// request carries; a deleted resource's last reconcile finds it gone, succeeds
+// and so forgets it. Reconciles of one resource never overlap, so a key is only
+// touched by one reconcile at a time.
+type FailureBackoff struct {
+	// Max caps the delay; it is never below the error interval
+	Max time.Duration
+
+	mu       sync.Mutex
+	failures map[types.NamespacedName]int
+	// failed holds the keys whose reconcile under way has counted a failure
+	failed map[types.NamespacedName]bool
+}
+
+// NewFailureBackoff returns a backoff capped at max
+func NewFailureBackoff(max time.Duration) *FailureBackoff {
+	return &FailureBackoff{Max: max}
+}
+
+// retry counts a failed reconcile of key and returns the result requeueing it
+// after base doubled for every earlier failure in a row
+func (b *FailureBackoff) retry(key types.NamespacedName, base time.Duration) ctrl.Result {
+	if b == nil {
+		return ctrl.Result{RequeueAfter: base}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures == nil {
+		b.failures = map[types.NamespacedName]int{}
+		b.failed = map[types.NamespacedName]bool{}
+	}
+	if !b.failed[key] {
+		b.failures[key]++
+		b.failed[key] = true
+	}
+	return ctrl.Result{RequeueAfter: backoffDelay(base, b.Max, b.failures[key])}
+}
+
+// finish ends a reconcile of key. One that returned no error and counted no
+// failure succeeded, which resets the backoff of key; an error nothing counted,
+// such as a failed status update, leaves the count as it is.
+func (b *FailureBackoff) finish(key types.NamespacedName, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failed[key] {
+		delete(b.failed, key)
+		return
+	}
+	if err == nil {
+		delete(b.failures, key)
+	}
+}
+
+// backoffDelay returns base doubled for each of the failures after the first,
+// capped at max unless max is below base
+func backoffDelay(base, max time.Duration, failures int) time.Duration {
+	delay := base
+	for i := 1; i < failures && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max && max > base {
+		delay = max
+	}
+	return delay
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Failure backoff", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "broken"}
+
+	// fail runs one reconcile of key that fails and returns its requeue delay
+	fail := func(b *FailureBackoff) time.Duration {
+		result := b.retry(key, errorRequeueInterval)
+		b.finish(key, nil)
+		return result.RequeueAfter
+	}
+
+	It("should double the delay for each failure in a row up to the cap", func() {
+		b := NewFailureBackoff(DefaultMaxErrorBackoff)
+		var delays []time.Duration
+		for range 7 {
+			delays = append(delays, fail(b))
+		}
+		Expect(delays).To(Equal([]time.Duration{
+			30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute,
+			8 * time.Minute, 15 * time.Minute, 15 * time.Minute,
+		}))
+	})
+
+	It("should count a reconcile once however many failures it reports", func() {
+		b := NewFailureBackoff(DefaultMaxErrorBackoff)
+		Expect(fail(b)).To(Equal(30 * time.Second))
+		b.retry(key, errorRequeueInterval)
+		Expect(b.retry(key, errorRequeueInterval).RequeueAfter).To(Equal(time.Minute))
+		b.finish(key, nil)
+		Expect(fail(b)).To(Equal(2 * time.Minute))
+	})
+
+	It("should start over after a success", func() {
+		b := NewFailureBackoff(DefaultMaxErrorBackoff)
+		fail(b)
+		fail(b)
+		b.finish(key, nil)
+		Expect(fail(b)).To(Equal(30 * time.Second))
+	})
+
+	It("should keep the count across an error that counted no failure", func() {
+		b := NewFailureBackoff(DefaultMaxErrorBackoff)
+		fail(b)
+		b.finish(key, errors.New("status update conflict"))
+		Expect(fail(b)).To(Equal(time.Minute))
+	})
+
+	It("should track resources separately", func() {
+		b := NewFailureBackoff(DefaultMaxErrorBackoff)
+		fail(b)
+		fail(b)
+		other := types.NamespacedName{Namespace: "default", Name: "healthy"}
+		Expect(b.retry(other, errorRequeueInterval).RequeueAfter).To(Equal(30 * time.Second))
+	})
+
+	It("should retry at the base interval when the cap is below it or unset", func() {
+		Expect(backoffDelay(30*time.Second, 10*time.Second, 5)).To(Equal(30 * time.Second))
+		var b *FailureBackoff
+		Expect(fail(b)).To(Equal(30 * time.Second))
+		Expect(fail(b)).To(Equal(30 * time.Second))
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// baseWorkflow is the part of a workflow export that spec.workflow.baseFrom
+// supplies. Both n8n exports and the workflow.json written by importFromId
+// have this shape.
+type baseWorkflow struct {
+	Nodes       []json.RawMessage `json:"nodes"`
+	Connections json.RawMessage   `json:"connections,omitempty"`
+	Settings    json.RawMessage   `json:"settings,omitempty"`
+}
+
+// loadBaseWorkflow reads the workflow export referenced by
+// spec.workflow.baseFrom, which must exist and hold at least one node
+func (r *N8nWorkflowReconciler) loadBaseWorkflow(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (*baseWorkflow, error) {
+	ref := workflow.Spec.Workflow.BaseFrom.ConfigMapKeyRef
+	if ref == nil {
+		return nil, fmt.Errorf("baseFrom: configMapKeyRef is required")
+	}
+	what := fmt.Sprintf("key %q of ConfigMap %q", ref.Key, ref.Name)
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: ref.Name}, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("baseFrom: ConfigMap %q not found", ref.Name)
+		}
+		return nil, fmt.Errorf("failed to get ConfigMap %q: %w", ref.Name, err)
+	}
+	var raw []byte
+	var found bool
+	if value, ok := configMap.Data[ref.Key]; ok {
+		raw, found = []byte(value), true
+	} else {
+		raw, found = configMap.BinaryData[ref.Key]
+	}
+	if !found {
+		return nil, fmt.Errorf("baseFrom: %s not found", what)
+	}
+
+	base := &baseWorkflow{}
+	if err := json.Unmarshal(raw, base); err != nil {
+		return nil, fmt.Errorf("baseFrom: %s is not a workflow JSON object: %w", what, err)
+	}
+	if len(base.Nodes) == 0 {
+		return nil, fmt.Errorf("baseFrom: %s has no nodes", what)
+	}
+	return base, nil
+}
+
+// applyBaseWorkflow copies the nodes, connections and, unless the workflow
+// sets its own, settings of base into the workflow spec, so conversion and
+// patches see the base as if it were written inline
+func applyBaseWorkflow(workflow *n8nv1alpha1.N8nWorkflow, base *baseWorkflow) {
+	spec := &workflow.Spec.Workflow
+	spec.Nodes = make([]runtime.RawExtension, len(base.Nodes))
+	for i, node := range base.Nodes {
+		spec.Nodes[i] = runtime.RawExtension{Raw: node}
+	}
+	spec.Connections = rawOrNil(base.Connections)
+	if spec.Settings == nil || len(spec.Settings.Raw) == 0 {
+		spec.Settings = rawOrNil(base.Settings)
+	}
+}
+
+// rawOrNil wraps raw JSON in a RawExtension, treating absent and null as unset
+func rawOrNil(raw json.RawMessage) *runtime.RawExtension {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return &runtime.RawExtension{Raw: raw}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// workflowTargetIndexKey indexes workflows by the n8n workflows they target on
+// their instance, both by name and by the ID recorded in status
+const workflowTargetIndexKey = "spec.workflowTarget"
+
+// workflowTargets returns the index values of the n8n workflows obj targets,
+// qualified by the namespace/name of its instance
+func (r *N8nWorkflowReconciler) workflowTargets(obj client.Object) []string {
+	workflow, ok := obj.(*n8nv1alpha1.N8nWorkflow)
+	if !ok {
+		return nil
+	}
+	instance := r.workflowInstanceKey(workflow)
+	if instance == "" {
+		return nil
+	}
+	var targets []string
+	// Uniquely named workflows step aside from a taken name instead of sharing it
+	if workflow.Spec.Workflow.Name != "" && workflow.Annotations[uniqueNameAnnotation] != "true" {
+		targets = append(targets, "name:"+instance+"/"+workflow.Spec.Workflow.Name)
+	}
+	if workflow.Status.WorkflowID != "" {
+		targets = append(targets, "id:"+instance+"/"+workflow.Status.WorkflowID)
+	}
+	return targets
+}
+
+// sharingTarget returns the other N8nWorkflows, in any namespace, that target
+// one of the n8n workflows workflow targets. Resources being deleted are
+// skipped, since they no longer compete for the workflow.
+func (r *N8nWorkflowReconciler) sharingTarget(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) ([]n8nv1alpha1.N8nWorkflow, error) {
+	seen := map[client.ObjectKey]bool{client.ObjectKeyFromObject(workflow): true}
+	var others []n8nv1alpha1.N8nWorkflow
+	for _, target := range r.workflowTargets(workflow) {
+		workflows, err := r.listByTarget(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range workflows {
+			key := client.ObjectKeyFromObject(&other)
+			if seen[key] || !other.DeletionTimestamp.IsZero() {
+				continue
+			}
+			seen[key] = true
+			others = append(others, other)
+		}
+	}
+	return others, nil
+}
+
+// listByTarget lists the workflows indexed under target. A client without the
+// index, such as a direct API client, rejects the field selector, in which
+// case every workflow is listed and filtered instead.
+func (r *N8nWorkflowReconciler) listByTarget(ctx context.Context, target string) ([]n8nv1alpha1.N8nWorkflow, error) {
+	workflows := &n8nv1alpha1.N8nWorkflowList{}
+	err := r.List(ctx, workflows, client.MatchingFields{workflowTargetIndexKey: target})
+	if err == nil {
+		return workflows.Items, nil
+	}
+	if !errors.IsBadRequest(err) {
+		return nil, err
+	}
+	if err := r.List(ctx, workflows); err != nil {
+		return nil, err
+	}
+	var matching []n8nv1alpha1.N8nWorkflow
+	for _, workflow := range workflows.Items {
+		for _, other := range r.workflowTargets(&workflow) {
+			if other == target {
+				matching = append(matching, workflow)
+				break
+			}
+		}
+	}
+	return matching, nil
+}
+
+// conflictWinner returns the resource that manages the n8n workflow instead of
+// workflow, or nil when workflow has no competitor or wins. See winsConflict
+// for the order resources are ranked in.
+func (r *N8nWorkflowReconciler) conflictWinner(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (*n8nv1alpha1.N8nWorkflow, error) {
+	others, err := r.sharingTarget(ctx, workflow)
+	if err != nil {
+		return nil, err
+	}
+	var winner *n8nv1alpha1.N8nWorkflow
+	for i := range others {
+		if winsConflict(&others[i], workflow) && (winner == nil || winsConflict(&others[i], winner)) {
+			winner = &others[i]
+		}
+	}
+	return winner, nil
+}
+
+// winsConflict reports whether a takes precedence over b for an n8n workflow
+// both target. A resource whose status.workflowId already tracks a workflow
+// wins, so a newcomer never takes over the workflow being synced; then the one
+// created first; and only then the first by namespace/name, so every resource
+// involved agrees on the winner whatever order they are reconciled in.
+func winsConflict(a, b *n8nv1alpha1.N8nWorkflow) bool {
+	if tracksA, tracksB := a.Status.WorkflowID != "", b.Status.WorkflowID != ""; tracksA != tracksB {
+		return tracksA
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return client.ObjectKeyFromObject(a).String() < client.ObjectKeyFromObject(b).String()
+}
+
+// workflowsSharingTarget maps a workflow to the others targeting the same n8n
+// workflow, so a resource that lost a conflict is re-reconciled as soon as the
+// winner is deleted or a new competitor appears. A loser whose winner is
+// renamed away picks that up on its next periodic reconcile.
+func (r *N8nWorkflowReconciler) workflowsSharingTarget(ctx context.Context, obj client.Object) []reconcile.Request {
+	workflow, ok := obj.(*n8nv1alpha1.N8nWorkflow)
+	if !ok {
+		return nil
+	}
+	others, err := r.sharingTarget(ctx, workflow)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list workflows sharing a target", "name", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(others))
+	for _, other := range others {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&other)})
+	}
+	return requests
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Conflict winner", func() {
+	older := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+
+	newWorkflow := func(name string, created metav1.Time, workflowID string) *n8nv1alpha1.N8nWorkflow {
+		return &n8nv1alpha1.N8nWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: created},
+			Status:     n8nv1alpha1.N8nWorkflowStatus{WorkflowID: workflowID},
+		}
+	}
+
+	DescribeTable("should rank the resource tracking the workflow first, then the oldest, then by name",
+		func(a, b *n8nv1alpha1.N8nWorkflow, aWins bool) {
+			Expect(winsConflict(a, b)).To(Equal(aWins))
+			Expect(winsConflict(b, a)).To(Equal(!aWins))
+		},
+		Entry("tracking beats older and first by name",
+			newWorkflow("orders-b", newer, "wf-1"), newWorkflow("orders-a", older, ""), true),
+		Entry("older beats first by name",
+			newWorkflow("orders-b", older, ""), newWorkflow("orders-a", newer, ""), true),
+		Entry("older wins when both track a workflow",
+			newWorkflow("orders-b", older, "wf-1"), newWorkflow("orders-a", newer, "wf-1"), true),
+		Entry("name breaks a tie",
+			newWorkflow("orders-b", older, ""), newWorkflow("orders-a", older, ""), false),
+	)
+})
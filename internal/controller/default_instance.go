@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// defaultInstanceLabel marks the N8nInstance that workflows without an
+// instanceRef in its namespace use
+const defaultInstanceLabel = "n8n.slys.dev/default-instance"
+
+// resolveInstance returns the N8nInstance the workflow syncs with. An explicit
+// spec.instanceRef names one in the operator namespace. Otherwise, in order:
+// the instance recorded in status once the workflow exists in n8n, the
+// instance in the workflow's namespace labeled as default, the only instance in
+// that namespace, and the operator's DefaultInstance. The choice is recorded in
+// status.defaultInstance.
+func (r *N8nWorkflowReconciler) resolveInstance(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (types.NamespacedName, error) {
+	if ref := workflow.Spec.InstanceRef; ref != "" {
+		workflow.Status.DefaultInstance = ""
+		return types.NamespacedName{Namespace: r.OperatorNamespace, Name: ref}, nil
+	}
+
+	// Keep a workflow on the instance it was created on
+	if recorded := workflow.Status.DefaultInstance; recorded != "" && workflow.Status.WorkflowID != "" {
+		if namespace, name, ok := strings.Cut(recorded, "/"); ok {
+			return types.NamespacedName{Namespace: namespace, Name: name}, nil
+		}
+	}
+
+	key, err := r.namespaceDefaultInstance(ctx, workflow.Namespace)
+	if err != nil {
+		return types.NamespacedName{}, err
+	}
+	if key.Name == "" && r.DefaultInstance != "" {
+		key = types.NamespacedName{Namespace: r.OperatorNamespace, Name: r.DefaultInstance}
+	}
+	if key.Name == "" {
+		return types.NamespacedName{}, &instanceUnavailableError{
+			reason: n8nv1alpha1.ReasonInstanceNotFound,
+			message: fmt.Sprintf("instanceRef is unset and namespace %q has no N8nInstance labeled %s=true, "+
+				"nor a single N8nInstance, and the operator has no --default-instance", workflow.Namespace, defaultInstanceLabel),
+		}
+	}
+	workflow.Status.DefaultInstance = key.String()
+	return key, nil
+}
+
+// namespaceDefaultInstance returns the N8nInstance in namespace labeled as the
+// default, or else the only one there. The name is empty when there is neither.
+func (r *N8nWorkflowReconciler) namespaceDefaultInstance(ctx context.Context, namespace string) (types.NamespacedName, error) {
+	instances := &n8nv1alpha1.N8nInstanceList{}
+	if err := r.List(ctx, instances, client.InNamespace(namespace)); err != nil {
+		return types.NamespacedName{}, fmt.Errorf("failed to list N8nInstances in namespace %q: %w", namespace, err)
+	}
+
+	var labeled []string
+	for _, instance := range instances.Items {
+		if instance.Labels[defaultInstanceLabel] == "true" {
+			labeled = append(labeled, instance.Name)
+		}
+	}
+	switch {
+	case len(labeled) == 1:
+		return types.NamespacedName{Namespace: namespace, Name: labeled[0]}, nil
+	case len(labeled) > 1:
+		sort.Strings(labeled)
+		return types.NamespacedName{}, &instanceUnavailableError{
+			reason: n8nv1alpha1.ReasonInstanceAmbiguous,
+			message: fmt.Sprintf("instanceRef is unset and N8nInstances %s in namespace %q are all labeled %s=true; "+
+				"label only one or set instanceRef", strings.Join(labeled, ", "), namespace, defaultInstanceLabel),
+		}
+	case len(instances.Items) == 1:
+		return types.NamespacedName{Namespace: namespace, Name: instances.Items[0].Name}, nil
+	}
+	return types.NamespacedName{}, nil
+}
+
+// workflowInstance identifies the N8nInstance a workflow syncs with for
+// indexing: spec.instanceRef, or else the namespace/name in
+// status.defaultInstance. The two can't collide, since names contain no slash.
+func workflowInstance(workflow *n8nv1alpha1.N8nWorkflow) string {
+	if workflow.Spec.InstanceRef != "" {
+		return workflow.Spec.InstanceRef
+	}
+	return workflow.Status.DefaultInstance
+}
+
+// workflowInstanceKey returns the namespace/name of the N8nInstance a
+// workflow syncs with, resolving spec.instanceRef to the operator namespace,
+// or "" if it isn't known yet. Unlike workflowInstance, it is the same for a
+// workflow naming an instance and one using that instance as its default.
+func (r *N8nWorkflowReconciler) workflowInstanceKey(workflow *n8nv1alpha1.N8nWorkflow) string {
+	if ref := workflow.Spec.InstanceRef; ref != "" {
+		return types.NamespacedName{Namespace: r.OperatorNamespace, Name: ref}.String()
+	}
+	return workflow.Status.DefaultInstance
+}
+
+// workflowUsesInstance reports whether the workflow syncs with instance, by
+// name or through the default recorded in its status
+func workflowUsesInstance(workflow *n8nv1alpha1.N8nWorkflow, instance *n8nv1alpha1.N8nInstance) bool {
+	if workflow.Spec.InstanceRef != "" {
+		return workflow.Spec.InstanceRef == instance.Name
+	}
+	return workflow.Status.DefaultInstance == client.ObjectKeyFromObject(instance).String()
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// maxDriftDetails caps the differences recorded in status.driftDetails
+const maxDriftDetails = 20
+
+// workflowDrift lists the differences between the desired workflow and the one
+// in n8n. Fields n8n fills in itself, such as node IDs or default settings, are
+// only compared when the spec sets them.
+func workflowDrift(desired, actual *n8n.Workflow) []string {
+	if actual == nil {
+		return []string{"workflow does not exist in n8n"}
+	}
+
+	var drift []string
+	if actual.Name != desired.Name {
+		drift = append(drift, fmt.Sprintf("name is %q, spec has %q", actual.Name, desired.Name))
+	}
+	if actual.Active != desired.Active {
+		drift = append(drift, fmt.Sprintf("active is %t, spec has %t", actual.Active, desired.Active))
+	}
+	drift = append(drift, nodeDrift(desired.Nodes, actual.Nodes)...)
+	if !reflect.DeepEqual(emptyIfNil(actual.Connections), emptyIfNil(desired.Connections)) {
+		drift = append(drift, "connections differ")
+	}
+	for _, key := range changedKeys(desired.Settings, actual.Settings) {
+		drift = append(drift, fmt.Sprintf("settings.%s differs", key))
+	}
+	// Some n8n versions leave pinData out of reads, and an update without it
+	// keeps what was pinned in the UI, so it is only compared when both set it
+	if desired.PinData != nil && actual.PinData != nil && !reflect.DeepEqual(desired.PinData, actual.PinData) {
+		drift = append(drift, "pinData differs")
+	}
+	return drift
+}
+
+// nodeDrift lists the nodes that are missing, differ or are extra in actual,
+// matching nodes by name
+func nodeDrift(desired, actual []map[string]any) []string {
+	var drift []string
+	actualNodes := make(map[string]map[string]any, len(actual))
+	for _, node := range actual {
+		name, _ := node["name"].(string)
+		actualNodes[name] = node
+	}
+	desiredNames := make(map[string]bool, len(desired))
+	for _, node := range desired {
+		name, _ := node["name"].(string)
+		desiredNames[name] = true
+		existing, ok := actualNodes[name]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("node %q is missing in n8n", name))
+			continue
+		}
+		for _, key := range changedKeys(node, existing) {
+			drift = append(drift, fmt.Sprintf("node %q %s differs", name, key))
+		}
+	}
+	var extra []string
+	for name := range actualNodes {
+		if !desiredNames[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		drift = append(drift, fmt.Sprintf("node %q is not in the spec", name))
+	}
+	return drift
+}
+
+// contentDrift is workflowDrift without the activation state, which is synced
+// separately from the workflow content
+func contentDrift(desired, actual *n8n.Workflow) []string {
+	content := decodedCopy(desired)
+	content.Active = actual.Active
+	return workflowDrift(content, actual)
+}
+
+// decodedCopy returns wf after a JSON round trip, so values built in Go compare
+// equal to the ones decoded from n8n
+func decodedCopy(wf *n8n.Workflow) *n8n.Workflow {
+	content := *wf
+	if raw, err := json.Marshal(wf); err == nil {
+		content = n8n.Workflow{}
+		if err := json.Unmarshal(raw, &content); err != nil {
+			content = *wf
+		}
+	}
+	return &content
+}
+
+// workflowPatch returns the fields of desired that differ from actual, compared
+// the way workflowDrift compares them. Sections are sent whole: a single
+// changed node puts every node in the patch. It returns nil when nothing
+// differs or a differing section is empty in desired, since a patch can't
+// clear a field; both call for a full update instead.
+func workflowPatch(desired, actual *n8n.Workflow) *n8n.WorkflowPatch {
+	content := decodedCopy(desired)
+	patch := &n8n.WorkflowPatch{}
+	if content.Name != actual.Name {
+		patch.Name = desired.Name
+	}
+	if len(nodeDrift(content.Nodes, actual.Nodes)) > 0 {
+		if len(desired.Nodes) == 0 {
+			return nil
+		}
+		patch.Nodes = desired.Nodes
+	}
+	sections := []struct {
+		changed bool
+		value   map[string]any
+		field   *map[string]any
+	}{
+		{!reflect.DeepEqual(emptyIfNil(actual.Connections), emptyIfNil(content.Connections)), desired.Connections, &patch.Connections},
+		{len(changedKeys(content.Settings, actual.Settings)) > 0, desired.Settings, &patch.Settings},
+		{len(changedKeys(content.StaticData, actual.StaticData)) > 0, desired.StaticData, &patch.StaticData},
+		{content.PinData != nil && !reflect.DeepEqual(content.PinData, actual.PinData), desired.PinData, &patch.PinData},
+		{len(changedKeys(content.Meta, actual.Meta)) > 0, desired.Meta, &patch.Meta},
+	}
+	for _, section := range sections {
+		if !section.changed {
+			continue
+		}
+		if len(section.value) == 0 {
+			return nil
+		}
+		*section.field = section.value
+	}
+	if reflect.DeepEqual(*patch, n8n.WorkflowPatch{}) {
+		return nil
+	}
+	return patch
+}
+
+// capDrift limits drift to maxDriftDetails entries, noting how many were left out
+func capDrift(drift []string) []string {
+	if len(drift) > maxDriftDetails {
+		return append(drift[:maxDriftDetails:maxDriftDetails], fmt.Sprintf("and %d more", len(drift)-maxDriftDetails))
+	}
+	return drift
+}
+
+// changedKeys returns the sorted keys set in desired whose value differs in actual
+func changedKeys(desired, actual map[string]any) []string {
+	var keys []string
+	for key, value := range desired {
+		if !reflect.DeepEqual(value, actual[key]) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func emptyIfNil(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
+
+// recordDrift marks the workflow as having been found to differ from n8n
+func recordDrift(workflow *n8nv1alpha1.N8nWorkflow) {
+	now := metav1.Now()
+	workflow.Status.DriftDetected = true
+	workflow.Status.LastDriftTime = &now
+}
+
+// reportDrift records the drift between the desired workflow and n8n without
+// writing anything to n8n, for the Report sync policy
+func (r *N8nWorkflowReconciler) reportDrift(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow,
+	instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client, desired *n8n.Workflow) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	actual, err := findWorkflow(ctx, n8nClient, desired.Name, SyncOptions{
+		TrackedID:    workflow.Status.WorkflowID,
+		AdoptID:      adoptID(workflow),
+		AdoptionMode: instance.GetAdoptionMode(),
+		ManagedBy:    r.ManagedBy,
+		OwnerUID:     string(workflow.UID),
+		OwnerName:    workflow.Namespace + "/" + workflow.Name,
+		Cache:        r.WorkflowCache.For(n8nClient.BaseURL()),
+	})
+	if err != nil {
+		log.Error(err, "Failed to look up workflow for drift report")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonAPIError, fmt.Sprintf("Failed to look up workflow: %v", err))
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), err
+	}
+	if actual != nil {
+		workflow.Status.WorkflowID = actual.ID
+		workflow.Status.Active = actual.Active
+		workflow.Status.Credentials = workflowCredentials(actual)
+	}
+
+	drift := capDrift(workflowDrift(desired, actual))
+	hadDrift := meta.IsStatusConditionTrue(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeDrift)
+	workflow.Status.DriftDetails = drift
+	if len(drift) > 0 {
+		recordDrift(workflow)
+		message := fmt.Sprintf("Workflow differs from the spec: %s", strings.Join(drift, "; "))
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeDrift, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonDriftDetected, message)
+		if !hadDrift {
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonDriftDetected, message)
+		}
+	} else {
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeDrift, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonInSync, "Workflow matches the spec")
+	}
+	log.V(1).Info("Reported drift without syncing", "differences", len(drift))
+
+	workflow.Status.ObservedGeneration = workflow.Generation
+	r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionTrue,
+		n8nv1alpha1.ReasonReportOnly, "Drift is reported without syncing (syncPolicy: Report)")
+	if err := r.Status().Update(ctx, workflow); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+}
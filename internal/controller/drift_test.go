@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("workflowDrift", func() {
+	desired := func() *n8n.Workflow {
+		return &n8n.Workflow{
+			Name:   "Orders",
+			Active: true,
+			Nodes: []map[string]any{
+				{"name": "Webhook", "type": "n8n-nodes-base.webhook", "parameters": map[string]any{"path": "orders"}},
+			},
+			Connections: map[string]any{},
+			Settings:    map[string]any{"timezone": "UTC"},
+		}
+	}
+
+	DescribeTable("should list differences from the spec",
+		func(mutate func(actual *n8n.Workflow), expected []string) {
+			actual := desired()
+			// n8n fills in fields the spec doesn't set, which are not drift
+			actual.ID = "wf-1"
+			actual.Nodes[0]["id"] = "generated"
+			actual.Settings["executionOrder"] = "v1"
+			mutate(actual)
+			drift := workflowDrift(desired(), actual)
+			if len(expected) == 0 {
+				Expect(drift).To(BeEmpty())
+			} else {
+				Expect(drift).To(Equal(expected))
+			}
+		},
+		Entry("nothing when only n8n-managed fields differ", func(*n8n.Workflow) {}, nil),
+		Entry("a renamed workflow", func(wf *n8n.Workflow) { wf.Name = "Old Orders" },
+			[]string{`name is "Old Orders", spec has "Orders"`}),
+		Entry("a deactivated workflow", func(wf *n8n.Workflow) { wf.Active = false },
+			[]string{"active is false, spec has true"}),
+		Entry("edited node parameters", func(wf *n8n.Workflow) {
+			wf.Nodes[0]["parameters"] = map[string]any{"path": "edited"}
+		}, []string{`node "Webhook" parameters differs`}),
+		Entry("a node added in n8n", func(wf *n8n.Workflow) {
+			wf.Nodes = append(wf.Nodes, map[string]any{"name": "Debug"})
+		}, []string{`node "Debug" is not in the spec`}),
+		Entry("a node removed in n8n", func(wf *n8n.Workflow) { wf.Nodes = nil },
+			[]string{`node "Webhook" is missing in n8n`}),
+		Entry("changed connections", func(wf *n8n.Workflow) {
+			wf.Connections = map[string]any{"Webhook": map[string]any{}}
+		}, []string{"connections differ"}),
+		Entry("a changed setting", func(wf *n8n.Workflow) { wf.Settings["timezone"] = "Europe/Berlin" },
+			[]string{"settings.timezone differs"}),
+		Entry("nothing when n8n has pinData the spec doesn't set", func(wf *n8n.Workflow) {
+			wf.PinData = map[string]any{"Webhook": []any{map[string]any{"json": map[string]any{}}}}
+		}, nil),
+	)
+
+	It("should compare pinData only when n8n returns it", func() {
+		withPinData := desired()
+		withPinData.PinData = map[string]any{"Webhook": []any{map[string]any{"json": map[string]any{"id": float64(1)}}}}
+
+		actual := desired()
+		Expect(workflowDrift(withPinData, actual)).To(BeEmpty())
+
+		actual.PinData = map[string]any{"Webhook": []any{map[string]any{"json": map[string]any{"id": float64(1)}}}}
+		Expect(workflowDrift(withPinData, actual)).To(BeEmpty())
+
+		actual.PinData = map[string]any{"Webhook": []any{}}
+		Expect(workflowDrift(withPinData, actual)).To(Equal([]string{"pinData differs"}))
+	})
+
+	It("should report a missing workflow", func() {
+		Expect(workflowDrift(desired(), nil)).To(Equal([]string{"workflow does not exist in n8n"}))
+	})
+})
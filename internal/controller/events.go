@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// EventVerbosity controls which Normal events the controllers emit.
+// Warning events are always emitted.
+type EventVerbosity string
+
+const (
+	// EventVerbosityAll emits every event
+	EventVerbosityAll EventVerbosity = "All"
+
+	// EventVerbosityChangesOnly emits Normal events only when n8n was actually changed
+	EventVerbosityChangesOnly EventVerbosity = "ChangesOnly"
+
+	// EventVerbosityErrorsOnly suppresses all Normal events
+	EventVerbosityErrorsOnly EventVerbosity = "ErrorsOnly"
+)
+
+// ParseEventVerbosity validates a verbosity level, defaulting empty values to All
+func ParseEventVerbosity(value string) (EventVerbosity, error) {
+	switch EventVerbosity(value) {
+	case "":
+		return EventVerbosityAll, nil
+	case EventVerbosityAll, EventVerbosityChangesOnly, EventVerbosityErrorsOnly:
+		return EventVerbosity(value), nil
+	}
+	return "", fmt.Errorf("invalid event verbosity %q: must be one of %s, %s, %s",
+		value, EventVerbosityAll, EventVerbosityChangesOnly, EventVerbosityErrorsOnly)
+}
+
+// changeRecorder is implemented by recorders that treat events recording a
+// change made in n8n apart from other Normal events
+type changeRecorder interface {
+	ChangeEvent(object runtime.Object, reason, message string)
+}
+
+// recordChange emits a Normal event recording a change made in n8n. Events
+// emitted any other way aren't changes, whatever their reason.
+func recordChange(recorder record.EventRecorder, object runtime.Object, reason, message string) {
+	if changes, ok := recorder.(changeRecorder); ok {
+		changes.ChangeEvent(object, reason, message)
+		return
+	}
+	recorder.Event(object, corev1.EventTypeNormal, reason, message)
+}
+
+// filteredRecorder drops Normal events that the configured verbosity excludes
+type filteredRecorder struct {
+	record.EventRecorder
+	verbosity EventVerbosity
+}
+
+// NewFilteredRecorder wraps recorder so Normal events are filtered by verbosity
+func NewFilteredRecorder(recorder record.EventRecorder, verbosity EventVerbosity) record.EventRecorder {
+	if verbosity == "" || verbosity == EventVerbosityAll {
+		return recorder
+	}
+	return &filteredRecorder{EventRecorder: recorder, verbosity: verbosity}
+}
+
+// allowed reports whether an event of the given type should be emitted. Normal
+// events passed here are never changes, which go through ChangeEvent.
+func (f *filteredRecorder) allowed(eventtype string) bool {
+	return eventtype != corev1.EventTypeNormal || f.verbosity == EventVerbosityAll
+}
+
+// ChangeEvent emits a Normal event recording a change unless only errors are emitted
+func (f *filteredRecorder) ChangeEvent(object runtime.Object, reason, message string) {
+	if f.verbosity != EventVerbosityErrorsOnly {
+		f.EventRecorder.Event(object, corev1.EventTypeNormal, reason, message)
+	}
+}
+
+func (f *filteredRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if f.allowed(eventtype) {
+		f.EventRecorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (f *filteredRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	if f.allowed(eventtype) {
+		f.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	}
+}
+
+func (f *filteredRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...any) {
+	if f.allowed(eventtype) {
+		f.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	}
+}
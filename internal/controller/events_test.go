@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Event verbosity", func() {
+	// emitAll sends one of each kind of event and returns the reasons that got through
+	emitAll := func(verbosity EventVerbosity) []string {
+		fakeRecorder := record.NewFakeRecorder(10)
+		recorder := NewFilteredRecorder(fakeRecorder, verbosity)
+		obj := &n8nv1alpha1.N8nWorkflow{}
+
+		recordChange(recorder, obj, "Updated", "Workflow updated successfully")
+		recordChange(recorder, obj, "TagsUpdated", "Tags set to [prod]")
+		recorder.Event(obj, corev1.EventTypeNormal, "AlreadyDeleted", "Workflow no longer exists in n8n")
+		recorder.Eventf(obj, corev1.EventTypeWarning, "UpdateFailed", "failed: %s", "boom")
+
+		var reasons []string
+		for len(fakeRecorder.Events) > 0 {
+			var eventtype, reason string
+			_, _ = fmt.Sscanf(<-fakeRecorder.Events, "%s %s", &eventtype, &reason)
+			reasons = append(reasons, reason)
+		}
+		return reasons
+	}
+
+	DescribeTable("should filter Normal events and always emit warnings",
+		func(verbosity EventVerbosity, expected []string) {
+			Expect(emitAll(verbosity)).To(Equal(expected))
+		},
+		Entry("All", EventVerbosityAll, []string{"Updated", "TagsUpdated", "AlreadyDeleted", "UpdateFailed"}),
+		Entry("ChangesOnly", EventVerbosityChangesOnly, []string{"Updated", "TagsUpdated", "UpdateFailed"}),
+		Entry("ErrorsOnly", EventVerbosityErrorsOnly, []string{"UpdateFailed"}),
+	)
+
+	It("should default an empty verbosity to All and reject unknown values", func() {
+		verbosity, err := ParseEventVerbosity("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verbosity).To(Equal(EventVerbosityAll))
+
+		_, err = ParseEventVerbosity("Quiet")
+		Expect(err).To(HaveOccurred())
+	})
+})
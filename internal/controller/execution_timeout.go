@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// executionTimeoutSetting is the workflow setting holding the execution
+// timeout in seconds; -1 disables the timeout
+const executionTimeoutSetting = "executionTimeout"
+
+// ExecutionTimeoutError reports a workflow whose executionTimeout setting
+// exceeds the ceiling of the execution timeout policy
+type ExecutionTimeoutError struct {
+	// Seconds is the workflow's setting, negative when the timeout is disabled
+	Seconds float64
+	// Max is the ceiling
+	Max time.Duration
+}
+
+func (e *ExecutionTimeoutError) Error() string {
+	if e.Seconds < 0 {
+		return fmt.Sprintf("executionTimeout disables the timeout, but the maximum is %s", e.Max)
+	}
+	return fmt.Sprintf("executionTimeout of %gs exceeds the maximum of %s", e.Seconds, e.Max)
+}
+
+// NewExecutionTimeoutPolicy builds the operator-wide execution timeout policy
+// from its flags. max must be 0, which disables the ceiling, or at least 1s.
+func NewExecutionTimeoutPolicy(max time.Duration, action string) (n8nv1alpha1.ExecutionTimeoutPolicy, error) {
+	policy := n8nv1alpha1.ExecutionTimeoutPolicy{
+		Max:    metav1.Duration{Duration: max},
+		Action: n8nv1alpha1.ExecutionTimeoutAction(action),
+	}
+	if max < 0 || (max > 0 && max < time.Second) {
+		return policy, fmt.Errorf("execution timeout ceiling must be 0 or at least 1s, got %s", max)
+	}
+	if policy.Action != n8nv1alpha1.ExecutionTimeoutClamp && policy.Action != n8nv1alpha1.ExecutionTimeoutReject {
+		return policy, fmt.Errorf("execution timeout action must be %s or %s, got %q",
+			n8nv1alpha1.ExecutionTimeoutClamp, n8nv1alpha1.ExecutionTimeoutReject, action)
+	}
+	return policy, nil
+}
+
+// enforceExecutionTimeout applies policy to the executionTimeout setting of
+// wf. A setting above policy.Max, or -1, is lowered to the ceiling under the
+// Clamp action and returned, and fails with an *ExecutionTimeoutError under
+// Reject. Workflows without the setting run with n8n's EXECUTIONS_TIMEOUT and
+// are left alone, as is every workflow when policy.Max is zero.
+func enforceExecutionTimeout(wf *n8n.Workflow, policy n8nv1alpha1.ExecutionTimeoutPolicy) (*ExecutionTimeoutError, error) {
+	if policy.Max.Duration <= 0 {
+		return nil, nil
+	}
+	value, ok := wf.Settings[executionTimeoutSetting]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	seconds, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("settings.%s must be a number of seconds, got %v", executionTimeoutSetting, value)
+	}
+
+	maxSeconds := math.Floor(policy.Max.Seconds())
+	if seconds >= 0 && seconds <= maxSeconds {
+		return nil, nil
+	}
+	exceeded := &ExecutionTimeoutError{Seconds: seconds, Max: policy.Max.Duration}
+	if policy.Action == n8nv1alpha1.ExecutionTimeoutReject {
+		return nil, exceeded
+	}
+	wf.Settings[executionTimeoutSetting] = maxSeconds
+	return exceeded, nil
+}
+
+// executionTimeoutHash folds a clamped executionTimeout into the spec hash, so
+// changing the ceiling resyncs the workflow
+func executionTimeoutHash(specHash string, clamped *ExecutionTimeoutError) string {
+	if clamped == nil {
+		return specHash
+	}
+
+	h := sha256.New()
+	h.Write([]byte(specHash))
+	fmt.Fprintf(h, "\x00executionTimeout=%s", clamped.Max)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("Execution timeout policy", func() {
+	policy := func(max time.Duration, action n8nv1alpha1.ExecutionTimeoutAction) n8nv1alpha1.ExecutionTimeoutPolicy {
+		return n8nv1alpha1.ExecutionTimeoutPolicy{Max: metav1.Duration{Duration: max}, Action: action}
+	}
+	withTimeout := func(value any) *n8n.Workflow {
+		return &n8n.Workflow{Settings: map[string]any{"executionOrder": "v1", executionTimeoutSetting: value}}
+	}
+
+	DescribeTable("should leave compliant workflows alone",
+		func(wf *n8n.Workflow, p n8nv1alpha1.ExecutionTimeoutPolicy) {
+			before := wf.Settings[executionTimeoutSetting]
+			clamped, err := enforceExecutionTimeout(wf, p)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clamped).To(BeNil())
+			Expect(wf.Settings[executionTimeoutSetting]).To(Equal(before))
+		},
+		Entry("below the ceiling", withTimeout(float64(600)), policy(time.Hour, n8nv1alpha1.ExecutionTimeoutReject)),
+		Entry("at the ceiling", withTimeout(float64(3600)), policy(time.Hour, n8nv1alpha1.ExecutionTimeoutReject)),
+		Entry("without the setting", &n8n.Workflow{Settings: map[string]any{}}, policy(time.Hour, n8nv1alpha1.ExecutionTimeoutReject)),
+		Entry("without a ceiling", withTimeout(float64(-1)), policy(0, n8nv1alpha1.ExecutionTimeoutReject)),
+	)
+
+	It("should lower a timeout above the ceiling under Clamp", func() {
+		wf := withTimeout(float64(7200))
+		clamped, err := enforceExecutionTimeout(wf, policy(90*time.Minute, n8nv1alpha1.ExecutionTimeoutClamp))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clamped).To(Equal(&ExecutionTimeoutError{Seconds: 7200, Max: 90 * time.Minute}))
+		Expect(wf.Settings[executionTimeoutSetting]).To(Equal(float64(5400)))
+		Expect(wf.Settings["executionOrder"]).To(Equal("v1"))
+	})
+
+	It("should treat a disabled timeout as above every ceiling", func() {
+		wf := withTimeout(float64(-1))
+		clamped, err := enforceExecutionTimeout(wf, policy(time.Hour, n8nv1alpha1.ExecutionTimeoutClamp))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clamped).NotTo(BeNil())
+		Expect(wf.Settings[executionTimeoutSetting]).To(Equal(float64(3600)))
+
+		_, err = enforceExecutionTimeout(withTimeout(float64(-1)), policy(time.Hour, n8nv1alpha1.ExecutionTimeoutReject))
+		Expect(err).To(MatchError("executionTimeout disables the timeout, but the maximum is 1h0m0s"))
+	})
+
+	It("should refuse a timeout above the ceiling under Reject", func() {
+		wf := withTimeout(float64(7200))
+		_, err := enforceExecutionTimeout(wf, policy(time.Hour, n8nv1alpha1.ExecutionTimeoutReject))
+		Expect(err).To(BeAssignableToTypeOf(&ExecutionTimeoutError{}))
+		Expect(err).To(MatchError("executionTimeout of 7200s exceeds the maximum of 1h0m0s"))
+		Expect(wf.Settings[executionTimeoutSetting]).To(Equal(float64(7200)))
+	})
+
+	It("should reject a setting that isn't a number", func() {
+		_, err := enforceExecutionTimeout(withTimeout("1h"), policy(time.Hour, n8nv1alpha1.ExecutionTimeoutClamp))
+		Expect(err).To(MatchError(ContainSubstring("settings.executionTimeout must be a number of seconds")))
+	})
+
+	It("should change the spec hash only when clamping", func() {
+		Expect(executionTimeoutHash("abc", nil)).To(Equal("abc"))
+		hour := executionTimeoutHash("abc", &ExecutionTimeoutError{Seconds: 7200, Max: time.Hour})
+		Expect(hour).NotTo(Equal("abc"))
+		Expect(executionTimeoutHash("abc", &ExecutionTimeoutError{Seconds: 7200, Max: 30 * time.Minute})).NotTo(Equal(hour))
+	})
+
+	It("should validate the operator flags", func() {
+		p, err := NewExecutionTimeoutPolicy(time.Hour, "Reject")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(policy(time.Hour, n8nv1alpha1.ExecutionTimeoutReject)))
+
+		_, err = NewExecutionTimeoutPolicy(0, "Clamp")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = NewExecutionTimeoutPolicy(500*time.Millisecond, "Clamp")
+		Expect(err).To(MatchError(ContainSubstring("must be 0 or at least 1s")))
+		_, err = NewExecutionTimeoutPolicy(time.Hour, "Truncate")
+		Expect(err).To(MatchError(ContainSubstring(`got "Truncate"`)))
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// unfinishedExecutionAllowance is how many running or waiting executions may
+// sit in front of the finished ones counted for ExecutionHealthy
+const unfinishedExecutionAllowance = 5
+
+// recordLastExecution copies the ID, status and start time of the workflow's
+// most recent execution into status and updates the ExecutionHealthy
+// condition. Execution history is informational, so a failure to fetch it is
+// logged and the previously recorded values are kept.
+func (r *N8nWorkflowReconciler) recordLastExecution(ctx context.Context, n8nClient *n8n.Client, workflow *n8nv1alpha1.N8nWorkflow) {
+	threshold := workflow.GetExecutionFailureThreshold()
+	executions, err := n8nClient.ListExecutions(ctx, workflow.Status.WorkflowID, &n8n.ExecutionListOptions{Limit: threshold + unfinishedExecutionAllowance})
+	if err != nil {
+		logf.FromContext(ctx).Info("Failed to get the last execution", "workflowId", workflow.Status.WorkflowID, "error", err.Error())
+		return
+	}
+	if len(executions) == 0 {
+		return
+	}
+
+	last := executions[0]
+	workflow.Status.LastExecutionID = last.ID.String()
+	workflow.Status.LastExecutionStatus = last.Status
+	workflow.Status.LastExecutionTime = nil
+	if startedAt, err := time.Parse(time.RFC3339, last.StartedAt); err == nil {
+		workflow.Status.LastExecutionTime = &metav1.Time{Time: startedAt}
+	}
+
+	r.updateExecutionHealth(workflow, executions, threshold)
+}
+
+// updateExecutionHealth sets ExecutionHealthy False once the newest threshold
+// finished executions all failed, and True again after one succeeds. Executions
+// still running or waiting say nothing either way and are skipped, so the
+// condition is left alone until enough executions have finished.
+func (r *N8nWorkflowReconciler) updateExecutionHealth(workflow *n8nv1alpha1.N8nWorkflow, executions []n8n.Execution, threshold int) {
+	failures := 0
+	for _, execution := range executions {
+		switch execution.Status {
+		case n8n.ExecutionStatusError, n8n.ExecutionStatusCrashed:
+			failures++
+			if failures < threshold {
+				continue
+			}
+			wasFailing := meta.IsStatusConditionFalse(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeExecutionHealthy)
+			message := fmt.Sprintf("The last %d executions failed", threshold)
+			if threshold == 1 {
+				message = "The last execution failed"
+			}
+			r.setCondition(workflow, n8nv1alpha1.ConditionTypeExecutionHealthy, metav1.ConditionFalse,
+				n8nv1alpha1.ReasonExecutionsFailing, message)
+			if !wasFailing {
+				r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonExecutionsFailing,
+					fmt.Sprintf("%s; last failed execution %s", message, workflow.Status.LastExecutionID))
+			}
+			return
+		case n8n.ExecutionStatusSuccess:
+			if meta.IsStatusConditionFalse(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeExecutionHealthy) {
+				r.Recorder.Event(workflow, corev1.EventTypeNormal, "ExecutionsRecovered",
+					fmt.Sprintf("Execution %s succeeded", execution.ID))
+			}
+			r.setCondition(workflow, n8nv1alpha1.ConditionTypeExecutionHealthy, metav1.ConditionTrue,
+				n8nv1alpha1.ReasonExecutionsSucceeding, fmt.Sprintf("Execution %s succeeded", execution.ID))
+			return
+		}
+	}
+}
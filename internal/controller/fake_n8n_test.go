@@ -0,0 +1,814 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// fakeN8n is an in-memory stand-in for the n8n REST API used by controller tests
+type fakeN8n struct {
+	mu        sync.Mutex
+	server    *httptest.Server
+	workflows map[string]*n8n.Workflow
+	nextID    int
+
+	// credentials holds created credentials, including the data n8n never returns
+	credentials map[string]*n8n.Credential
+
+	// credentialSchemas are served by type from /api/v1/credentials/schema/
+	credentialSchemas map[string]n8n.CredentialSchema
+
+	// tags holds the tags served from /api/v1/tags, keyed by ID
+	tags map[string]*n8n.Tag
+
+	// variables holds the variables served from /api/v1/variables, keyed by ID
+	variables map[string]*n8n.Variable
+
+	// executions are served newest first from /api/v1/executions
+	executions []n8n.Execution
+
+	// requests records every call as "METHOD path"
+	requests []string
+
+	// lastBody is the body of the most recent request that had one
+	lastBody []byte
+
+	// idempotencyKeys records the Idempotency-Key header of every mutating call
+	idempotencyKeys []string
+
+	// readOnly makes the server reject writes as n8n does in maintenance mode
+	readOnly bool
+
+	// forbidWrites rejects writes with 403 as n8n does for a read-only API key
+	forbidWrites bool
+
+	// projectsUnlicensed rejects workflow transfers with 403 as n8n editions
+	// without projects do
+	projectsUnlicensed bool
+
+	// inlineTags accepts tags when creating a workflow, as releases from
+	// n8n.InlineTagsMinVersion do; by default they are read-only on create
+	inlineTags bool
+
+	// clockOffset shifts the Date header to simulate a skewed n8n clock
+	clockOffset time.Duration
+
+	// metrics is served as the Prometheus text body of /metrics
+	metrics string
+
+	// settings is served from /rest/settings when set, as n8n's editor settings
+	settings string
+
+	// delay stalls every response to simulate a slow n8n
+	delay time.Duration
+
+	// proxyAuthorization, when set, rejects requests without this Authorization
+	// header with 401, as an auth proxy in front of n8n does
+	proxyAuthorization string
+
+	// rateLimitHeaders are added to every response
+	rateLimitHeaders map[string]string
+
+	// sourceControlResult is returned by source control pulls. When nil, pulls
+	// fail as on an instance without source control connected.
+	sourceControlResult *n8n.SourceControlPullResult
+
+	// sourceControlConflict fails pulls without force with 409, as n8n does
+	// when a pull would overwrite local changes
+	sourceControlConflict bool
+
+	// auditReport is the body served from security audits; empty reports no findings
+	auditReport string
+}
+
+// newFakeN8n starts a fake n8n API server
+func newFakeN8n() *fakeN8n {
+	f := &fakeN8n{
+		workflows:   map[string]*n8n.Workflow{},
+		credentials: map[string]*n8n.Credential{},
+		tags:        map[string]*n8n.Tag{},
+		variables:   map[string]*n8n.Variable{},
+	}
+	f.server = httptest.NewServer(f)
+	return f
+}
+
+// newFakeN8nTLS starts a fake n8n API server serving HTTPS with a certificate
+// from its own CA, available from caPEM
+func newFakeN8nTLS() *fakeN8n {
+	f := newFakeN8n()
+	f.server.Close()
+	f.server = httptest.NewTLSServer(f)
+	return f
+}
+
+// caPEM returns the PEM-encoded certificate of a server started by newFakeN8nTLS
+func (f *fakeN8n) caPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.server.Certificate().Raw}))
+}
+
+// Close shuts down the fake server
+func (f *fakeN8n) Close() {
+	f.server.Close()
+}
+
+// URL returns the base URL of the fake server
+func (f *fakeN8n) URL() string {
+	return f.server.URL
+}
+
+// addWorkflow seeds a workflow and returns its ID
+func (f *fakeN8n) addWorkflow(wf n8n.Workflow) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	wf.ID = fmt.Sprintf("wf-%d", f.nextID)
+	f.workflows[wf.ID] = &wf
+	return wf.ID
+}
+
+// workflow returns a copy of the stored workflow, or nil
+func (f *fakeN8n) workflow(id string) *n8n.Workflow {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wf, ok := f.workflows[id]
+	if !ok {
+		return nil
+	}
+	out := *wf
+	return &out
+}
+
+// editWorkflow changes the stored workflow as an edit in the n8n UI would
+func (f *fakeN8n) editWorkflow(id string, edit func(wf *n8n.Workflow)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if wf, ok := f.workflows[id]; ok {
+		edit(wf)
+	}
+}
+
+// credential returns a copy of the credential with the given ID, or nil
+func (f *fakeN8n) credential(id string) *n8n.Credential {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	credential, ok := f.credentials[id]
+	if !ok {
+		return nil
+	}
+	copied := *credential
+	return &copied
+}
+
+// removeCredential deletes a credential as if it was deleted in the n8n UI
+func (f *fakeN8n) removeCredential(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.credentials, id)
+}
+
+// addTag seeds a tag and returns its ID
+func (f *fakeN8n) addTag(name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	id := fmt.Sprintf("tag-%d", f.nextID)
+	f.tags[id] = &n8n.Tag{ID: id, Name: name}
+	return id
+}
+
+// tag returns a copy of the stored tag, or nil
+func (f *fakeN8n) tag(id string) *n8n.Tag {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tag, ok := f.tags[id]
+	if !ok {
+		return nil
+	}
+	copied := *tag
+	return &copied
+}
+
+// tagNames returns the sorted names of all tags
+func (f *fakeN8n) tagNames() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.tags))
+	for _, tag := range f.tags {
+		names = append(names, tag.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addVariable seeds a variable and returns its ID
+func (f *fakeN8n) addVariable(key, value string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	id := fmt.Sprintf("var-%d", f.nextID)
+	f.variables[id] = &n8n.Variable{ID: id, Key: key, Value: value, Type: "string"}
+	return id
+}
+
+// addExecution records an execution of a workflow as the newest one
+func (f *fakeN8n) addExecution(workflowID, status, startedAt string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	id := strconv.Itoa(f.nextID)
+	execution := n8n.Execution{ID: json.Number(id), WorkflowID: workflowID, Status: status, StartedAt: startedAt}
+	f.executions = append([]n8n.Execution{execution}, f.executions...)
+	return id
+}
+
+// variable returns a copy of the variable with the given ID, or nil
+func (f *fakeN8n) variable(id string) *n8n.Variable {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	variable, ok := f.variables[id]
+	if !ok {
+		return nil
+	}
+	copied := *variable
+	return &copied
+}
+
+// setCredentialSchema makes the server publish schema for a credential type
+func (f *fakeN8n) setCredentialSchema(credentialType string, schema n8n.CredentialSchema) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.credentialSchemas == nil {
+		f.credentialSchemas = map[string]n8n.CredentialSchema{}
+	}
+	f.credentialSchemas[credentialType] = schema
+}
+
+// setReadOnly toggles maintenance / read-only mode
+func (f *fakeN8n) setReadOnly(readOnly bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.readOnly = readOnly
+}
+
+// recordedIdempotencyKeys returns the Idempotency-Key headers of mutating calls in order
+func (f *fakeN8n) recordedIdempotencyKeys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.idempotencyKeys...)
+}
+
+// lastRequestBody returns the body of the most recent request that had one
+func (f *fakeN8n) lastRequestBody() []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastBody
+}
+
+// setForbidWrites toggles rejecting writes as for a read-only API key
+func (f *fakeN8n) setForbidWrites(forbid bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.forbidWrites = forbid
+}
+
+// setProjectsUnlicensed toggles rejecting project transfers as on an unlicensed instance
+func (f *fakeN8n) setProjectsUnlicensed(unlicensed bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.projectsUnlicensed = unlicensed
+}
+
+// setInlineTags makes the server accept or reject tags when creating a workflow
+func (f *fakeN8n) setInlineTags(accept bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inlineTags = accept
+}
+
+// setClockOffset makes the server report a Date header shifted by offset
+func (f *fakeN8n) setClockOffset(offset time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clockOffset = offset
+}
+
+// setProxyAuthorization makes the server reject requests without this
+// Authorization header, as an auth proxy does
+func (f *fakeN8n) setProxyAuthorization(authorization string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.proxyAuthorization = authorization
+}
+
+// setRateLimitHeaders sets headers added to every response to report a rate limit
+func (f *fakeN8n) setRateLimitHeaders(headers map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rateLimitHeaders = headers
+}
+
+// setSourceControl connects source control, serving result from pulls and
+// failing pulls without force when conflict is set
+func (f *fakeN8n) setSourceControl(result *n8n.SourceControlPullResult, conflict bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sourceControlResult = result
+	f.sourceControlConflict = conflict
+}
+
+// setAudit sets the JSON report served from security audits
+func (f *fakeN8n) setAudit(report string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auditReport = report
+}
+
+// setMetrics sets the body served from /metrics
+func (f *fakeN8n) setMetrics(metrics string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.metrics = metrics
+}
+
+// setSettings sets the body served from /rest/settings; empty answers 404
+func (f *fakeN8n) setSettings(settings string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.settings = settings
+}
+
+// countRequests counts recorded calls matching the method and path prefix
+func (f *fakeN8n) countRequests(method, pathPrefix string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, r := range f.requests {
+		if strings.HasPrefix(r, method+" "+pathPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// requestLog returns every recorded call as "METHOD path", in order
+func (f *fakeN8n) requestLog() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+// setDelay stalls every subsequent response by d
+func (f *fakeN8n) setDelay(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delay = d
+}
+
+func (f *fakeN8n) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	delay := f.delay
+	f.mu.Unlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	if r.Method != http.MethodGet {
+		f.idempotencyKeys = append(f.idempotencyKeys, r.Header.Get("Idempotency-Key"))
+	}
+	if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+		f.lastBody = body
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if f.clockOffset != 0 {
+		w.Header().Set("Date", time.Now().Add(f.clockOffset).UTC().Format(http.TimeFormat))
+	}
+	for name, value := range f.rateLimitHeaders {
+		w.Header().Set(name, value)
+	}
+	if f.proxyAuthorization != "" && r.Header.Get("Authorization") != f.proxyAuthorization {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.URL.Path == "/metrics" {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(f.metrics))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/rest/settings" && f.settings != "" {
+		_, _ = w.Write([]byte(f.settings))
+		return
+	}
+	if f.forbidWrites && r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Forbidden"})
+		return
+	}
+	if f.readOnly && r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "n8n is in maintenance mode"})
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/workflows"), "/")
+
+	switch {
+	case r.URL.Path == "/api/v1/workflows" && r.Method == http.MethodGet:
+		list := n8n.WorkflowListResponse{Data: []n8n.Workflow{}}
+		for _, wf := range f.workflows {
+			list.Data = append(list.Data, *wf)
+		}
+		_ = json.NewEncoder(w).Encode(list)
+
+	case r.URL.Path == "/api/v1/workflows" && r.Method == http.MethodPost:
+		var wf n8n.Workflow
+		if err := json.NewDecoder(r.Body).Decode(&wf); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if len(wf.Tags) > 0 && !f.inlineTags {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "request/body/tags is read-only"})
+			return
+		}
+		for i, ref := range wf.Tags {
+			id, _ := ref["id"].(string)
+			tag, ok := f.tags[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+				return
+			}
+			wf.Tags[i] = map[string]any{"id": tag.ID, "name": tag.Name}
+		}
+		f.nextID++
+		wf.ID = fmt.Sprintf("wf-%d", f.nextID)
+		f.workflows[wf.ID] = &wf
+		_ = json.NewEncoder(w).Encode(wf)
+
+	case len(parts) >= 2 && strings.HasPrefix(r.URL.Path, "/api/v1/workflows/"):
+		wf, ok := f.workflows[parts[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+			return
+		}
+		action := ""
+		if len(parts) > 2 {
+			action = parts[2]
+		}
+		switch {
+		case r.Method == http.MethodGet && action == "":
+		case r.Method == http.MethodPut && action == "":
+			var updated n8n.Workflow
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			// Tags are read-only here, as in n8n
+			updated.ID = wf.ID
+			updated.Active = wf.Active
+			updated.Tags = wf.Tags
+			updated.Shared = wf.Shared
+			*wf = updated
+		case r.Method == http.MethodDelete && action == "":
+			delete(f.workflows, wf.ID)
+		case r.Method == http.MethodPost && action == "activate":
+			wf.Active = true
+		case r.Method == http.MethodPost && action == "deactivate":
+			wf.Active = false
+		case r.Method == http.MethodPut && action == "tags":
+			var refs []map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&refs); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			attached := []n8n.Tag{}
+			wf.Tags = nil
+			for _, ref := range refs {
+				tag, ok := f.tags[ref["id"]]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+					return
+				}
+				attached = append(attached, *tag)
+				wf.Tags = append(wf.Tags, map[string]any{"id": tag.ID, "name": tag.Name})
+			}
+			_ = json.NewEncoder(w).Encode(attached)
+			return
+		case r.Method == http.MethodPut && action == "transfer":
+			if f.projectsUnlicensed {
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Plan lacks license for this feature"})
+				return
+			}
+			var transfer map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&transfer); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			wf.Shared = []map[string]any{{"role": "workflow:owner", "projectId": transfer["destinationProjectId"]}}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(wf)
+
+	case r.URL.Path == "/api/v1/tags" && r.Method == http.MethodGet:
+		list := n8n.TagListResponse{Data: []n8n.Tag{}}
+		for _, tag := range f.tags {
+			list.Data = append(list.Data, *tag)
+		}
+		_ = json.NewEncoder(w).Encode(list)
+
+	case r.URL.Path == "/api/v1/tags" && r.Method == http.MethodPost:
+		var tag n8n.Tag
+		if err := json.NewDecoder(r.Body).Decode(&tag); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, existing := range f.tags {
+			if existing.Name == tag.Name {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Tag already exists"})
+				return
+			}
+		}
+		f.nextID++
+		tag.ID = fmt.Sprintf("tag-%d", f.nextID)
+		f.tags[tag.ID] = &tag
+		_ = json.NewEncoder(w).Encode(tag)
+
+	case strings.HasPrefix(r.URL.Path, "/api/v1/tags/") && (r.Method == http.MethodPut || r.Method == http.MethodDelete):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/tags/")
+		tag, ok := f.tags[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.tags, id)
+			_ = json.NewEncoder(w).Encode(tag)
+			return
+		}
+		var renamed n8n.Tag
+		if err := json.NewDecoder(r.Body).Decode(&renamed); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, existing := range f.tags {
+			if existing.ID != id && existing.Name == renamed.Name {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Tag already exists"})
+				return
+			}
+		}
+		tag.Name = renamed.Name
+		_ = json.NewEncoder(w).Encode(tag)
+
+	case r.URL.Path == "/api/v1/executions" && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		list := n8n.ExecutionListResponse{Data: []n8n.Execution{}}
+		for _, execution := range f.executions {
+			if execution.WorkflowID != query.Get("workflowId") ||
+				(query.Get("status") != "" && execution.Status != query.Get("status")) {
+				continue
+			}
+			if limit > 0 && len(list.Data) == limit {
+				break
+			}
+			list.Data = append(list.Data, execution)
+		}
+		_ = json.NewEncoder(w).Encode(list)
+
+	case r.URL.Path == "/api/v1/variables" && r.Method == http.MethodGet:
+		list := n8n.VariableListResponse{Data: []n8n.Variable{}}
+		for _, variable := range f.variables {
+			list.Data = append(list.Data, *variable)
+		}
+		_ = json.NewEncoder(w).Encode(list)
+
+	case r.URL.Path == "/api/v1/variables" && r.Method == http.MethodPost:
+		var variable n8n.Variable
+		if err := json.NewDecoder(r.Body).Decode(&variable); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, existing := range f.variables {
+			if existing.Key == variable.Key {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Variable with key already exists"})
+				return
+			}
+		}
+		f.nextID++
+		variable.ID = fmt.Sprintf("var-%d", f.nextID)
+		variable.Type = "string"
+		f.variables[variable.ID] = &variable
+		// Like n8n, answer with no body
+		w.WriteHeader(http.StatusCreated)
+
+	case strings.HasPrefix(r.URL.Path, "/api/v1/variables/") && (r.Method == http.MethodPut || r.Method == http.MethodDelete):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/variables/")
+		variable, ok := f.variables[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.variables, id)
+		} else if err := json.NewDecoder(r.Body).Decode(variable); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.URL.Path == "/api/v1/credentials" && r.Method == http.MethodPost:
+		var credential n8n.Credential
+		if err := json.NewDecoder(r.Body).Decode(&credential); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		credential.ID = fmt.Sprintf("cred-%d", f.nextID)
+		f.credentials[credential.ID] = &credential
+		_ = json.NewEncoder(w).Encode(n8n.Credential{ID: credential.ID, Name: credential.Name, Type: credential.Type})
+
+	case strings.HasPrefix(r.URL.Path, "/api/v1/credentials/schema/") && r.Method == http.MethodGet:
+		schema, ok := f.credentialSchemas[strings.TrimPrefix(r.URL.Path, "/api/v1/credentials/schema/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(schema)
+
+	case strings.HasPrefix(r.URL.Path, "/api/v1/credentials/") && r.Method == http.MethodDelete:
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/credentials/")
+		credential, ok := f.credentials[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+			return
+		}
+		delete(f.credentials, id)
+		_ = json.NewEncoder(w).Encode(n8n.Credential{ID: credential.ID, Name: credential.Name, Type: credential.Type})
+
+	case strings.HasPrefix(r.URL.Path, "/api/v1/credentials/") && r.Method == http.MethodPatch:
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/credentials/")
+		credential, ok := f.credentials[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Not Found"})
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(credential); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(n8n.Credential{ID: credential.ID, Name: credential.Name, Type: credential.Type})
+
+	case r.URL.Path == "/api/v1/source-control/pull" && r.Method == http.MethodPost:
+		var req n8n.SourceControlPullRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch {
+		case f.sourceControlResult == nil:
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Source Control is not connected to a repository"})
+		case f.sourceControlConflict && !req.Force:
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(n8n.ErrorResponse{Message: "Conflicts found while pulling"})
+		default:
+			_ = json.NewEncoder(w).Encode(f.sourceControlResult)
+		}
+
+	case r.URL.Path == "/api/v1/audit" && r.Method == http.MethodPost:
+		if f.auditReport == "" {
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+		_, _ = w.Write([]byte(f.auditReport))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// createReadyInstance creates an N8nInstance and API key secret pointing at url,
+// and marks the instance Ready so workflows can resolve a client from it
+func createReadyInstance(ctx context.Context, name, namespace, url string) *n8nv1alpha1.N8nInstance {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-api-key", Namespace: namespace},
+		Data:       map[string][]byte{"api-key": []byte("test-key")},
+	}
+	Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+	instance := &n8nv1alpha1.N8nInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: n8nv1alpha1.N8nInstanceSpec{
+			URL:         url,
+			Credentials: n8nv1alpha1.CredentialsRef{SecretName: secret.Name},
+		},
+	}
+	Expect(k8sClient.Create(ctx, instance)).To(Succeed())
+
+	instance.Status.Ready = true
+	instance.Status.URL = url
+	Expect(k8sClient.Status().Update(ctx, instance)).To(Succeed())
+	return instance
+}
+
+// deleteInstance removes an instance created by createReadyInstance
+func deleteInstance(ctx context.Context, instance *n8nv1alpha1.N8nInstance) {
+	Expect(k8sClient.Delete(ctx, instance)).To(Succeed())
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: instance.Spec.Credentials.SecretName, Namespace: instance.Namespace}}
+	Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+}
+
+// cleanupWorkflow strips finalizers from and deletes an N8nWorkflow if it still exists
+func cleanupWorkflow(ctx context.Context, key types.NamespacedName) {
+	resource := &n8nv1alpha1.N8nWorkflow{}
+	if err := k8sClient.Get(ctx, key, resource); err != nil {
+		return
+	}
+	if len(resource.Finalizers) > 0 {
+		resource.Finalizers = nil
+		Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+	}
+	Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+}
+
+// reconcileTimes runs the reconciler n times for key, failing on any error
+func reconcileTimes(ctx context.Context, r reconcile.Reconciler, key types.NamespacedName, n int) {
+	for i := 0; i < n; i++ {
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+}
+
+// drainEvents returns and removes the events recorded so far
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	return events
+}
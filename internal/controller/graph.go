@@ -0,0 +1,346 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// mainConnection is the connection type carrying items between executed nodes.
+// Other types, such as ai_languageModel, attach a sub-node to the node it serves.
+const mainConnection = "main"
+
+// loopControlTypes are node types that can end a loop by routing or dropping
+// items, so a cycle through them is intended
+var loopControlTypes = []string{
+	"n8n-nodes-base.if",
+	"n8n-nodes-base.switch",
+	"n8n-nodes-base.filter",
+	"n8n-nodes-base.splitInBatches",
+}
+
+// graphEdge is one link from a node output to another node
+type graphEdge struct {
+	source string
+	target string
+	// kind is the connection type, e.g. "main"
+	kind string
+	// output is the index of the source output the link leaves from
+	output int
+}
+
+// workflowGraph is the node graph described by a workflow's connections
+type workflowGraph struct {
+	// names lists the nodes in authored order
+	names []string
+	nodes map[string]map[string]any
+	edges []graphEdge
+	// mainOutputs is the number of main outputs listed for each source,
+	// including outputs with no links
+	mainOutputs map[string]int
+}
+
+// buildWorkflowGraph collects the nodes of wf and the edges of its
+// {source: {type: [[{node: target}]]}} connections
+func buildWorkflowGraph(wf *n8n.Workflow) *workflowGraph {
+	g := &workflowGraph{nodes: make(map[string]map[string]any, len(wf.Nodes)), mainOutputs: map[string]int{}}
+	for _, node := range wf.Nodes {
+		name, _ := node["name"].(string)
+		if _, dup := g.nodes[name]; name == "" || dup {
+			continue
+		}
+		g.names = append(g.names, name)
+		g.nodes[name] = node
+	}
+
+	sources := make([]string, 0, len(wf.Connections))
+	for source := range wf.Connections {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		byType, _ := wf.Connections[source].(map[string]any)
+		kinds := make([]string, 0, len(byType))
+		for kind := range byType {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			outputs, _ := byType[kind].([]any)
+			if kind == mainConnection {
+				g.mainOutputs[source] = len(outputs)
+			}
+			for output, group := range outputs {
+				links, _ := group.([]any)
+				for _, link := range links {
+					linkMap, _ := link.(map[string]any)
+					if target, ok := linkMap["node"].(string); ok {
+						g.edges = append(g.edges, graphEdge{source: source, target: target, kind: kind, output: output})
+					}
+				}
+			}
+		}
+	}
+	return g
+}
+
+// GraphIssues are the problems found in a workflow's connection graph
+type GraphIssues struct {
+	// Dangling are connections from or to a node that doesn't exist
+	Dangling []string
+	// Cycles are loops no node can leave, so items would circle forever
+	Cycles []string
+	// Unreachable are nodes no trigger leads to, which never run
+	Unreachable []string
+}
+
+// Blocking reports whether the graph is broken enough that syncing it would
+// only deploy a workflow that can't work. Unreachable nodes are allowed, since
+// unfinished branches are common while a workflow is being built.
+func (g *GraphIssues) Blocking() bool {
+	return len(g.Dangling) > 0 || len(g.Cycles) > 0
+}
+
+// Empty reports whether no issue was found
+func (g *GraphIssues) Empty() bool {
+	return !g.Blocking() && len(g.Unreachable) == 0
+}
+
+func (g *GraphIssues) String() string {
+	var parts []string
+	parts = append(parts, g.Dangling...)
+	parts = append(parts, g.Cycles...)
+	if len(g.Unreachable) > 0 {
+		parts = append(parts, "not reachable from any trigger: "+quoteAll(g.Unreachable))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// analyzeGraph builds the node graph of wf and reports connections to missing
+// nodes, nodes no trigger reaches and cycles without a way out
+func analyzeGraph(wf *n8n.Workflow) *GraphIssues {
+	g := buildWorkflowGraph(wf)
+	issues := &GraphIssues{}
+	seenSources := map[string]bool{}
+	for _, edge := range g.edges {
+		if _, ok := g.nodes[edge.source]; !ok {
+			if !seenSources[edge.source] {
+				seenSources[edge.source] = true
+				issues.Dangling = append(issues.Dangling, fmt.Sprintf("connection source %q is not a node", edge.source))
+			}
+			continue
+		}
+		if _, ok := g.nodes[edge.target]; !ok {
+			issues.Dangling = append(issues.Dangling,
+				fmt.Sprintf("connection from %q targets missing node %q", edge.source, edge.target))
+		}
+	}
+	issues.Unreachable = g.unreachable()
+	for _, cycle := range g.closedCycles() {
+		issues.Cycles = append(issues.Cycles, describeCycle(cycle))
+	}
+	return issues
+}
+
+// describeCycle explains why the cycle formed by names is a problem
+func describeCycle(names []string) string {
+	if len(names) == 1 {
+		return fmt.Sprintf("node %q connects to itself with no way out", names[0])
+	}
+	return fmt.Sprintf("nodes %s form a cycle no node can leave", quoteAll(names))
+}
+
+// unreachable returns the nodes, in authored order, that no trigger leads to.
+// Main connections are followed forward; a sub-node attached through another
+// connection type is reached with the node it serves. Workflows without any
+// trigger are not checked, as n8n can only run them by hand.
+func (g *workflowGraph) unreachable() []string {
+	forward := map[string][]string{}
+	attached := map[string][]string{}
+	for _, edge := range g.edges {
+		if edge.kind == mainConnection {
+			forward[edge.source] = append(forward[edge.source], edge.target)
+		} else {
+			attached[edge.target] = append(attached[edge.target], edge.source)
+		}
+	}
+
+	reached := map[string]bool{}
+	var queue []string
+	for _, name := range g.names {
+		if isTriggerNode(g.nodes[name]) {
+			reached[name] = true
+			queue = append(queue, name)
+		}
+	}
+	if len(queue) == 0 {
+		return nil
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, next := range append(forward[name], attached[name]...) {
+			if _, ok := g.nodes[next]; ok && !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	var unreachable []string
+	for _, name := range g.names {
+		if !reached[name] && !isStickyNote(g.nodes[name]) {
+			unreachable = append(unreachable, name)
+		}
+	}
+	return unreachable
+}
+
+// closedCycles returns the cycles of main connections that contain neither a
+// loop control node nor a node with an output that doesn't continue the cycle.
+// Each cycle lists its nodes in authored order.
+func (g *workflowGraph) closedCycles() [][]string {
+	var cycles [][]string
+	for _, component := range g.stronglyConnected() {
+		members := map[string]bool{}
+		for _, name := range component {
+			members[name] = true
+		}
+		if len(component) == 1 && !g.hasSelfLoop(component[0]) {
+			continue
+		}
+		if !g.canLeave(members) {
+			cycles = append(cycles, component)
+		}
+	}
+	return cycles
+}
+
+// hasSelfLoop reports whether a main connection leads from name back to itself
+func (g *workflowGraph) hasSelfLoop(name string) bool {
+	for _, edge := range g.edges {
+		if edge.kind == mainConnection && edge.source == name && edge.target == name {
+			return true
+		}
+	}
+	return false
+}
+
+// canLeave reports whether items can stop circling the cycle formed by members
+func (g *workflowGraph) canLeave(members map[string]bool) bool {
+	for name := range members {
+		nodeType, _ := g.nodes[name]["type"].(string)
+		for _, loopType := range loopControlTypes {
+			if nodeType == loopType {
+				return true
+			}
+		}
+	}
+	// An output with no link back into the cycle, even an unconnected one,
+	// routes items out of it
+	inside := map[string]map[int]bool{}
+	for _, edge := range g.edges {
+		if edge.kind != mainConnection || !members[edge.source] || !members[edge.target] {
+			continue
+		}
+		if inside[edge.source] == nil {
+			inside[edge.source] = map[int]bool{}
+		}
+		inside[edge.source][edge.output] = true
+	}
+	for name := range members {
+		for output := 0; output < g.mainOutputs[name]; output++ {
+			if !inside[name][output] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stronglyConnected returns the strongly connected components of the main
+// connection graph using Tarjan's algorithm, each in authored node order
+func (g *workflowGraph) stronglyConnected() [][]string {
+	successors := map[string][]string{}
+	for _, edge := range g.edges {
+		if _, ok := g.nodes[edge.target]; ok && edge.kind == mainConnection {
+			successors[edge.source] = append(successors[edge.source], edge.target)
+		}
+	}
+	position := make(map[string]int, len(g.names))
+	for i, name := range g.names {
+		position[name] = i
+	}
+
+	index := map[string]int{}
+	lowLink := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var components [][]string
+	var connect func(name string)
+	connect = func(name string) {
+		index[name] = len(index)
+		lowLink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+		for _, next := range successors[name] {
+			if _, visited := index[next]; !visited {
+				connect(next)
+				lowLink[name] = min(lowLink[name], lowLink[next])
+			} else if onStack[next] {
+				lowLink[name] = min(lowLink[name], index[next])
+			}
+		}
+		if lowLink[name] != index[name] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == name {
+				break
+			}
+		}
+		sort.Slice(component, func(i, j int) bool { return position[component[i]] < position[component[j]] })
+		components = append(components, component)
+	}
+	for _, name := range g.names {
+		if _, visited := index[name]; !visited {
+			connect(name)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return position[components[i][0]] < position[components[j][0]]
+	})
+	return components
+}
+
+// quoteAll formats names as a comma separated list of quoted strings
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("Workflow graph analysis", func() {
+	// analyze decodes a workflow holding nodes and connections and analyzes its graph
+	analyze := func(nodes, connections string) *GraphIssues {
+		wf := &n8n.Workflow{}
+		Expect(json.Unmarshal([]byte(`{"nodes":`+nodes+`,"connections":`+connections+`}`), wf)).To(Succeed())
+		return analyzeGraph(wf)
+	}
+
+	const chain = `[
+		{"name":"Start","type":"n8n-nodes-base.manualTrigger"},
+		{"name":"Fetch","type":"n8n-nodes-base.httpRequest"},
+		{"name":"Store","type":"n8n-nodes-base.set"}
+	]`
+
+	It("should accept a valid graph", func() {
+		issues := analyze(chain, `{
+			"Start":{"main":[[{"node":"Fetch","type":"main","index":0}]]},
+			"Fetch":{"main":[[{"node":"Store","type":"main","index":0}]]}
+		}`)
+		Expect(issues.Empty()).To(BeTrue())
+	})
+
+	It("should report connections to and from missing nodes", func() {
+		issues := analyze(chain, `{
+			"Start":{"main":[[{"node":"Fetch","type":"main","index":0}]]},
+			"Fetch":{"main":[[{"node":"Stroe","type":"main","index":0}]]},
+			"Ghost":{"main":[[{"node":"Store","type":"main","index":0}]]}
+		}`)
+		Expect(issues.Blocking()).To(BeTrue())
+		Expect(issues.Dangling).To(Equal([]string{
+			`connection from "Fetch" targets missing node "Stroe"`,
+			`connection source "Ghost" is not a node`,
+		}))
+	})
+
+	It("should report a cycle no node can leave", func() {
+		issues := analyze(chain, `{
+			"Start":{"main":[[{"node":"Fetch","type":"main","index":0}]]},
+			"Fetch":{"main":[[{"node":"Store","type":"main","index":0}]]},
+			"Store":{"main":[[{"node":"Fetch","type":"main","index":0}]]}
+		}`)
+		Expect(issues.Blocking()).To(BeTrue())
+		Expect(issues.Cycles).To(Equal([]string{`nodes "Fetch", "Store" form a cycle no node can leave`}))
+	})
+
+	It("should report a node connected to itself", func() {
+		issues := analyze(chain, `{
+			"Start":{"main":[[{"node":"Fetch","type":"main","index":0}]]},
+			"Fetch":{"main":[[{"node":"Fetch","type":"main","index":0},{"node":"Store","type":"main","index":0}]]}
+		}`)
+		Expect(issues.Cycles).To(Equal([]string{`node "Fetch" connects to itself with no way out`}))
+	})
+
+	It("should accept a cycle left on another output", func() {
+		issues := analyze(chain, `{
+			"Start":{"main":[[{"node":"Fetch","type":"main","index":0}]]},
+			"Fetch":{"main":[[{"node":"Store","type":"main","index":0}]]},
+			"Store":{"main":[[],[{"node":"Fetch","type":"main","index":0}]]}
+		}`)
+		Expect(issues.Cycles).To(BeEmpty())
+	})
+
+	It("should accept a cycle through a loop node", func() {
+		issues := analyze(`[
+			{"name":"Start","type":"n8n-nodes-base.manualTrigger"},
+			{"name":"Loop","type":"n8n-nodes-base.splitInBatches"},
+			{"name":"Fetch","type":"n8n-nodes-base.httpRequest"}
+		]`, `{
+			"Start":{"main":[[{"node":"Loop","type":"main","index":0}]]},
+			"Loop":{"main":[[],[{"node":"Fetch","type":"main","index":0}]]},
+			"Fetch":{"main":[[{"node":"Loop","type":"main","index":0}]]}
+		}`)
+		Expect(issues.Empty()).To(BeTrue())
+	})
+
+	It("should report nodes no trigger reaches without blocking", func() {
+		issues := analyze(`[
+			{"name":"Start","type":"n8n-nodes-base.manualTrigger"},
+			{"name":"Agent","type":"@n8n/n8n-nodes-langchain.agent"},
+			{"name":"Model","type":"@n8n/n8n-nodes-langchain.lmChatOpenAi"},
+			{"name":"Draft","type":"n8n-nodes-base.set"},
+			{"name":"Note","type":"n8n-nodes-base.stickyNote"}
+		]`, `{
+			"Start":{"main":[[{"node":"Agent","type":"main","index":0}]]},
+			"Model":{"ai_languageModel":[[{"node":"Agent","type":"ai_languageModel","index":0}]]}
+		}`)
+		Expect(issues.Blocking()).To(BeFalse())
+		Expect(issues.Unreachable).To(Equal([]string{"Draft"}))
+	})
+
+	It("should not check reachability without a trigger", func() {
+		issues := analyze(`[{"name":"Fetch","type":"n8n-nodes-base.httpRequest"}]`, `{}`)
+		Expect(issues.Empty()).To(BeTrue())
+	})
+})
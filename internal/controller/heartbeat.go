@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LeaseHeartbeat renews a Lease each time a reconcile succeeds, so an external
+// watchdog can alert when spec.renewTime stops advancing. A nil heartbeat is
+// valid and does nothing.
+type LeaseHeartbeat struct {
+	Client client.Client
+
+	// Name and Namespace identify the Lease, which is created if missing
+	Name      string
+	Namespace string
+
+	// Identity is recorded as the lease holder, typically the pod name
+	Identity string
+
+	// MinInterval throttles renewals so busy reconcilers don't write on every pass
+	MinInterval time.Duration
+
+	mu        sync.Mutex
+	lastRenew time.Time
+}
+
+// RecordSuccess renews the Lease unless it was renewed within MinInterval.
+// Failures are logged rather than returned so the heartbeat never fails a reconcile.
+func (h *LeaseHeartbeat) RecordSuccess(ctx context.Context) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	if !h.lastRenew.IsZero() && now.Sub(h.lastRenew) < h.MinInterval {
+		return
+	}
+
+	if err := h.renew(ctx, now); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to renew heartbeat lease", "lease", h.Namespace+"/"+h.Name)
+		return
+	}
+	h.lastRenew = now
+}
+
+// renew writes now as the Lease renew time, creating the Lease if needed
+func (h *LeaseHeartbeat) renew(ctx context.Context, now time.Time) error {
+	renewTime := metav1.NewMicroTime(now)
+	// Tell watchdogs how stale the lease may get before reconciles count as stalled
+	duration := int32((2 * max(h.MinInterval, defaultRequeueInterval)).Seconds())
+
+	lease := &coordinationv1.Lease{}
+	err := h.Client.Get(ctx, types.NamespacedName{Name: h.Name, Namespace: h.Namespace}, lease)
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: h.Name, Namespace: h.Namespace},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &h.Identity,
+				LeaseDurationSeconds: &duration,
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		}
+		return h.Client.Create(ctx, lease)
+	}
+	if err != nil {
+		return err
+	}
+
+	lease.Spec.HolderIdentity = &h.Identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &renewTime
+	return h.Client.Update(ctx, lease)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// importConfigMapSuffix is appended to the workflow name to name the ConfigMap
+// an imported workflow definition is written to
+const importConfigMapSuffix = "-n8n-import"
+
+// importPending reports whether the workflow only asks to import an existing
+// n8n workflow so far. Once nodes, a template or a base workflow are set, the
+// spec is user-managed and synced.
+func importPending(workflow *n8nv1alpha1.N8nWorkflow) bool {
+	return workflow.Spec.ImportFromID != "" && len(workflow.Spec.Workflow.Nodes) == 0 &&
+		workflow.Spec.TemplateRef == nil && workflow.Spec.Workflow.BaseFrom == nil
+}
+
+// adoptID returns the n8n ID of the existing workflow to take over, from the
+// adoption annotation or else spec.importFromId
+func adoptID(workflow *n8nv1alpha1.N8nWorkflow) string {
+	if id := workflow.Annotations[adoptedWorkflowIDAnnotation]; id != "" {
+		return id
+	}
+	return workflow.Spec.ImportFromID
+}
+
+// importWorkflow exports the n8n workflow named by spec.importFromId to a
+// ConfigMap as spec.workflow JSON, so it can be copied into the manifest. It
+// runs once per ID: status.import guards against re-exporting, and nothing is
+// written to n8n or the spec. The workflow stays NotReady until nodes are set.
+func (r *N8nWorkflowReconciler) importWorkflow(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, n8nClient *n8n.Client) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	id := workflow.Spec.ImportFromID
+
+	if record := workflow.Status.Import; record == nil || record.WorkflowID != id {
+		record, err := r.exportWorkflow(ctx, workflow, n8nClient, id)
+		if err != nil {
+			log.Error(err, "Failed to import workflow", "id", id)
+			r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to import workflow %s: %v", id, err))
+			if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+				log.Error(statusErr, "Failed to update status")
+			}
+			return r.errorRequeue(workflow), err
+		}
+		workflow.Status.Import = record
+		log.Info("Imported workflow from n8n", "id", id, "configMap", record.ConfigMap)
+		recordChange(r.Recorder, workflow, n8nv1alpha1.ReasonImported,
+			fmt.Sprintf("Workflow %s exported to ConfigMap %s", id, record.ConfigMap))
+	}
+
+	workflow.Status.ObservedGeneration = workflow.Generation
+	r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse, n8nv1alpha1.ReasonImported,
+		fmt.Sprintf("Workflow %s exported to ConfigMap %s; copy its workflow.json into spec.workflow to start syncing",
+			id, workflow.Status.Import.ConfigMap))
+	if err := r.Status().Update(ctx, workflow); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// exportWorkflow fetches the n8n workflow and writes it to the import ConfigMap.
+// Pinned and static data are left out: they hold execution payloads and node
+// state such as polling cursors, which don't belong in a readable ConfigMap.
+func (r *N8nWorkflowReconciler) exportWorkflow(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, n8nClient *n8n.Client, id string) (*n8nv1alpha1.ImportRecord, error) {
+	existing, err := n8nClient.GetWorkflow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := workflowSpecFromN8n(existing)
+	if err != nil {
+		return nil, err
+	}
+	spec.PinData, spec.StaticData = nil, nil
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workflow: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name + importConfigMapSuffix,
+			Namespace: workflow.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{
+			"workflow.json": string(data),
+			"workflowId":    id,
+			"active":        strconv.FormatBool(existing.Active),
+		}
+		return controllerutil.SetControllerReference(workflow, configMap, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write ConfigMap %s: %w", configMap.Name, err)
+	}
+
+	return &n8nv1alpha1.ImportRecord{WorkflowID: id, ConfigMap: configMap.Name, Time: metav1.Now()}, nil
+}
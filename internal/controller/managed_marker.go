@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+const (
+	// DefaultManagedByKey is the workflow meta key marking operator-managed workflows
+	DefaultManagedByKey = "managedBy"
+
+	// DefaultManagedByValue identifies this operator under DefaultManagedByKey
+	DefaultManagedByValue = "n8n-resource-operator"
+
+	// OwnerUIDMetaKey is the workflow meta key holding the UID of the resource
+	// that syncs the workflow
+	OwnerUIDMetaKey = "ownerUid"
+
+	// OwnerMetaKey is the workflow meta key holding the namespace/name of the
+	// resource that syncs the workflow
+	OwnerMetaKey = "owner"
+
+	// ReleasedMetaKey is the workflow meta key recording the namespace/name of
+	// the resource that left the workflow in n8n on deletion. Released workflows
+	// are no longer owned, so the orphan sweep keeps them.
+	ReleasedMetaKey = "releasedBy"
+)
+
+// ManagedByMarker is the key/value pair injected into the meta of every workflow
+// the operator syncs. Operators sharing an n8n instance should use distinct values
+// (or keys) so each only claims its own workflows.
+type ManagedByMarker struct {
+	Key   string
+	Value string
+}
+
+// orDefault fills in the default key and value when unset
+func (m ManagedByMarker) orDefault() ManagedByMarker {
+	if m.Key == "" {
+		m.Key = DefaultManagedByKey
+	}
+	if m.Value == "" {
+		m.Value = DefaultManagedByValue
+	}
+	return m
+}
+
+// String formats the marker as key=value
+func (m ManagedByMarker) String() string {
+	return fmt.Sprintf("%s=%s", m.Key, m.Value)
+}
+
+// apply sets the marker in the workflow's meta
+func (m ManagedByMarker) apply(wf *n8n.Workflow) {
+	if wf.Meta == nil {
+		wf.Meta = map[string]any{}
+	}
+	wf.Meta[m.Key] = m.Value
+}
+
+// marks reports whether the workflow carries this marker
+func (m ManagedByMarker) marks(wf *n8n.Workflow) bool {
+	value, ok := wf.Meta[m.Key].(string)
+	return ok && value == m.Value
+}
+
+// foreign reports whether the workflow is marked under this key by someone else
+func (m ManagedByMarker) foreign(wf *n8n.Workflow) bool {
+	value, ok := wf.Meta[m.Key]
+	return ok && value != m.Value
+}
+
+// ownership is how a workflow's owner marker relates to the syncing resource
+type ownership int
+
+const (
+	// unowned workflows carry no owner marker, or the syncing resource has none
+	unowned ownership = iota
+	// ownedBySelf workflows carry the syncing resource's UID
+	ownedBySelf
+	// ownedBySameName workflows carry the resource's namespace/name but another
+	// UID, i.e. the resource was deleted and created again
+	ownedBySameName
+	// ownedByOther workflows belong to another resource
+	ownedByOther
+)
+
+// ownershipOf compares the owner marker in the workflow's meta with the owner
+// UID and namespace/name in opts
+func ownershipOf(wf *n8n.Workflow, opts SyncOptions) ownership {
+	if opts.OwnerUID == "" && opts.OwnerName == "" {
+		return unowned
+	}
+	uid, _ := wf.Meta[OwnerUIDMetaKey].(string)
+	name, _ := wf.Meta[OwnerMetaKey].(string)
+	switch {
+	case uid == "" && name == "":
+		return unowned
+	case uid != "" && uid == opts.OwnerUID:
+		return ownedBySelf
+	case name != "" && name == opts.OwnerName:
+		return ownedBySameName
+	default:
+		return ownedByOther
+	}
+}
+
+// applyOwner sets the owner marker of opts in the workflow's meta
+func applyOwner(wf *n8n.Workflow, opts SyncOptions) {
+	if opts.OwnerUID == "" && opts.OwnerName == "" {
+		return
+	}
+	if wf.Meta == nil {
+		wf.Meta = map[string]any{}
+	}
+	if opts.OwnerUID != "" {
+		wf.Meta[OwnerUIDMetaKey] = opts.OwnerUID
+	}
+	if opts.OwnerName != "" {
+		wf.Meta[OwnerMetaKey] = opts.OwnerName
+	}
+	delete(wf.Meta, ReleasedMetaKey)
+}
+
+// release strips the owner marker from the workflow's meta and records the
+// resource that let it go, so the workflow is left alone by the orphan sweep
+// and can be adopted by any resource later
+func release(wf *n8n.Workflow, owner string) {
+	if wf.Meta == nil {
+		wf.Meta = map[string]any{}
+	}
+	delete(wf.Meta, OwnerUIDMetaKey)
+	delete(wf.Meta, OwnerMetaKey)
+	wf.Meta[ReleasedMetaKey] = owner
+}
+
+// released reports whether a deletion left the workflow in n8n on purpose
+func released(wf *n8n.Workflow) bool {
+	_, ok := wf.Meta[ReleasedMetaKey]
+	return ok
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// maxAuditReportBytes keeps the report ConfigMap well under the 1MiB object limit
+const maxAuditReportBytes = 256 * 1024
+
+// N8nAuditReconciler reconciles a N8nAudit object
+type N8nAuditReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// OperatorNamespace is the namespace where N8nInstance resources live
+	OperatorNamespace string
+	// AuditLogger receives an entry for every mutating n8n API request
+	AuditLogger logr.Logger
+	// RateLimiters bounds the request rate to each n8n instance across all clients
+	RateLimiters *n8n.RateLimiterRegistry
+	// Transports shares the connections to each n8n instance across all clients
+	Transports *n8n.TransportRegistry
+	// InstanceStates shares what was learned about each n8n instance across all clients
+	InstanceStates *n8n.InstanceStateRegistry
+	// CredentialsDir is the directory credentials.filePath of an N8nInstance
+	// must point into; empty rejects filePath
+	CredentialsDir string
+	// Retry sets how idempotent n8n requests are retried; zero values keep the
+	// client defaults
+	Retry RetryConfig
+}
+
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8naudits,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8naudits/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8naudits/finalizers,verbs=update
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile runs the security audit once per generation, or every
+// spec.interval when set, and summarizes the findings in status
+func (r *N8nAuditReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.V(1).Info("Reconciling N8nAudit")
+
+	audit := &n8nv1alpha1.N8nAudit{}
+	if err := r.Get(ctx, req.NamespacedName, audit); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("N8nAudit resource not found, ignoring")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get N8nAudit")
+		return ctrl.Result{}, err
+	}
+	if !audit.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	wait, ok := audit.NextAuditIn(time.Now())
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	if wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	n8nClient, _, err := instanceClient(ctx, r.Client, r.OperatorNamespace, audit.Spec.InstanceRef,
+		r.RateLimiters, r.Transports, r.InstanceStates, r.CredentialsDir, n8n.WithAuditLogger(r.AuditLogger), n8n.WithRetry(r.Retry.MaxAttempts, r.Retry.BaseDelay))
+	if err != nil {
+		log.Error(err, "Failed to create n8n client")
+		reason, message := clientErrorReason(err)
+		r.setCondition(audit, metav1.ConditionFalse, reason, message)
+		if statusErr := r.Status().Update(ctx, audit); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	log.Info("Running security audit", "instance", audit.Spec.InstanceRef)
+	report, err := n8nClient.GenerateAudit(ctx, auditOptions(audit))
+	if err == nil {
+		err = r.saveReport(ctx, audit, report)
+	}
+	if err != nil {
+		log.Error(err, "Failed to run security audit")
+		// Only warn on the first failure rather than on every retry
+		if previous := meta.FindStatusCondition(audit.Status.Conditions, n8nv1alpha1.ConditionTypeReady); previous == nil ||
+			previous.Reason != n8nv1alpha1.ReasonAuditFailed {
+			r.Recorder.Event(audit, corev1.EventTypeWarning, n8nv1alpha1.ReasonAuditFailed, err.Error())
+		}
+		r.setCondition(audit, metav1.ConditionFalse, n8nv1alpha1.ReasonAuditFailed,
+			fmt.Sprintf("Failed to run security audit: %v", err))
+		if statusErr := r.Status().Update(ctx, audit); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	audit.Status.Risks, audit.Status.TotalFindings = summarizeAudit(report)
+	now := metav1.Now()
+	audit.Status.LastAuditTime = &now
+	audit.Status.ObservedGeneration = audit.Generation
+	message := fmt.Sprintf("Audit found %d findings in %d risk categories", audit.Status.TotalFindings, len(audit.Status.Risks))
+	r.setCondition(audit, metav1.ConditionTrue, n8nv1alpha1.ReasonAuditSucceeded, message)
+	if err := r.Status().Update(ctx, audit); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	r.Recorder.Event(audit, corev1.EventTypeNormal, n8nv1alpha1.ReasonAuditSucceeded, message)
+
+	if audit.Spec.Interval != nil {
+		return ctrl.Result{RequeueAfter: audit.Spec.Interval.Duration}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// auditOptions returns the audit options of the spec, nil when it sets none
+func auditOptions(audit *n8nv1alpha1.N8nAudit) *n8n.AuditOptions {
+	if len(audit.Spec.Categories) == 0 && audit.Spec.DaysAbandonedWorkflow == 0 {
+		return nil
+	}
+	opts := &n8n.AuditOptions{DaysAbandonedWorkflow: int(audit.Spec.DaysAbandonedWorkflow)}
+	for _, category := range audit.Spec.Categories {
+		opts.Categories = append(opts.Categories, string(category))
+	}
+	return opts
+}
+
+// summarizeAudit counts the findings of each risk category, sorted by risk
+func summarizeAudit(report *n8n.AuditReport) ([]n8nv1alpha1.AuditRiskSummary, int32) {
+	var risks []n8nv1alpha1.AuditRiskSummary
+	var total int32
+	for title, riskReport := range report.Reports {
+		risk := riskReport.Risk
+		if risk == "" {
+			risk = title
+		}
+		findings := int32(riskReport.Findings())
+		risks = append(risks, n8nv1alpha1.AuditRiskSummary{
+			Risk:     risk,
+			Sections: int32(len(riskReport.Sections)),
+			Findings: findings,
+		})
+		total += findings
+	}
+	sort.Slice(risks, func(i, j int) bool { return risks[i].Risk < risks[j].Risk })
+	return risks, total
+}
+
+// saveReport writes the full report to spec.reportConfigMap when set and
+// records whether it had to be truncated
+func (r *N8nAuditReconciler) saveReport(ctx context.Context, audit *n8nv1alpha1.N8nAudit, report *n8n.AuditReport) error {
+	audit.Status.ReportTruncated = false
+	if audit.Spec.ReportConfigMap == "" {
+		return nil
+	}
+
+	data, truncated := truncateReport(report.Raw)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      audit.Spec.ReportConfigMap,
+			Namespace: audit.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{
+			"report.json": data,
+			"auditedAt":   time.Now().UTC().Format(time.RFC3339),
+			"truncated":   strconv.FormatBool(truncated),
+		}
+		return controllerutil.SetControllerReference(audit, configMap, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write ConfigMap %s: %w", configMap.Name, err)
+	}
+	audit.Status.ReportTruncated = truncated
+	return nil
+}
+
+// truncateReport indents the raw report and cuts it to maxAuditReportBytes,
+// keeping the result valid UTF-8
+func truncateReport(raw []byte) (string, bool) {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, raw, "", "  "); err == nil {
+		raw = indented.Bytes()
+	}
+	if len(raw) <= maxAuditReportBytes {
+		return string(raw), false
+	}
+	cut := maxAuditReportBytes
+	for cut > 0 && !utf8.RuneStart(raw[cut]) {
+		cut--
+	}
+	return string(raw[:cut]), true
+}
+
+// setCondition sets the Ready condition on the audit status
+func (r *N8nAuditReconciler) setCondition(audit *n8nv1alpha1.N8nAudit, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               n8nv1alpha1.ConditionTypeReady,
+		Status:             status,
+		ObservedGeneration: audit.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	meta.SetStatusCondition(&audit.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *N8nAuditReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&n8nv1alpha1.N8nAudit{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("n8naudit").
+		Complete(r)
+}
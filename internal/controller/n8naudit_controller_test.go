@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+const testAuditReport = `{
+  "Credentials Risk Report": {
+    "risk": "credentials",
+    "sections": [
+      {
+        "title": "Credentials not used in any workflow",
+        "location": [{"kind": "credential", "id": "1"}, {"kind": "credential", "id": "2"}]
+      }
+    ]
+  },
+  "Instance Risk Report": {
+    "risk": "instance",
+    "sections": [{"title": "Outdated instance"}]
+  }
+}`
+
+var _ = Describe("N8nAudit Controller", func() {
+	Context("When running a security audit", func() {
+		const resourceName = "test-audit"
+		const reportName = "test-audit-report"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var recorder *record.FakeRecorder
+		var controllerReconciler *N8nAuditReconciler
+
+		createAudit := func(spec n8nv1alpha1.N8nAuditSpec) {
+			spec.InstanceRef = instance.Name
+			resource := &n8nv1alpha1.N8nAudit{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		}
+
+		getAudit := func() *n8nv1alpha1.N8nAudit {
+			resource := &n8nv1alpha1.N8nAudit{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "audit-instance", "default", fakeServer.URL())
+
+			recorder = record.NewFakeRecorder(100)
+			controllerReconciler = &N8nAuditReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				Recorder:          recorder,
+				OperatorNamespace: "default",
+			}
+		})
+
+		AfterEach(func() {
+			resource := &n8nv1alpha1.N8nAudit{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: reportName, Namespace: "default"}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, configMap))).To(Succeed())
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should summarize the findings once per generation", func() {
+			fakeServer.setAudit(testAuditReport)
+			createAudit(n8nv1alpha1.N8nAuditSpec{})
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := getAudit()
+			Expect(resource.Status.Risks).To(Equal([]n8nv1alpha1.AuditRiskSummary{
+				{Risk: "credentials", Sections: 1, Findings: 2},
+				{Risk: "instance", Sections: 1, Findings: 1},
+			}))
+			Expect(resource.Status.TotalFindings).To(Equal(int32(3)))
+			Expect(resource.Status.LastAuditTime).NotTo(BeNil())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)).To(BeTrue())
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/audit")).To(Equal(1))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Audit found 3 findings in 2 risk categories")))
+		})
+
+		It("should report no findings when n8n returns an empty report", func() {
+			createAudit(n8nv1alpha1.N8nAuditSpec{})
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			resource := getAudit()
+			Expect(resource.Status.Risks).To(BeEmpty())
+			Expect(resource.Status.TotalFindings).To(BeZero())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)).To(BeTrue())
+		})
+
+		It("should write the full report to the ConfigMap", func() {
+			fakeServer.setAudit(testAuditReport)
+			createAudit(n8nv1alpha1.N8nAuditSpec{ReportConfigMap: reportName})
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: reportName, Namespace: "default"}, configMap)).To(Succeed())
+			Expect(configMap.Data["report.json"]).To(ContainSubstring("Credentials not used in any workflow"))
+			Expect(configMap.Data["truncated"]).To(Equal("false"))
+			Expect(metav1.IsControlledBy(configMap, getAudit())).To(BeTrue())
+			Expect(getAudit().Status.ReportTruncated).To(BeFalse())
+		})
+
+		It("should truncate reports too large for a ConfigMap", func() {
+			fakeServer.setAudit(`{"Nodes Risk Report": {"risk": "nodes", "sections": [{"title": "` +
+				strings.Repeat("x", maxAuditReportBytes) + `"}]}}`)
+			createAudit(n8nv1alpha1.N8nAuditSpec{ReportConfigMap: reportName})
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: reportName, Namespace: "default"}, configMap)).To(Succeed())
+			Expect(len(configMap.Data["report.json"])).To(BeNumerically("<=", maxAuditReportBytes))
+			Expect(configMap.Data["truncated"]).To(Equal("true"))
+			Expect(getAudit().Status.ReportTruncated).To(BeTrue())
+		})
+
+		It("should requeue until the interval has passed", func() {
+			createAudit(n8nv1alpha1.N8nAuditSpec{Interval: &metav1.Duration{Duration: time.Hour}})
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(And(BeNumerically(">", 0), BeNumerically("<=", time.Hour)))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/audit")).To(Equal(1))
+		})
+
+		It("should fail when the audit request fails", func() {
+			fakeServer.setAudit("not json")
+			createAudit(n8nv1alpha1.N8nAuditSpec{})
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			condition := meta.FindStatusCondition(getAudit().Status.Conditions, n8nv1alpha1.ConditionTypeReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(n8nv1alpha1.ReasonAuditFailed))
+			Expect(getAudit().Status.LastAuditTime).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,380 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// credentialFinalizerName is the finalizer used to clean up credentials in n8n
+const credentialFinalizerName = "n8n.slys.dev/credential-cleanup"
+
+// N8nCredentialReconciler reconciles a N8nCredential object
+type N8nCredentialReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// OperatorNamespace is the namespace where N8nInstance resources live
+	OperatorNamespace string
+	// AuditLogger receives an entry for every mutating n8n API request
+	AuditLogger logr.Logger
+	// RateLimiters bounds the request rate to each n8n instance across all clients
+	RateLimiters *n8n.RateLimiterRegistry
+	// Transports shares the connections to each n8n instance across all clients
+	Transports *n8n.TransportRegistry
+	// InstanceStates shares what was learned about each n8n instance across all clients
+	InstanceStates *n8n.InstanceStateRegistry
+	// CredentialsDir is the directory credentials.filePath of an N8nInstance
+	// must point into; empty rejects filePath
+	CredentialsDir string
+	// Retry sets how idempotent n8n requests are retried; zero values keep the
+	// client defaults
+	Retry RetryConfig
+}
+
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ncredentials,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ncredentials/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ncredentials/finalizers,verbs=update
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile creates the credential in n8n, updates it when the spec or the
+// Secret data changes, recreates it when it was deleted in n8n, and deletes it
+// on cleanup.
+func (r *N8nCredentialReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.V(1).Info("Reconciling N8nCredential")
+
+	credential := &n8nv1alpha1.N8nCredential{}
+	if err := r.Get(ctx, req.NamespacedName, credential); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("N8nCredential resource not found, ignoring")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get N8nCredential")
+		return ctrl.Result{}, err
+	}
+
+	// A credential that was never created in n8n has nothing to clean up, so
+	// don't let an unavailable instance hold the finalizer
+	if !credential.DeletionTimestamp.IsZero() && credential.Status.CredentialID == "" {
+		return r.handleDeletion(ctx, credential, nil)
+	}
+
+	n8nClient, _, err := instanceClient(ctx, r.Client, r.OperatorNamespace, credential.Spec.InstanceRef,
+		r.RateLimiters, r.Transports, r.InstanceStates, r.CredentialsDir, n8n.WithAuditLogger(r.AuditLogger), n8n.WithRetry(r.Retry.MaxAttempts, r.Retry.BaseDelay))
+	if err != nil {
+		log.Error(err, "Failed to create n8n client")
+		reason, message := clientErrorReason(err)
+		r.setCondition(credential, metav1.ConditionFalse, reason, message)
+		if statusErr := r.Status().Update(ctx, credential); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	// Handle deletion
+	if !credential.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, credential, n8nClient)
+	}
+
+	// Add finalizer if it doesn't exist
+	if !controllerutil.ContainsFinalizer(credential, credentialFinalizerName) {
+		controllerutil.AddFinalizer(credential, credentialFinalizerName)
+		if err := r.Update(ctx, credential); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	data, err := r.loadCredentialData(ctx, credential)
+	if err != nil {
+		log.Info("Credential data unavailable", "reason", err.Error())
+		r.setCondition(credential, metav1.ConditionFalse, n8nv1alpha1.ReasonSecretUnavailable, err.Error())
+		if statusErr := r.Status().Update(ctx, credential); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, nil
+	}
+
+	// n8n never returns credential data, so compare what was last written
+	// instead of reading the credential back
+	desired := &n8n.Credential{
+		Name: credential.Spec.Name,
+		Type: credential.Spec.Type,
+		Data: data,
+	}
+	dataHash := credentialHash(desired)
+	if credential.Status.CredentialID != "" && credential.Status.DataHash == dataHash &&
+		credential.Status.ObservedGeneration == credential.Generation {
+		r.setCondition(credential, metav1.ConditionTrue, n8nv1alpha1.ReasonSyncSucceeded,
+			fmt.Sprintf("Credential exists in n8n with ID %s", credential.Status.CredentialID))
+		if err := r.Status().Update(ctx, credential); err != nil {
+			log.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Check the type and data against n8n's schema, since n8n rejects a bad
+	// credential with an opaque 400
+	if message, reason := r.validateCredentialData(ctx, n8nClient, credential, data); message != "" {
+		log.Info("Credential data is invalid", "reason", message)
+		r.setCondition(credential, metav1.ConditionFalse, reason, message)
+		if statusErr := r.Status().Update(ctx, credential); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, nil
+	}
+
+	if credential.Status.CredentialID != "" {
+		err := n8nClient.UpdateCredential(ctx, credential.Status.CredentialID, desired)
+		switch {
+		case err == nil:
+			log.Info("Updated credential in n8n", "id", credential.Status.CredentialID)
+			recordChange(r.Recorder, credential, "Updated",
+				fmt.Sprintf("Credential %s updated in n8n", credential.Status.CredentialID))
+			return r.recordSynced(ctx, credential, credential.Status.CredentialID, dataHash)
+		case n8n.IsNotFound(err):
+			// Deleted in n8n behind the operator's back; create it again below
+			log.Info("Credential no longer exists in n8n, recreating", "id", credential.Status.CredentialID)
+			r.Recorder.Event(credential, corev1.EventTypeWarning, "CredentialMissing",
+				fmt.Sprintf("Credential %s no longer exists in n8n, recreating it", credential.Status.CredentialID))
+		default:
+			log.Error(err, "Failed to update credential in n8n")
+			r.Recorder.Event(credential, corev1.EventTypeWarning, "UpdateFailed",
+				fmt.Sprintf("Failed to update credential in n8n: %v", err))
+			r.setCondition(credential, metav1.ConditionFalse, n8nv1alpha1.ReasonSyncFailed,
+				fmt.Sprintf("Failed to update credential: %v", err))
+			if statusErr := r.Status().Update(ctx, credential); statusErr != nil {
+				log.Error(statusErr, "Failed to update status")
+			}
+			return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+		}
+	}
+
+	created, err := n8nClient.CreateCredential(n8n.WithIdempotencyKey(ctx, string(credential.UID)+"/"+dataHash), desired)
+	if err != nil {
+		log.Error(err, "Failed to create credential in n8n")
+		r.Recorder.Event(credential, corev1.EventTypeWarning, "CreateFailed",
+			fmt.Sprintf("Failed to create credential in n8n: %v", err))
+		r.setCondition(credential, metav1.ConditionFalse, n8nv1alpha1.ReasonSyncFailed,
+			fmt.Sprintf("Failed to create credential: %v", err))
+		if statusErr := r.Status().Update(ctx, credential); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	log.Info("Created credential in n8n", "id", created.ID)
+	recordChange(r.Recorder, credential, "Created",
+		fmt.Sprintf("Credential created in n8n with ID %s", created.ID))
+	return r.recordSynced(ctx, credential, created.ID, dataHash)
+}
+
+// recordSynced records the ID of the credential in n8n and the hash of what
+// was written to it, and marks the credential Ready
+func (r *N8nCredentialReconciler) recordSynced(ctx context.Context, credential *n8nv1alpha1.N8nCredential,
+	id, dataHash string) (ctrl.Result, error) {
+	credential.Status.CredentialID = id
+	credential.Status.DataHash = dataHash
+	credential.Status.ObservedGeneration = credential.Generation
+	r.setCondition(credential, metav1.ConditionTrue, n8nv1alpha1.ReasonSyncSucceeded,
+		fmt.Sprintf("Credential exists in n8n with ID %s", id))
+	if err := r.Status().Update(ctx, credential); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// credentialHash hashes the name, type and data written to n8n. Map keys are
+// marshaled in sorted order, so equal data always hashes the same.
+func credentialHash(credential *n8n.Credential) string {
+	data, err := json.Marshal(credential)
+	if err != nil {
+		// If marshaling fails, return empty string to force update
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// loadCredentialData reads the credential data from the referenced Secret,
+// either field by field or as a JSON object stored under spec.data.key
+func (r *N8nCredentialReconciler) loadCredentialData(ctx context.Context, credential *n8nv1alpha1.N8nCredential) (map[string]any, error) {
+	source := credential.Spec.Data
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: credential.Namespace, Name: source.SecretName}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("secret %q not found", source.SecretName)
+		}
+		return nil, fmt.Errorf("failed to get Secret %q: %w", source.SecretName, err)
+	}
+
+	if source.Key != "" {
+		raw, ok := secret.Data[source.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret %q does not contain key %q", source.SecretName, source.Key)
+		}
+		var data map[string]any
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("key %q of Secret %q is not a JSON object: %w", source.Key, source.SecretName, err)
+		}
+		return data, nil
+	}
+
+	data := make(map[string]any, len(secret.Data))
+	for key, value := range secret.Data {
+		data[key] = string(value)
+	}
+	return data, nil
+}
+
+// validateCredentialData checks that n8n knows the credential type and that the
+// data sets every field its schema requires. It returns an empty message when
+// the data is valid or the schema can't be fetched, leaving n8n to judge.
+func (r *N8nCredentialReconciler) validateCredentialData(ctx context.Context, n8nClient *n8n.Client,
+	credential *n8nv1alpha1.N8nCredential, data map[string]any) (string, string) {
+	schema, err := n8nClient.GetCredentialSchema(ctx, credential.Spec.Type)
+	if n8n.IsNotFound(err) {
+		return fmt.Sprintf("credential type %q is not known to n8n", credential.Spec.Type), n8nv1alpha1.ReasonInvalidCredentialType
+	}
+	if err != nil {
+		logf.FromContext(ctx).Info("Failed to get credential schema, skipping validation", "type", credential.Spec.Type, "error", err)
+		return "", ""
+	}
+
+	var missing []string
+	for _, field := range schema.Required {
+		if _, ok := data[field]; !ok {
+			missing = append(missing, fmt.Sprintf("'%s'", field))
+		}
+	}
+	switch len(missing) {
+	case 0:
+		return "", ""
+	case 1:
+		return fmt.Sprintf("credential data missing required field %s", missing[0]), n8nv1alpha1.ReasonInvalidCredentialData
+	default:
+		return fmt.Sprintf("credential data missing required fields %s", strings.Join(missing, ", ")), n8nv1alpha1.ReasonInvalidCredentialData
+	}
+}
+
+// handleDeletion deletes the credential from n8n and releases the finalizer.
+// A credential that is already gone from n8n is treated as deleted.
+func (r *N8nCredentialReconciler) handleDeletion(ctx context.Context, credential *n8nv1alpha1.N8nCredential, n8nClient *n8n.Client) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(credential, credentialFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Handling deletion of N8nCredential")
+
+	if credential.Status.CredentialID != "" {
+		log.Info("Deleting credential from n8n", "id", credential.Status.CredentialID)
+		if err := n8nClient.DeleteCredential(ctx, credential.Status.CredentialID); err != nil {
+			if n8n.IsNotFound(err) {
+				log.Info("Credential already deleted from n8n", "id", credential.Status.CredentialID)
+				r.Recorder.Event(credential, corev1.EventTypeNormal, "AlreadyDeleted",
+					"Credential no longer exists in n8n, releasing finalizer")
+			} else {
+				// Log as warning but continue with finalizer removal
+				log.Info("Failed to delete credential from n8n (continuing with cleanup)", "error", err)
+				r.Recorder.Event(credential, corev1.EventTypeWarning, "DeleteFailed",
+					fmt.Sprintf("Failed to delete credential from n8n: %v", err))
+			}
+		} else {
+			recordChange(r.Recorder, credential, "Deleted", "Credential deleted from n8n")
+		}
+	}
+
+	controllerutil.RemoveFinalizer(credential, credentialFinalizerName)
+	if err := r.Update(ctx, credential); err != nil {
+		log.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully deleted N8nCredential")
+	return ctrl.Result{}, nil
+}
+
+// credentialsForSecret maps a Secret to the credentials in its namespace that
+// read their data from it
+func (r *N8nCredentialReconciler) credentialsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	credentials := &n8nv1alpha1.N8nCredentialList{}
+	if err := r.List(ctx, credentials, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list credentials for Secret", "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, credential := range credentials.Items {
+		if credential.Spec.Data.SecretName == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&credential)})
+		}
+	}
+	return requests
+}
+
+// setCondition sets the Ready condition on the credential status
+func (r *N8nCredentialReconciler) setCondition(credential *n8nv1alpha1.N8nCredential, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               n8nv1alpha1.ConditionTypeReady,
+		Status:             status,
+		ObservedGeneration: credential.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	meta.SetStatusCondition(&credential.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *N8nCredentialReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&n8nv1alpha1.N8nCredential{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.credentialsForSecret)).
+		Named("n8ncredential").
+		Complete(r)
+}
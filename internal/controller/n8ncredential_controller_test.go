@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("N8nCredential Controller", func() {
+	Context("When managing a credential", func() {
+		const resourceName = "test-credential"
+		const secretName = "test-credential-data"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nCredentialReconciler
+		var credentialType string
+
+		createSecret := func(data map[string][]byte) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"},
+				Data:       data,
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		}
+
+		createCredential := func(key string) {
+			resource := &n8nv1alpha1.N8nCredential{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: n8nv1alpha1.N8nCredentialSpec{
+					InstanceRef: instance.Name,
+					Name:        "API Token",
+					Type:        credentialType,
+					Data:        n8nv1alpha1.CredentialDataSource{SecretName: secretName, Key: key},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			fakeServer.setCredentialSchema("httpHeaderAuth", n8n.CredentialSchema{Type: "object", Required: []string{"name", "value"}})
+			instance = createReadyInstance(ctx, "credential-instance", "default", fakeServer.URL())
+			credentialType = "httpHeaderAuth"
+
+			controllerReconciler = &N8nCredentialReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				Recorder:          record.NewFakeRecorder(100),
+				OperatorNamespace: "default",
+			}
+		})
+
+		AfterEach(func() {
+			resource := &n8nv1alpha1.N8nCredential{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+			}
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should create the credential from the Secret once and record its ID", func() {
+			createSecret(map[string][]byte{"name": []byte("Authorization"), "value": []byte("Bearer s3cret")})
+			createCredential("")
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 3)
+
+			resource := &n8nv1alpha1.N8nCredential{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.CredentialID).NotTo(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+			created := fakeServer.credential(resource.Status.CredentialID)
+			Expect(created).NotTo(BeNil())
+			Expect(created.Name).To(Equal("API Token"))
+			Expect(created.Type).To(Equal("httpHeaderAuth"))
+			Expect(created.Data).To(Equal(map[string]any{"name": "Authorization", "value": "Bearer s3cret"}))
+
+			// Unchanged data is neither created twice nor written again
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/credentials")).To(Equal(1))
+			Expect(fakeServer.countRequests(http.MethodPatch, "/api/v1/credentials/")).To(BeZero())
+			Expect(resource.Status.DataHash).NotTo(BeEmpty())
+		})
+
+		It("should update the credential when the Secret or the spec changes", func() {
+			createSecret(map[string][]byte{"name": []byte("Authorization"), "value": []byte("Bearer old")})
+			createCredential("")
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := &n8nv1alpha1.N8nCredential{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			credentialID := resource.Status.CredentialID
+			Expect(credentialID).NotTo(BeEmpty())
+
+			By("rotating the Secret")
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: "default"}, secret)).To(Succeed())
+			secret.Data["value"] = []byte("Bearer new")
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(fakeServer.credential(credentialID).Data).To(HaveKeyWithValue("value", "Bearer new"))
+
+			By("renaming the credential")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			resource.Spec.Name = "Renamed Token"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			Expect(fakeServer.credential(credentialID).Name).To(Equal("Renamed Token"))
+			Expect(fakeServer.countRequests(http.MethodPatch, "/api/v1/credentials/")).To(Equal(2))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/credentials")).To(Equal(1))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.CredentialID).To(Equal(credentialID))
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+		})
+
+		It("should recreate a credential that was deleted in n8n", func() {
+			createSecret(map[string][]byte{"name": []byte("Authorization"), "value": []byte("Bearer old")})
+			createCredential("")
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := &n8nv1alpha1.N8nCredential{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			oldID := resource.Status.CredentialID
+			fakeServer.removeCredential(oldID)
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: "default"}, secret)).To(Succeed())
+			secret.Data["value"] = []byte("Bearer new")
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.CredentialID).NotTo(BeEmpty())
+			Expect(resource.Status.CredentialID).NotTo(Equal(oldID))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)).To(BeTrue())
+			recreated := fakeServer.credential(resource.Status.CredentialID)
+			Expect(recreated).NotTo(BeNil())
+			Expect(recreated.Data).To(HaveKeyWithValue("value", "Bearer new"))
+		})
+
+		It("should read the data as a JSON object from a single key", func() {
+			createSecret(map[string][]byte{"credential.json": []byte(`{"name":"X-Retries","ssl":true,"value":3}`)})
+			createCredential("credential.json")
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := &n8nv1alpha1.N8nCredential{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			created := fakeServer.credential(resource.Status.CredentialID)
+			Expect(created).NotTo(BeNil())
+			Expect(created.Data).To(Equal(map[string]any{"name": "X-Retries", "ssl": true, "value": float64(3)}))
+		})
+
+		It("should wait for the Secret before creating the credential", func() {
+			createCredential("")
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := &n8nv1alpha1.N8nCredential{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.CredentialID).To(BeEmpty())
+			ready := meta.FindStatusCondition(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.ReasonSecretUnavailable))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/credentials")).To(Equal(0))
+
+			createSecret(map[string][]byte{"name": []byte("Authorization"), "value": []byte("s3cret")})
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.CredentialID).NotTo(BeEmpty())
+		})
+
+		DescribeTable("should validate the type and data against the n8n schema before creating",
+			func(typeName string, data map[string][]byte, expectedReason, expectedMessage string) {
+				credentialType = typeName
+				createSecret(data)
+				createCredential("")
+
+				reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+				resource := &n8nv1alpha1.N8nCredential{}
+				Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+				Expect(resource.Status.CredentialID).To(BeEmpty())
+				ready := meta.FindStatusCondition(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(expectedReason))
+				Expect(ready.Message).To(Equal(expectedMessage))
+				Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/credentials")).To(Equal(0))
+			},
+			Entry("unknown type", "httpHeaderAuthh", map[string][]byte{"name": []byte("a"), "value": []byte("b")},
+				n8nv1alpha1.ReasonInvalidCredentialType, `credential type "httpHeaderAuthh" is not known to n8n`),
+			Entry("missing field", "httpHeaderAuth", map[string][]byte{"name": []byte("Authorization")},
+				n8nv1alpha1.ReasonInvalidCredentialData, "credential data missing required field 'value'"),
+			Entry("missing fields", "httpHeaderAuth", map[string][]byte{"token": []byte("s3cret")},
+				n8nv1alpha1.ReasonInvalidCredentialData, "credential data missing required fields 'name', 'value'"),
+		)
+
+		It("should delete the credential from n8n on deletion", func() {
+			createSecret(map[string][]byte{"name": []byte("Authorization"), "value": []byte("s3cret")})
+			createCredential("")
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := &n8nv1alpha1.N8nCredential{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			credentialID := resource.Status.CredentialID
+			Expect(fakeServer.credential(credentialID)).NotTo(BeNil())
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(fakeServer.credential(credentialID)).To(BeNil())
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -19,14 +19,15 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,11 +38,15 @@ import (
 )
 
 const (
-	// Health check interval
-	healthCheckInterval = 5 * time.Minute
+	// Health check interval for instances without spec.healthCheckInterval
+	healthCheckInterval = n8nv1alpha1.DefaultReconcileInterval
 
 	// Error requeue interval for instance
 	instanceErrorRequeueInterval = 30 * time.Second
+
+	// Remaining rate limit budget, as a percentage of the limit, below which a
+	// RateLimitLow warning is raised
+	lowRateLimitPercent = 10
 )
 
 // N8nInstanceReconciler reconciles a N8nInstance object
@@ -49,16 +54,75 @@ type N8nInstanceReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// AuditLogger receives an entry for every mutating n8n API request
+	AuditLogger logr.Logger
+	// RateLimiters bounds the request rate to each n8n instance across all clients
+	RateLimiters *n8n.RateLimiterRegistry
+	// Transports shares the connections to each n8n instance across all clients
+	Transports *n8n.TransportRegistry
+	// InstanceStates shares what was learned about each n8n instance across all clients
+	InstanceStates *n8n.InstanceStateRegistry
+	// CredentialsDir is the directory credentials.filePath of an N8nInstance
+	// must point into; empty rejects filePath
+	CredentialsDir string
+	// Retry sets how idempotent n8n requests are retried; zero values keep the
+	// client defaults
+	Retry RetryConfig
+	// Heartbeat is renewed after every successful reconcile
+	Heartbeat *LeaseHeartbeat
+	// ManagedBy identifies workflows managed by this operator, which tag
+	// adoption leaves to their existing N8nWorkflow
+	ManagedBy ManagedByMarker
+	// Environment is the environment name the workflow controller matches
+	// against spec.activeIn, used to count the workflows that should be active
+	Environment string
+	// ReconcileTimeout bounds a whole reconcile, including the health check and
+	// tag adoption; a reconcile still running at the deadline is abandoned and
+	// requeued. Defaults to 2 minutes.
+	ReconcileTimeout time.Duration
+	// Backoff doubles the delay before retrying an instance each time it fails
+	// again. Nil retries every failure after 30 seconds.
+	Backoff *FailureBackoff
 }
 
 // +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances/finalizers,verbs=update
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nworkflows,verbs=get;list;watch;create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *N8nInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	deadlineCtx, cancel, timeout := withReconcileDeadline(ctx, r.ReconcileTimeout)
+	defer cancel()
+
+	result, err := r.reconcile(deadlineCtx, req)
+	// Ready is left as the last completed health check set it; the instance is
+	// simply checked again later
+	if deadlineExceeded(ctx, deadlineCtx) {
+		logf.FromContext(ctx).Info("Reconcile exceeded its deadline, abandoning", "timeout", timeout, "error", err)
+		instance := &n8nv1alpha1.N8nInstance{}
+		if getErr := r.Get(ctx, req.NamespacedName, instance); getErr == nil {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, n8nv1alpha1.InstanceReasonReconcileTimeout,
+				fmt.Sprintf("Reconcile did not finish within %s and was abandoned", timeout))
+		}
+		result, err = r.Backoff.retry(req.NamespacedName, instanceErrorRequeueInterval), nil
+	}
+	r.Backoff.finish(req.NamespacedName, err)
+	return result, err
+}
+
+// errorRequeue counts a failed reconcile of instance and returns the result
+// retrying it after the error interval, backed off while failures repeat
+func (r *N8nInstanceReconciler) errorRequeue(instance *n8nv1alpha1.N8nInstance) ctrl.Result {
+	return r.Backoff.retry(client.ObjectKeyFromObject(instance), instanceErrorRequeueInterval)
+}
+
+// reconcile does the work of Reconcile under its deadline
+func (r *N8nInstanceReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 	log.V(1).Info("Reconciling N8nInstance")
 
@@ -82,47 +146,132 @@ func (r *N8nInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if statusErr := r.Status().Update(ctx, instance); statusErr != nil {
 			log.Error(statusErr, "Failed to update status")
 		}
-		return ctrl.Result{RequeueAfter: instanceErrorRequeueInterval}, nil
+		return r.errorRequeue(instance), nil
 	}
 
-	// Resolve URL
+	// Resolve URL. status.url keeps the last validated URL until the new one passes
+	// a health check, so a URL change can be detected and migrated gradually.
 	resolvedURL := instance.GetResolvedURL()
-	instance.Status.URL = resolvedURL
+	previousURL := instance.Status.URL
 
-	// Get API key from secret
-	apiKey, err := r.getAPIKey(ctx, instance)
+	// Get API key and any proxy credentials from the secret or file
+	apiKey, auth, err := instanceCredentials(ctx, r.Client, instance, r.CredentialsDir)
 	if err != nil {
-		log.Error(err, "Failed to get API key from secret")
+		log.Error(err, "Failed to get credentials")
 		r.setCondition(instance, n8nv1alpha1.InstanceConditionTypeReady, metav1.ConditionFalse,
-			n8nv1alpha1.InstanceReasonAuthError, fmt.Sprintf("Failed to get API key: %v", err))
+			n8nv1alpha1.InstanceReasonAuthError, fmt.Sprintf("Failed to get credentials: %v", err))
 		instance.Status.Ready = false
 		r.Recorder.Event(instance, corev1.EventTypeWarning, "SecretError", err.Error())
 		if statusErr := r.Status().Update(ctx, instance); statusErr != nil {
 			log.Error(statusErr, "Failed to update status")
 		}
-		return ctrl.Result{RequeueAfter: instanceErrorRequeueInterval}, nil
+		return r.errorRequeue(instance), nil
 	}
 
-	// Create n8n client and perform health check
-	n8nClient := n8n.NewClient(resolvedURL, apiKey)
-	if err := n8nClient.HealthCheck(ctx); err != nil {
-		log.Error(err, "Health check failed")
+	// Load the CA bundle before connecting, so an internal CA is trusted by the health check
+	tlsConfig, err := instanceTLSConfig(ctx, r.Client, instance)
+	if err != nil {
+		log.Error(err, "Failed to load TLS configuration")
 		r.setCondition(instance, n8nv1alpha1.InstanceConditionTypeReady, metav1.ConditionFalse,
-			n8nv1alpha1.InstanceReasonConnectionError, fmt.Sprintf("Health check failed: %v", err))
+			n8nv1alpha1.InstanceReasonTLSConfigError, fmt.Sprintf("Failed to load TLS configuration: %v", err))
 		instance.Status.Ready = false
-		r.Recorder.Event(instance, corev1.EventTypeWarning, "HealthCheckFailed", err.Error())
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "TLSConfigError", err.Error())
 		if statusErr := r.Status().Update(ctx, instance); statusErr != nil {
 			log.Error(statusErr, "Failed to update status")
 		}
-		return ctrl.Result{RequeueAfter: instanceErrorRequeueInterval}, nil
+		return r.errorRequeue(instance), nil
 	}
 
-	// Health check passed - update status
+	// Create n8n client and perform health check
+	n8nClient := n8n.NewClient(resolvedURL, apiKey, n8n.WithAuditLogger(r.AuditLogger),
+		instanceRateLimit(r.RateLimiters, instance, resolvedURL), n8n.WithRetry(r.Retry.MaxAttempts, r.Retry.BaseDelay),
+		n8n.WithTimeout(instance.GetTimeout()), instanceTransport(r.Transports, instance, resolvedURL, tlsConfig),
+		n8n.WithInstanceState(r.InstanceStates.For(resolvedURL)), n8n.WithLogger(log.WithName("n8n")), auth)
 	now := metav1.Now()
+	instance.Status.LastHealthCheckAttempt = &now
+	if err := n8nClient.HealthCheck(ctx); err != nil {
+		return r.recordHealthCheckFailure(ctx, instance, err)
+	}
+	instance.Status.ConsecutiveHealthCheckFailures = 0
+	meta.RemoveStatusCondition(&instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)
+
+	// Health check passed - move dependent workflows over if the URL changed
+	if previousURL != "" && previousURL != resolvedURL {
+		r.startURLMigration(ctx, instance, previousURL, resolvedURL)
+	}
+	instance.Status.URL = resolvedURL
+
+	// Update status
 	instance.Status.Ready = true
 	instance.Status.LastHealthCheck = &now
 	instance.Status.ObservedGeneration = instance.Generation
 
+	// Record the release so outdated instances and missing features are visible
+	r.checkVersion(ctx, instance, n8nClient)
+
+	// Compare n8n's clock with ours, since skew makes cron triggers misfire
+	r.checkClockSkew(ctx, instance, n8nClient)
+
+	// Record the remaining rate limit budget to help right-size concurrency
+	r.checkRateLimit(ctx, instance, n8nClient)
+
+	// Verify the API key can write, since a read-only key passes the health check
+	if instance.Spec.ProbeWriteScope {
+		r.probeAPIKeyScope(ctx, instance, n8nClient)
+	} else {
+		instance.Status.APIKeyScope = ""
+	}
+
+	// Read the load indicator so dependent workflows can back off while n8n is busy
+	if instance.Spec.Backpressure != nil {
+		r.checkLoad(ctx, instance, n8nClient)
+	} else {
+		instance.Status.Load = 0
+		instance.Status.HighLoad = false
+	}
+
+	// Generate N8nWorkflow resources for tagged workflows. Failures are reported
+	// but don't affect readiness, and are retried on the next health check.
+	if instance.Spec.AdoptTagSelector != nil {
+		adopted, err := r.adoptTaggedWorkflows(ctx, instance, n8nClient)
+		if err != nil {
+			log.Error(err, "Failed to adopt tagged workflows")
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "AdoptionFailed", err.Error())
+		}
+		instance.Status.AdoptedWorkflows = adopted
+	}
+
+	// Report workflows left behind in n8n by deleted N8nWorkflows, deleting
+	// them when asked to. Failures are retried on the next health check.
+	if instance.Spec.OrphanCleanup != nil {
+		if err := r.reconcileOrphans(ctx, instance, n8nClient); err != nil {
+			log.Error(err, "Failed to clean up orphaned workflows")
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "OrphanCleanupFailed", err.Error())
+		}
+	} else {
+		instance.Status.OrphanedWorkflowCount = 0
+		instance.Status.OrphanedWorkflows = nil
+	}
+
+	// Summarize how many dependent workflows are active against how many should be
+	if err := r.countWorkflowActivation(ctx, instance); err != nil {
+		log.Error(err, "Failed to count workflow activation")
+	}
+
+	// Release the next batch of workflows while a URL migration is in progress
+	requeueAfter := instance.GetHealthCheckInterval()
+	if migration := instance.Status.URLMigration; migration != nil && migration.Phase == n8nv1alpha1.URLMigrationInProgress {
+		wait, err := r.advanceURLMigration(ctx, instance)
+		if err != nil {
+			log.Error(err, "Failed to advance URL migration")
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "URLMigrationFailed", err.Error())
+			wait = instanceErrorRequeueInterval
+		}
+		if wait > 0 && wait < requeueAfter {
+			requeueAfter = wait
+		}
+	}
+
 	r.setCondition(instance, n8nv1alpha1.InstanceConditionTypeReady, metav1.ConditionTrue,
 		n8nv1alpha1.InstanceReasonConnected, "Successfully connected to n8n instance")
 
@@ -131,8 +280,215 @@ func (r *N8nInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	r.Heartbeat.RecordSuccess(ctx)
 	log.V(1).Info("N8nInstance reconciliation complete", "url", resolvedURL, "ready", true)
-	return ctrl.Result{RequeueAfter: healthCheckInterval}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// recordHealthCheckFailure counts a failed health check. While a Ready instance
+// has failed fewer than healthCheckFailureThreshold checks in a row it only
+// turns Degraded, so dependent workflows keep syncing through a brief blip;
+// from the threshold on it is no longer Ready.
+func (r *N8nInstanceReconciler) recordHealthCheckFailure(ctx context.Context, instance *n8nv1alpha1.N8nInstance, err error) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.Error(err, "Health check failed")
+
+	instance.Status.ConsecutiveHealthCheckFailures++
+	failures, threshold := instance.Status.ConsecutiveHealthCheckFailures, instance.GetHealthCheckFailureThreshold()
+	if instance.Status.Ready && failures < threshold {
+		message := fmt.Sprintf("Health check failed %d of %d times before the instance is not ready: %v", failures, threshold, err)
+		wasDegraded := meta.IsStatusConditionTrue(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)
+		r.setCondition(instance, n8nv1alpha1.InstanceConditionTypeDegraded, metav1.ConditionTrue,
+			n8nv1alpha1.InstanceReasonHealthCheckFailing, message)
+		if !wasDegraded {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "HealthCheckDegraded", message)
+		}
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)
+		r.setCondition(instance, n8nv1alpha1.InstanceConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.InstanceReasonConnectionError, fmt.Sprintf("Health check failed: %v", err))
+		instance.Status.Ready = false
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "HealthCheckFailed", err.Error())
+	}
+
+	if statusErr := r.Status().Update(ctx, instance); statusErr != nil {
+		log.Error(statusErr, "Failed to update status")
+	}
+	return r.errorRequeue(instance), nil
+}
+
+// checkClockSkew records the skew between the n8n and operator clocks, warning
+// when it first exceeds the instance's tolerance
+func (r *N8nInstanceReconciler) checkClockSkew(ctx context.Context, instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client) {
+	skew, ok := n8nClient.ClockSkew()
+	if !ok {
+		return
+	}
+
+	maxSkew := instance.GetMaxClockSkew()
+	wasSkewed := time.Duration(abs(instance.Status.ClockSkewSeconds))*time.Second > maxSkew
+	instance.Status.ClockSkewSeconds = int64(skew.Round(time.Second) / time.Second)
+
+	if skew > maxSkew || skew < -maxSkew {
+		logf.FromContext(ctx).Info("n8n clock skew exceeds threshold", "skew", skew, "threshold", maxSkew)
+		if !wasSkewed {
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "ClockSkew",
+				fmt.Sprintf("n8n clock differs from the operator by %s (threshold %s); cron triggers may misfire", skew.Round(time.Second), maxSkew))
+		}
+	}
+}
+
+// checkVersion records the n8n version and edition. Instances that don't expose
+// them get empty fields; other failures keep the last known values.
+func (r *N8nInstanceReconciler) checkVersion(ctx context.Context, instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client) {
+	info, err := n8nClient.GetVersion(ctx)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info("Could not read n8n version", "error", err.Error())
+		if n8n.IsVersionUnavailable(err) {
+			instance.Status.Version = ""
+			instance.Status.Edition = ""
+			instance.Status.VersionSupported = nil
+			meta.RemoveStatusCondition(&instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeUnsupported)
+		}
+		return
+	}
+	instance.Status.Version = info.Version
+	instance.Status.Edition = info.Edition
+	r.checkVersionSupported(ctx, instance)
+}
+
+// checkVersionSupported sets the Unsupported condition when the recorded
+// version is outside the release range the operator is tested against,
+// warning when it first is. Syncing continues either way.
+func (r *N8nInstanceReconciler) checkVersionSupported(ctx context.Context, instance *n8nv1alpha1.N8nInstance) {
+	supported, err := n8n.IsSupportedVersion(instance.Status.Version)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info("Could not parse n8n version", "version", instance.Status.Version, "error", err.Error())
+		instance.Status.VersionSupported = nil
+		meta.RemoveStatusCondition(&instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeUnsupported)
+		return
+	}
+	instance.Status.VersionSupported = &supported
+	if supported {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeUnsupported)
+		return
+	}
+
+	message := fmt.Sprintf("n8n %s is outside the tested range %s to %s.x; syncing may break on API changes",
+		instance.Status.Version, n8n.MinSupportedVersion, n8n.MaxSupportedVersion)
+	logf.FromContext(ctx).Info("n8n version is not supported", "version", instance.Status.Version)
+	if !meta.IsStatusConditionTrue(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeUnsupported) {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "UnsupportedVersion", message)
+	}
+	r.setCondition(instance, n8nv1alpha1.InstanceConditionTypeUnsupported, metav1.ConditionTrue,
+		n8nv1alpha1.InstanceReasonVersionOutOfRange, message)
+}
+
+// checkRateLimit records the rate limit budget n8n reported, warning when the
+// remaining budget first drops below lowRateLimitPercent of the limit
+func (r *N8nInstanceReconciler) checkRateLimit(ctx context.Context, instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client) {
+	remaining, limit, ok := n8nClient.RateLimit()
+	if !ok {
+		instance.Status.RateLimitRemaining = nil
+		instance.Status.RateLimitLimit = 0
+		return
+	}
+
+	wasLow := instance.Status.RateLimitRemaining != nil &&
+		isRateLimitLow(*instance.Status.RateLimitRemaining, instance.Status.RateLimitLimit)
+	instance.Status.RateLimitRemaining = &remaining
+	instance.Status.RateLimitLimit = limit
+
+	if isRateLimitLow(remaining, limit) {
+		logf.FromContext(ctx).Info("n8n rate limit budget is low", "remaining", remaining, "limit", limit)
+		if !wasLow {
+			budget := fmt.Sprintf("%d of %d requests", remaining, limit)
+			if limit <= 0 {
+				budget = "no requests"
+			}
+			r.Recorder.Event(instance, corev1.EventTypeWarning, "RateLimitLow",
+				fmt.Sprintf("n8n reports %s remaining in the rate limit window; consider lowering concurrency", budget))
+		}
+	}
+}
+
+// isRateLimitLow reports whether remaining is below lowRateLimitPercent of
+// limit, or exhausted when the limit is unknown
+func isRateLimitLow(remaining, limit int64) bool {
+	if limit <= 0 {
+		return remaining == 0
+	}
+	return remaining*100 < limit*lowRateLimitPercent
+}
+
+// RetryConfig controls how the n8n client retries idempotent requests after a
+// 5xx response or network error
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts per request
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on each further one
+	BaseDelay time.Duration
+}
+
+// abs returns the absolute value of n
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// probeAPIKeyScope records whether the API key is read-only or read-write. An
+// inconclusive probe leaves the previously detected scope in place.
+func (r *N8nInstanceReconciler) probeAPIKeyScope(ctx context.Context, instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client) {
+	log := logf.FromContext(ctx)
+
+	canWrite, err := n8nClient.ProbeWriteAccess(ctx)
+	if err != nil {
+		log.Error(err, "Failed to probe API key scope")
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "ScopeProbeFailed", err.Error())
+		if !canWrite {
+			return
+		}
+	}
+
+	scope := n8nv1alpha1.APIKeyScopeReadWrite
+	if !canWrite {
+		scope = n8nv1alpha1.APIKeyScopeRead
+	}
+	if scope == n8nv1alpha1.APIKeyScopeRead && instance.Status.APIKeyScope != scope {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "ReadOnlyAPIKey",
+			"API key cannot write workflows; workflow syncs to this instance will fail")
+	}
+	instance.Status.APIKeyScope = scope
+}
+
+// checkLoad reads the backpressure metric and records whether the instance is under
+// high load. A failed read clears HighLoad so a missing metric never stalls syncs.
+func (r *N8nInstanceReconciler) checkLoad(ctx context.Context, instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client) {
+	log := logf.FromContext(ctx)
+	backpressure := instance.Spec.Backpressure
+
+	load, err := n8nClient.GetMetric(ctx, backpressure.GetMetricsPath(), backpressure.GetMetric())
+	if err != nil {
+		log.Error(err, "Failed to read load metric")
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "LoadCheckFailed", err.Error())
+		instance.Status.HighLoad = false
+		return
+	}
+
+	wasHighLoad := instance.Status.HighLoad
+	instance.Status.Load = int64(load)
+	instance.Status.HighLoad = instance.Status.Load >= backpressure.Threshold
+
+	switch {
+	case instance.Status.HighLoad && !wasHighLoad:
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "HighLoad",
+			fmt.Sprintf("Load %d reached threshold %d, slowing down workflow syncs", instance.Status.Load, backpressure.Threshold))
+	case !instance.Status.HighLoad && wasHighLoad:
+		r.Recorder.Event(instance, corev1.EventTypeNormal, "LoadRecovered",
+			fmt.Sprintf("Load %d is below threshold %d, resuming normal workflow syncs", instance.Status.Load, backpressure.Threshold))
+	}
 }
 
 // validateInstance validates the N8nInstance configuration
@@ -159,33 +515,40 @@ func (r *N8nInstanceReconciler) validateInstance(instance *n8nv1alpha1.N8nInstan
 		}
 	}
 
-	// Credentials must be specified
-	if instance.Spec.Credentials.SecretName == "" {
-		return fmt.Errorf("credentials.secretName is required")
+	if webhookBaseURL := instance.Spec.WebhookBaseURL; webhookBaseURL != "" {
+		if u, err := url.Parse(webhookBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhookBaseURL must be an absolute http or https URL, got %q", webhookBaseURL)
+		}
 	}
 
-	return nil
-}
+	// Credentials must be specified, either as a Secret or a file
+	if (instance.Spec.Credentials.SecretName == "") == (instance.Spec.Credentials.FilePath == "") {
+		return fmt.Errorf("exactly one of credentials.secretName or credentials.filePath must be set")
+	}
+	if path := instance.Spec.Credentials.FilePath; path != "" {
+		if _, err := credentialFileDir(path, r.CredentialsDir); err != nil {
+			return err
+		}
+	}
 
-// getAPIKey retrieves the API key from the referenced secret
-func (r *N8nInstanceReconciler) getAPIKey(ctx context.Context, instance *n8nv1alpha1.N8nInstance) (string, error) {
-	secret := &corev1.Secret{}
-	secretKey := types.NamespacedName{
-		Name:      instance.Spec.Credentials.SecretName,
-		Namespace: instance.Namespace, // Secret must be in same namespace as N8nInstance
+	if tlsSpec := instance.Spec.TLS; tlsSpec != nil && tlsSpec.CA != nil &&
+		(tlsSpec.CA.ConfigMapKeyRef == nil) == (tlsSpec.CA.SecretKeyRef == nil) {
+		return fmt.Errorf("tls.ca must set exactly one of configMapKeyRef or secretKeyRef")
 	}
 
-	if err := r.Get(ctx, secretKey, secret); err != nil {
-		return "", fmt.Errorf("failed to get secret %s/%s: %w", secretKey.Namespace, secretKey.Name, err)
+	if instance.Spec.Timeout != nil && instance.Spec.Timeout.Duration <= 0 {
+		return fmt.Errorf("timeout must be positive, got %s", instance.Spec.Timeout.Duration)
 	}
 
-	key := instance.GetSecretKey()
-	apiKeyBytes, ok := secret.Data[key]
-	if !ok {
-		return "", fmt.Errorf("secret %s/%s does not contain key %s", secretKey.Namespace, secretKey.Name, key)
+	if interval := instance.Spec.HealthCheckInterval; interval != nil && interval.Duration < n8nv1alpha1.MinReconcileInterval {
+		return fmt.Errorf("healthCheckInterval must be at least %s, got %s", n8nv1alpha1.MinReconcileInterval, interval.Duration)
 	}
 
-	return string(apiKeyBytes), nil
+	if _, err := instance.HoldsInactive(nil); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // setCondition sets a condition on the instance status
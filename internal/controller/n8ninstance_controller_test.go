@@ -0,0 +1,1234 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("N8nInstance Controller", func() {
+	Context("When adopting tagged workflows", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nInstanceReconciler
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "adopt-instance", "default", fakeServer.URL())
+
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.DeleteAllOf(ctx, &n8nv1alpha1.N8nWorkflow{},
+				client.InNamespace("default"), client.MatchingLabels{adoptedLabel: "true"})).To(Succeed())
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should generate N8nWorkflow resources only for workflows carrying every selected tag", func() {
+			taggedID := fakeServer.addWorkflow(n8n.Workflow{
+				Name:   "Nightly Report",
+				Active: true,
+				Nodes:  []map[string]any{{"name": "Start", "type": "n8n-nodes-base.manualTrigger"}},
+				Tags:   []map[string]any{{"name": "gitops"}, {"name": "team-a"}},
+			})
+			fakeServer.addWorkflow(n8n.Workflow{
+				Name: "Partially Tagged",
+				Tags: []map[string]any{{"name": "gitops"}},
+			})
+			fakeServer.addWorkflow(n8n.Workflow{Name: "Untagged"})
+
+			instance.Spec.AdoptTagSelector = &n8nv1alpha1.AdoptTagSelector{
+				Tags:            []string{"gitops", "team-a"},
+				TargetNamespace: "default",
+			}
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+
+			By("reconciling the instance twice to confirm adoption is idempotent")
+			for i := 0; i < 2; i++ {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			adopted := &n8nv1alpha1.N8nWorkflowList{}
+			Expect(k8sClient.List(ctx, adopted, client.InNamespace("default"),
+				client.MatchingLabels{adoptedLabel: "true"})).To(Succeed())
+			Expect(adopted.Items).To(HaveLen(1))
+
+			resource := adopted.Items[0]
+			Expect(resource.Labels).To(HaveKeyWithValue(adoptedInstanceLabel, instance.Name))
+			Expect(resource.Annotations).To(HaveKeyWithValue(adoptedWorkflowIDAnnotation, taggedID))
+			Expect(resource.Spec.InstanceRef).To(Equal(instance.Name))
+			Expect(resource.Spec.Active).To(BeTrue())
+			Expect(resource.Spec.Workflow.Name).To(Equal("Nightly Report"))
+			Expect(resource.Spec.Workflow.Nodes).To(HaveLen(1))
+
+			updated := &n8nv1alpha1.N8nInstance{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, updated)).To(Succeed())
+			Expect(updated.Status.AdoptedWorkflows).To(Equal(int32(1)))
+		})
+
+		It("should derive valid, distinct resource names", func() {
+			first := adoptedResourceName(&n8n.Workflow{ID: "abc", Name: "My  Workflow!! (v2)"})
+			second := adoptedResourceName(&n8n.Workflow{ID: "def", Name: "My  Workflow!! (v2)"})
+			Expect(first).To(MatchRegexp(`^my-workflow-v2-[0-9a-f]{8}$`))
+			Expect(second).NotTo(Equal(first))
+			Expect(adoptedResourceName(&n8n.Workflow{ID: "x", Name: "???"})).To(MatchRegexp(`^workflow-[0-9a-f]{8}$`))
+		})
+	})
+
+	Context("When looking for orphaned workflows", func() {
+		const ownerName = "orphan-owner"
+
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var owner *n8nv1alpha1.N8nWorkflow
+		var controllerReconciler *N8nInstanceReconciler
+
+		// marked returns workflow meta carrying the default managed-by marker and the given owner
+		marked := func(uid, ownedBy string) map[string]any {
+			workflowMeta := map[string]any{DefaultManagedByKey: DefaultManagedByValue}
+			if uid != "" {
+				workflowMeta[OwnerUIDMetaKey] = uid
+			}
+			if ownedBy != "" {
+				workflowMeta[OwnerMetaKey] = ownedBy
+			}
+			return workflowMeta
+		}
+
+		// releasedMeta returns marked meta that a deletion policy released,
+		// keeping an owner UID as left by an interrupted release
+		releasedMeta := func(uid, releasedBy string) map[string]any {
+			workflowMeta := marked(uid, "")
+			workflowMeta[ReleasedMetaKey] = releasedBy
+			return workflowMeta
+		}
+
+		reconcileInstance := func() *n8nv1alpha1.N8nInstance {
+			key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &n8nv1alpha1.N8nInstance{}
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			return updated
+		}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "orphan-instance", "default", fakeServer.URL())
+			owner = &n8nv1alpha1.N8nWorkflow{
+				ObjectMeta: metav1.ObjectMeta{Name: ownerName, Namespace: "default"},
+				Spec: n8nv1alpha1.N8nWorkflowSpec{
+					InstanceRef: instance.Name,
+					Workflow:    n8nv1alpha1.WorkflowSpec{Name: "Owned"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, owner)).To(Succeed())
+
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+		})
+
+		AfterEach(func() {
+			cleanupWorkflow(ctx, types.NamespacedName{Name: ownerName, Namespace: "default"})
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should only treat marked workflows whose owner is gone as orphans", func() {
+			resources := []n8nv1alpha1.N8nWorkflow{{
+				ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "team-a", UID: "uid-1"},
+				Status:     n8nv1alpha1.N8nWorkflowStatus{WorkflowID: "wf-tracked"},
+			}}
+			workflows := []n8n.Workflow{
+				{ID: "wf-owned", Meta: marked("uid-1", "team-a/orders")},
+				{ID: "wf-tracked", Meta: marked("uid-old", "")},
+				{ID: "wf-recreated", Meta: marked("uid-old", "team-a/orders")},
+				{ID: "wf-legacy", Meta: marked("", "")},
+				{ID: "wf-unmarked", Meta: map[string]any{OwnerUIDMetaKey: "uid-gone"}},
+				{ID: "wf-foreign", Meta: map[string]any{DefaultManagedByKey: "other", OwnerUIDMetaKey: "uid-gone"}},
+				{ID: "wf-orphan", Meta: marked("uid-gone", "team-a/old")},
+				{ID: "wf-released", Meta: releasedMeta("uid-gone", "team-a/kept")},
+			}
+			orphans := findOrphanedWorkflows(workflows, resources, ManagedByMarker{}.orDefault())
+			Expect(orphans).To(HaveLen(1))
+			Expect(orphans[0].ID).To(Equal("wf-orphan"))
+		})
+
+		It("should report orphans without deleting them by default", func() {
+			fakeServer.addWorkflow(n8n.Workflow{Name: "Owned", Meta: marked(string(owner.UID), "default/"+ownerName)})
+			orphanID := fakeServer.addWorkflow(n8n.Workflow{Name: "Left Behind", Meta: marked("uid-gone", "default/gone")})
+			fakeServer.addWorkflow(n8n.Workflow{Name: "Built In The UI"})
+
+			instance.Spec.OrphanCleanup = &n8nv1alpha1.OrphanCleanupSpec{}
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+			updated := reconcileInstance()
+
+			Expect(updated.Status.OrphanedWorkflowCount).To(Equal(int32(1)))
+			Expect(updated.Status.OrphanedWorkflows).To(Equal([]n8nv1alpha1.OrphanedWorkflow{
+				{ID: orphanID, Name: "Left Behind", Owner: "default/gone"}}))
+			Expect(fakeServer.workflow(orphanID)).NotTo(BeNil())
+			Expect(fakeServer.countRequests(http.MethodDelete, "/api/v1/workflows")).To(Equal(0))
+		})
+
+		It("should deactivate and delete orphans with the Delete action", func() {
+			ownedID := fakeServer.addWorkflow(n8n.Workflow{Name: "Owned", Meta: marked(string(owner.UID), "default/"+ownerName)})
+			orphanID := fakeServer.addWorkflow(n8n.Workflow{Name: "Left Behind", Active: true, Meta: marked("uid-gone", "default/gone")})
+
+			instance.Spec.OrphanCleanup = &n8nv1alpha1.OrphanCleanupSpec{Action: n8nv1alpha1.OrphanActionDelete}
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+			updated := reconcileInstance()
+
+			Expect(updated.Status.OrphanedWorkflowCount).To(BeZero())
+			Expect(updated.Status.OrphanedWorkflows).To(BeEmpty())
+			Expect(fakeServer.workflow(orphanID)).To(BeNil())
+			Expect(fakeServer.workflow(ownedID)).NotTo(BeNil())
+			var teardown []string
+			for _, request := range fakeServer.requestLog() {
+				if strings.HasPrefix(request, "POST /api/v1/workflows/"+orphanID) || strings.HasPrefix(request, "DELETE /api/v1/workflows/"+orphanID) {
+					teardown = append(teardown, request)
+				}
+			}
+			Expect(teardown).To(Equal([]string{
+				"POST /api/v1/workflows/" + orphanID + "/deactivate",
+				"DELETE /api/v1/workflows/" + orphanID,
+			}))
+		})
+
+		It("should keep workflows released by a deletion policy with the Delete action", func() {
+			left := n8n.Workflow{Name: "Left On Purpose", Meta: marked("uid-gone", "default/gone")}
+			release(&left, "default/gone")
+			leftID := fakeServer.addWorkflow(left)
+			releasedID := fakeServer.addWorkflow(n8n.Workflow{Name: "Released Earlier", Meta: releasedMeta("uid-gone", "default/gone")})
+
+			instance.Spec.OrphanCleanup = &n8nv1alpha1.OrphanCleanupSpec{Action: n8nv1alpha1.OrphanActionDelete}
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+			updated := reconcileInstance()
+
+			Expect(updated.Status.OrphanedWorkflowCount).To(BeZero())
+			Expect(fakeServer.workflow(leftID)).NotTo(BeNil())
+			Expect(fakeServer.workflow(releasedID)).NotTo(BeNil())
+			Expect(fakeServer.countRequests(http.MethodDelete, "/api/v1/workflows")).To(Equal(0))
+		})
+
+		It("should not list n8n workflows when orphan cleanup is off", func() {
+			fakeServer.addWorkflow(n8n.Workflow{Name: "Left Behind", Meta: marked("uid-gone", "default/gone")})
+			updated := reconcileInstance()
+
+			Expect(updated.Status.OrphanedWorkflowCount).To(BeZero())
+			Expect(fakeServer.countRequests(http.MethodGet, "/api/v1/workflows")).To(Equal(0))
+		})
+	})
+
+	Context("When backpressure is configured", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nInstanceReconciler
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "load-instance", "default", fakeServer.URL())
+			instance.Spec.Backpressure = &n8nv1alpha1.BackpressureSpec{Threshold: 10, RequeueMultiplier: 3}
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should flag high load from the metric and clear it once load recovers", func() {
+			key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+			updated := &n8nv1alpha1.N8nInstance{}
+
+			By("reporting a queue above the threshold")
+			fakeServer.setMetrics("n8n_scaling_mode_queue_jobs_waiting 25\n")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			Expect(updated.Status.Load).To(Equal(int64(25)))
+			Expect(updated.Status.HighLoad).To(BeTrue())
+			Expect(updated.GetRequeueMultiplier()).To(Equal(int32(3)))
+
+			By("reporting a queue below the threshold")
+			fakeServer.setMetrics("n8n_scaling_mode_queue_jobs_waiting 2\n")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			Expect(updated.Status.HighLoad).To(BeFalse())
+			Expect(updated.GetRequeueMultiplier()).To(Equal(int32(1)))
+		})
+	})
+
+	Context("When probing the API key scope", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nInstanceReconciler
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "scope-instance", "default", fakeServer.URL())
+			instance.Spec.ProbeWriteScope = true
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		DescribeTable("should record the detected scope",
+			func(forbidWrites bool, expected n8nv1alpha1.APIKeyScope) {
+				fakeServer.setForbidWrites(forbidWrites)
+				key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &n8nv1alpha1.N8nInstance{}
+				Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+				Expect(updated.Status.Ready).To(BeTrue())
+				Expect(updated.Status.APIKeyScope).To(Equal(expected))
+
+				// The probe workflow must never be left behind
+				Expect(fakeServer.countRequests("POST", "/api/v1/workflows")).To(Equal(1))
+				Expect(fakeServer.workflows).To(BeEmpty())
+			},
+			Entry("read-write key", false, n8nv1alpha1.APIKeyScopeReadWrite),
+			Entry("read-only key", true, n8nv1alpha1.APIKeyScopeRead),
+		)
+	})
+
+	Context("When validating the instance configuration", func() {
+		reconciler := &N8nInstanceReconciler{}
+
+		newInstance := func(timeout *metav1.Duration) *n8nv1alpha1.N8nInstance {
+			return &n8nv1alpha1.N8nInstance{
+				Spec: n8nv1alpha1.N8nInstanceSpec{
+					URL:         "http://n8n.example.com",
+					Credentials: n8nv1alpha1.CredentialsRef{SecretName: "n8n-api-key"},
+					Timeout:     timeout,
+				},
+			}
+		}
+
+		DescribeTable("should check the request timeout",
+			func(timeout *metav1.Duration, expectErr bool) {
+				err := reconciler.validateInstance(newInstance(timeout))
+				if expectErr {
+					Expect(err).To(MatchError(ContainSubstring("timeout must be positive")))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("unset", nil, false),
+			Entry("positive", &metav1.Duration{Duration: 2 * time.Minute}, false),
+			Entry("zero", &metav1.Duration{}, true),
+			Entry("negative", &metav1.Duration{Duration: -time.Second}, true),
+		)
+
+		It("should require exactly one CA bundle reference", func() {
+			instance := newInstance(nil)
+			instance.Spec.TLS = &n8nv1alpha1.TLSConfig{CA: &n8nv1alpha1.CABundleSource{}}
+			Expect(reconciler.validateInstance(instance)).To(MatchError(ContainSubstring("tls.ca must set exactly one")))
+
+			instance.Spec.TLS.CA.SecretKeyRef = &corev1.SecretKeySelector{Key: "ca.crt"}
+			Expect(reconciler.validateInstance(instance)).To(Succeed())
+		})
+
+		It("should require a health check interval of at least 10s", func() {
+			instance := newInstance(nil)
+			instance.Spec.HealthCheckInterval = &metav1.Duration{Duration: time.Second}
+			Expect(reconciler.validateInstance(instance)).To(MatchError(ContainSubstring("healthCheckInterval must be at least 10s")))
+
+			instance.Spec.HealthCheckInterval = &metav1.Duration{Duration: time.Minute}
+			Expect(reconciler.validateInstance(instance)).To(Succeed())
+			Expect(instance.GetHealthCheckInterval()).To(Equal(time.Minute))
+			Expect(newInstance(nil).GetHealthCheckInterval()).To(Equal(healthCheckInterval))
+		})
+
+		It("should require an absolute webhook base URL", func() {
+			instance := newInstance(nil)
+			instance.Spec.WebhookBaseURL = "hooks.example.com"
+			Expect(reconciler.validateInstance(instance)).To(MatchError(ContainSubstring("webhookBaseURL must be an absolute")))
+
+			instance.Spec.WebhookBaseURL = "https://hooks.example.com"
+			Expect(reconciler.validateInstance(instance)).To(Succeed())
+			Expect(instance.GetWebhookBaseURL()).To(Equal("https://hooks.example.com"))
+			Expect(newInstance(nil).GetWebhookBaseURL()).To(Equal("http://n8n.example.com"))
+		})
+
+		It("should reject an invalid deactivate selector", func() {
+			instance := newInstance(nil)
+			instance.Spec.DeactivateSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: "Bogus"},
+			}}
+			Expect(reconciler.validateInstance(instance)).To(MatchError(ContainSubstring("invalid deactivateSelector")))
+
+			instance.Spec.DeactivateSelector.MatchExpressions[0].Operator = metav1.LabelSelectorOpExists
+			Expect(reconciler.validateInstance(instance)).To(Succeed())
+			Expect(instance.HoldsInactive(map[string]string{"tier": "batch"})).To(BeTrue())
+			Expect(instance.HoldsInactive(nil)).To(BeFalse())
+		})
+
+		It("should convert the rate limit to requests per second", func() {
+			_, _, ok := newInstance(nil).GetRateLimit()
+			Expect(ok).To(BeFalse())
+
+			instance := newInstance(nil)
+			instance.Spec.RateLimit = &n8nv1alpha1.RateLimitSpec{RequestsPerMinute: 120}
+			requestsPerSecond, burst, ok := instance.GetRateLimit()
+			Expect(ok).To(BeTrue())
+			Expect(requestsPerSecond).To(Equal(2.0))
+			Expect(burst).To(Equal(1))
+		})
+
+		It("should share one transport per instance tuned by its connection pool", func() {
+			transports := n8n.NewTransportRegistry()
+			instance := newInstance(nil)
+			Expect(instanceConnectionPool(instance)).To(Equal(n8n.ConnectionPool{}))
+
+			instance.Spec.ConnectionPool = &n8nv1alpha1.ConnectionPoolSpec{
+				MaxIdleConnsPerHost: 32,
+				IdleConnTimeout:     &metav1.Duration{Duration: time.Minute},
+			}
+			Expect(instanceConnectionPool(instance)).To(Equal(n8n.ConnectionPool{MaxIdleConnsPerHost: 32, IdleConnTimeout: time.Minute}))
+
+			transport := transports.For("http://n8n.example.com", instanceConnectionPool(instance), nil)
+			Expect(transport.MaxIdleConnsPerHost).To(Equal(32))
+			Expect(transport.MaxIdleConns).To(Equal(n8n.DefaultMaxIdleConns))
+			Expect(transport.IdleConnTimeout).To(Equal(time.Minute))
+			Expect(transports.For("http://n8n.example.com/", instanceConnectionPool(instance), nil)).To(BeIdenticalTo(transport))
+		})
+
+		It("should default the timeout to 30s", func() {
+			Expect(newInstance(nil).GetTimeout()).To(Equal(30 * time.Second))
+			Expect(newInstance(&metav1.Duration{Duration: 5 * time.Second}).GetTimeout()).To(Equal(5 * time.Second))
+		})
+	})
+
+	Context("When n8n's clock is skewed", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var recorder *record.FakeRecorder
+		var controllerReconciler *N8nInstanceReconciler
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "skew-instance", "default", fakeServer.URL())
+
+			recorder = record.NewFakeRecorder(100)
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		DescribeTable("should record the skew and warn beyond the threshold",
+			func(offset time.Duration, expectedSkew int64, expectWarning bool) {
+				fakeServer.setClockOffset(offset)
+				key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &n8nv1alpha1.N8nInstance{}
+				Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+				Expect(updated.Status.ClockSkewSeconds).To(BeNumerically("~", expectedSkew, 1))
+
+				warned := false
+				for len(recorder.Events) > 0 {
+					if strings.Contains(<-recorder.Events, "ClockSkew") {
+						warned = true
+					}
+				}
+				Expect(warned).To(Equal(expectWarning))
+			},
+			Entry("n8n ahead beyond the threshold", 2*time.Minute, int64(120), true),
+			Entry("n8n behind beyond the threshold", -5*time.Minute, int64(-300), true),
+			Entry("n8n within the threshold", 10*time.Second, int64(10), false),
+		)
+	})
+
+	Context("When n8n exposes its version", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nInstanceReconciler
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "version-instance", "default", fakeServer.URL())
+
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should record the version and edition, and clear them once they are hidden", func() {
+			fakeServer.setSettings(`{"data":{"versionCli":"1.64.0","license":{"planName":"Enterprise"}}}`)
+			key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &n8nv1alpha1.N8nInstance{}
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			Expect(updated.Status.Version).To(Equal("1.64.0"))
+			Expect(updated.Status.Edition).To(Equal("Enterprise"))
+
+			// Instances that don't serve their settings stay Ready
+			fakeServer.setSettings("")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			Expect(updated.Status.Ready).To(BeTrue())
+			Expect(updated.Status.Version).To(BeEmpty())
+			Expect(updated.Status.Edition).To(BeEmpty())
+		})
+
+		It("should flag versions outside the tested range once and stay Ready", func() {
+			recorder := record.NewFakeRecorder(100)
+			controllerReconciler.Recorder = recorder
+			key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+			updated := &n8nv1alpha1.N8nInstance{}
+			reconcileWith := func(version string) {
+				fakeServer.setSettings(`{"data":{"versionCli":"` + version + `"}}`)
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			}
+
+			reconcileWith("1.64.0")
+			Expect(updated.Status.VersionSupported).To(HaveValue(BeTrue()))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, n8nv1alpha1.InstanceConditionTypeUnsupported)).To(BeNil())
+
+			for range 2 {
+				reconcileWith("99.0.0")
+				Expect(updated.Status.Ready).To(BeTrue())
+				Expect(updated.Status.VersionSupported).To(HaveValue(BeFalse()))
+				unsupported := meta.FindStatusCondition(updated.Status.Conditions, n8nv1alpha1.InstanceConditionTypeUnsupported)
+				Expect(unsupported).NotTo(BeNil())
+				Expect(unsupported.Status).To(Equal(metav1.ConditionTrue))
+				Expect(unsupported.Reason).To(Equal(n8nv1alpha1.InstanceReasonVersionOutOfRange))
+				Expect(unsupported.Message).To(ContainSubstring("99.0.0"))
+			}
+			Expect(drainEvents(recorder)).To(ConsistOf(ContainSubstring("UnsupportedVersion")))
+
+			reconcileWith("1.64.1")
+			Expect(updated.Status.VersionSupported).To(HaveValue(BeTrue()))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, n8nv1alpha1.InstanceConditionTypeUnsupported)).To(BeNil())
+		})
+	})
+
+	Context("When n8n reports rate limit headers", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var recorder *record.FakeRecorder
+		var controllerReconciler *N8nInstanceReconciler
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "ratelimit-instance", "default", fakeServer.URL())
+
+			recorder = record.NewFakeRecorder(100)
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		int64Ptr := func(n int64) *int64 { return &n }
+
+		DescribeTable("should record the remaining budget and warn when low",
+			func(headers map[string]string, expectedRemaining *int64, expectedLimit int64, expectWarning bool) {
+				fakeServer.setRateLimitHeaders(headers)
+				key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &n8nv1alpha1.N8nInstance{}
+				Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+				Expect(updated.Status.RateLimitRemaining).To(Equal(expectedRemaining))
+				Expect(updated.Status.RateLimitLimit).To(Equal(expectedLimit))
+
+				warned := false
+				for len(recorder.Events) > 0 {
+					if strings.Contains(<-recorder.Events, "RateLimitLow") {
+						warned = true
+					}
+				}
+				Expect(warned).To(Equal(expectWarning))
+			},
+			Entry("plenty of budget left",
+				map[string]string{"X-RateLimit-Remaining": "80", "X-RateLimit-Limit": "100"}, int64Ptr(80), int64(100), false),
+			Entry("budget below 10% of the limit",
+				map[string]string{"X-RateLimit-Remaining": "5", "X-RateLimit-Limit": "100"}, int64Ptr(5), int64(100), true),
+			Entry("IETF headers with a policy suffix",
+				map[string]string{"RateLimit-Remaining": "40", "RateLimit-Limit": "50, 50;w=60"}, int64Ptr(40), int64(50), false),
+			Entry("exhausted budget without a limit",
+				map[string]string{"X-RateLimit-Remaining": "0"}, int64Ptr(0), int64(0), true),
+			Entry("no rate limit headers", nil, nil, int64(0), false),
+		)
+	})
+
+	Context("When the instance URL changes", func() {
+		ctx := context.Background()
+
+		var oldServer, newServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nInstanceReconciler
+		var key types.NamespacedName
+
+		workflowNames := []string{"migrate-a", "migrate-b", "migrate-c"}
+
+		// reconcileInstance runs one instance reconcile and returns the updated instance
+		reconcileInstance := func() (*n8nv1alpha1.N8nInstance, reconcile.Result) {
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &n8nv1alpha1.N8nInstance{}
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			return updated, result
+		}
+
+		// released counts the workflows the instance status records as released,
+		// checking that the workflows themselves were left untouched
+		released := func() int {
+			updated := &n8nv1alpha1.N8nInstance{}
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			count := 0
+			for _, name := range workflowNames {
+				workflow := &n8nv1alpha1.N8nWorkflow{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, workflow)).To(Succeed())
+				Expect(workflow.Annotations).To(BeEmpty())
+				if migration := updated.Status.URLMigration; migration != nil &&
+					urlMigrationReleased(migration, "default/"+name) {
+					count++
+				}
+			}
+			return count
+		}
+
+		BeforeEach(func() {
+			oldServer = newFakeN8n()
+			newServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "migrate-instance", "default", oldServer.URL())
+			key = types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+
+			for _, name := range workflowNames {
+				Expect(k8sClient.Create(ctx, &n8nv1alpha1.N8nWorkflow{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  "default",
+						Finalizers: []string{finalizerName},
+					},
+					Spec: n8nv1alpha1.N8nWorkflowSpec{
+						InstanceRef: instance.Name,
+						Workflow:    n8nv1alpha1.WorkflowSpec{Name: name},
+					},
+				})).To(Succeed())
+			}
+
+			instance.Spec.URL = newServer.URL()
+			instance.Spec.URLMigration = &n8nv1alpha1.URLMigrationSpec{BatchSize: 2, BatchIntervalSeconds: 60}
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+		})
+
+		AfterEach(func() {
+			for _, name := range workflowNames {
+				cleanupWorkflow(ctx, types.NamespacedName{Name: name, Namespace: "default"})
+			}
+			deleteInstance(ctx, instance)
+			oldServer.Close()
+			newServer.Close()
+		})
+
+		It("should release dependent workflows in rate-limited batches", func() {
+			By("validating the new URL and releasing the first batch")
+			updated, result := reconcileInstance()
+			Expect(updated.Status.URL).To(Equal(newServer.URL()))
+			migration := updated.Status.URLMigration
+			Expect(migration).NotTo(BeNil())
+			Expect(migration.FromURL).To(Equal(oldServer.URL()))
+			Expect(migration.ToURL).To(Equal(newServer.URL()))
+			Expect(migration.Phase).To(Equal(n8nv1alpha1.URLMigrationInProgress))
+			Expect(migration.Total).To(Equal(int32(3)))
+			Expect(migration.Migrated).To(Equal(int32(2)))
+			Expect(released()).To(Equal(2))
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Minute, time.Second))
+
+			By("holding the next batch until the interval elapses")
+			updated, _ = reconcileInstance()
+			Expect(updated.Status.URLMigration.Migrated).To(Equal(int32(2)))
+			Expect(released()).To(Equal(2))
+
+			By("releasing the remaining workflow once the interval has passed")
+			earlier := metav1.NewTime(time.Now().Add(-time.Hour))
+			updated.Status.URLMigration.LastBatchTime = &earlier
+			Expect(k8sClient.Status().Update(ctx, updated)).To(Succeed())
+			updated, result = reconcileInstance()
+			Expect(updated.Status.URLMigration.Phase).To(Equal(n8nv1alpha1.URLMigrationCompleted))
+			Expect(updated.Status.URLMigration.Migrated).To(Equal(int32(3)))
+			Expect(updated.Status.URLMigration.CompletedAt).NotTo(BeNil())
+			Expect(released()).To(Equal(3))
+			Expect(result.RequeueAfter).To(Equal(healthCheckInterval))
+		})
+
+		It("should keep unreleased workflows from syncing", func() {
+			reconcileInstance()
+
+			workflowReconciler := &N8nWorkflowReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				Recorder:          record.NewFakeRecorder(100),
+				OperatorNamespace: "default",
+			}
+			pendingKey := types.NamespacedName{Name: "migrate-c", Namespace: "default"}
+			_, err := workflowReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: pendingKey})
+			Expect(err).NotTo(HaveOccurred())
+
+			workflow := &n8nv1alpha1.N8nWorkflow{}
+			Expect(k8sClient.Get(ctx, pendingKey, workflow)).To(Succeed())
+			ready := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.ReasonURLMigrationPending))
+			Expect(newServer.countRequests("POST", "/api/v1/workflows")).To(Equal(0))
+			Expect(oldServer.countRequests("POST", "/api/v1/workflows")).To(Equal(0))
+		})
+
+		It("should not start a migration while the new URL fails its health check", func() {
+			newServer.Close()
+			updated, _ := reconcileInstance()
+			Expect(updated.Status.Ready).To(BeFalse())
+			Expect(updated.Status.URL).To(Equal(oldServer.URL()))
+			Expect(updated.Status.URLMigration).To(BeNil())
+			Expect(released()).To(Equal(0))
+		})
+	})
+
+	Context("When n8n uses a certificate from a private CA", func() {
+		const caConfigMap = "n8n-ca"
+
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nInstanceReconciler
+		var key types.NamespacedName
+
+		setTLS := func(tlsSpec *n8nv1alpha1.TLSConfig) {
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			instance.Spec.TLS = tlsSpec
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+		}
+
+		reconcileInstance := func() *metav1.Condition {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			return meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeReady)
+		}
+
+		caRef := &n8nv1alpha1.TLSConfig{CA: &n8nv1alpha1.CABundleSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: caConfigMap},
+				Key:                  "ca.crt",
+			},
+		}}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8nTLS()
+			instance = createReadyInstance(ctx, "tls-instance", "default", fakeServer.URL())
+			key = types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+
+			controllerReconciler = &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+				Retry:    RetryConfig{MaxAttempts: 1},
+			}
+		})
+
+		AfterEach(func() {
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: caConfigMap, Namespace: "default"}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, configMap))).To(Succeed())
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should fail the health check without the CA", func() {
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.InstanceReasonConnectionError))
+			Expect(ready.Message).To(ContainSubstring("certificate"))
+		})
+
+		It("should trust the CA bundle from a ConfigMap for health checks and syncs", func() {
+			Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: caConfigMap, Namespace: "default"},
+				Data:       map[string]string{"ca.crt": fakeServer.caPEM()},
+			})).To(Succeed())
+			setTLS(caRef)
+
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+
+			n8nClient, _, err := instanceClient(ctx, k8sClient, "default", instance.Name, nil, nil, nil, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n8nClient.HealthCheck(ctx)).To(Succeed())
+		})
+
+		It("should report a missing CA bundle", func() {
+			setTLS(caRef)
+
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.InstanceReasonTLSConfigError))
+			Expect(fakeServer.countRequests(http.MethodGet, "/api/v1/workflows")).To(Equal(0))
+		})
+
+		It("should skip verification when insecureSkipVerify is set", func() {
+			setTLS(&n8nv1alpha1.TLSConfig{InsecureSkipVerify: true})
+
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+		})
+	})
+
+	Context("When n8n sits behind an auth proxy", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var key types.NamespacedName
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "proxy-instance", "default", fakeServer.URL())
+			key = types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		// configure stores data in the credentials secret and selects authType
+		configure := func(authType n8nv1alpha1.AuthType, data map[string]string) {
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name: instance.Spec.Credentials.SecretName, Namespace: instance.Namespace}, secret)).To(Succeed())
+			for k, v := range data {
+				secret.Data[k] = []byte(v)
+			}
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			instance.Spec.Credentials.AuthType = authType
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+		}
+
+		reconcileInstance := func() *metav1.Condition {
+			controllerReconciler := &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+				Retry:    RetryConfig{MaxAttempts: 1},
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			return meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeReady)
+		}
+
+		DescribeTable("should send the proxy credentials with the API key",
+			func(authType n8nv1alpha1.AuthType, data map[string]string, authorization string) {
+				fakeServer.setProxyAuthorization(authorization)
+				configure(authType, data)
+
+				ready := reconcileInstance()
+				Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+
+				n8nClient, _, err := instanceClient(ctx, k8sClient, "default", instance.Name, nil, nil, nil, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n8nClient.HealthCheck(ctx)).To(Succeed())
+			},
+			Entry("Basic", n8nv1alpha1.AuthTypeBasic,
+				map[string]string{"username": "operator", "password": "proxy-pass"}, "Basic b3BlcmF0b3I6cHJveHktcGFzcw=="),
+			Entry("Bearer", n8nv1alpha1.AuthTypeBearer,
+				map[string]string{"token": "proxy-token"}, "Bearer proxy-token"),
+		)
+
+		It("should fail the health check when only the API key is sent", func() {
+			fakeServer.setProxyAuthorization("Bearer proxy-token")
+
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.InstanceReasonConnectionError))
+		})
+
+		DescribeTable("should report missing proxy credentials without calling n8n",
+			func(authType n8nv1alpha1.AuthType, data map[string]string, missing string) {
+				configure(authType, data)
+
+				ready := reconcileInstance()
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(n8nv1alpha1.InstanceReasonAuthError))
+				Expect(ready.Message).To(ContainSubstring(missing))
+				Expect(fakeServer.countRequests(http.MethodGet, "/api/v1/workflows")).To(Equal(0))
+			},
+			Entry("Basic without a password", n8nv1alpha1.AuthTypeBasic,
+				map[string]string{"username": "operator"}, `"password"`),
+			Entry("Basic with a colon in the username", n8nv1alpha1.AuthTypeBasic,
+				map[string]string{"username": "oper:ator", "password": "proxy-pass"}, "without a colon"),
+			Entry("Bearer without a token", n8nv1alpha1.AuthTypeBearer, nil, `"token"`),
+		)
+	})
+
+	Context("When the API key is read from a file", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var key types.NamespacedName
+		var dir string
+
+		// writeFile writes a credentials file as a Vault agent would, with a trailing newline
+		writeFile := func(name, content string) {
+			Expect(os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0o600)).To(Succeed())
+		}
+
+		// useFile switches the instance from its secret to the API key file name
+		useFile := func(name string, authType n8nv1alpha1.AuthType) {
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			instance.Spec.Credentials.SecretName = ""
+			instance.Spec.Credentials.FilePath = filepath.Join(dir, name)
+			instance.Spec.Credentials.AuthType = authType
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+		}
+
+		reconcileInstance := func() *metav1.Condition {
+			controllerReconciler := &N8nInstanceReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				Recorder:       record.NewFakeRecorder(100),
+				Retry:          RetryConfig{MaxAttempts: 1},
+				CredentialsDir: dir,
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			return meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeReady)
+		}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "file-key-instance", "default", fakeServer.URL())
+			key = types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+			dir = GinkgoT().TempDir()
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should read the API key without a secret", func() {
+			writeFile("n8n-api-key", "file-key")
+			useFile("n8n-api-key", "")
+
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+			apiKey, _, err := instanceCredentials(ctx, k8sClient, instance, dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiKey).To(Equal("file-key"))
+		})
+
+		It("should pick up a rotated key", func() {
+			writeFile("n8n-api-key", "old-key")
+			useFile("n8n-api-key", "")
+			writeFile("n8n-api-key", "new-key")
+
+			apiKey, _, err := instanceCredentials(ctx, k8sClient, instance, dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiKey).To(Equal("new-key"))
+		})
+
+		It("should read proxy credentials from the same directory", func() {
+			fakeServer.setProxyAuthorization("Bearer proxy-token")
+			writeFile("n8n-api-key", "file-key")
+			writeFile("token", "proxy-token")
+			useFile("n8n-api-key", n8nv1alpha1.AuthTypeBearer)
+
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+		})
+
+		DescribeTable("should report unreadable files without calling n8n",
+			func(content *string, missing string) {
+				if content != nil {
+					Expect(os.WriteFile(filepath.Join(dir, "n8n-api-key"), []byte(*content), 0o600)).To(Succeed())
+				}
+				useFile("n8n-api-key", "")
+
+				ready := reconcileInstance()
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(n8nv1alpha1.InstanceReasonAuthError))
+				Expect(ready.Message).To(ContainSubstring(missing))
+				Expect(fakeServer.countRequests(http.MethodGet, "/api/v1/workflows")).To(Equal(0))
+			},
+			Entry("missing file", nil, `does not contain file "n8n-api-key"`),
+			Entry("file not rendered yet", new(string), "is empty"),
+		)
+
+		It("should refuse files outside the credentials directory without calling n8n", func() {
+			outside := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(outside, "token"), []byte("operator-token"), 0o600)).To(Succeed())
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			instance.Spec.Credentials.SecretName = ""
+			instance.Spec.Credentials.FilePath = filepath.Join(outside, "token")
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+
+			ready := reconcileInstance()
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.InstanceReasonInvalidConfig))
+			Expect(ready.Message).To(ContainSubstring("outside the credentials directory"))
+			Expect(fakeServer.countRequests(http.MethodGet, "/api/v1/workflows")).To(Equal(0))
+
+			_, _, err := instanceCredentials(ctx, k8sClient, instance, dir)
+			Expect(err).To(MatchError(ContainSubstring("outside the credentials directory")))
+		})
+	})
+
+	Context("When health checks fail intermittently", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var key types.NamespacedName
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "flaky-instance", "default", fakeServer.URL())
+			key = types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+			recorder = record.NewFakeRecorder(100)
+
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			instance.Spec.HealthCheckFailureThreshold = 3
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		reconcileInstance := func() {
+			controllerReconciler := &N8nInstanceReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				Retry:    RetryConfig{MaxAttempts: 1},
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+		}
+
+		It("should stay Ready while degraded, turn not ready at the threshold and recover", func() {
+			reconcileInstance()
+			Expect(instance.Status.Ready).To(BeTrue())
+			lastSuccess := instance.Status.LastHealthCheck
+			Expect(lastSuccess).NotTo(BeNil())
+			drainEvents(recorder)
+
+			// Any Authorization header other than the one required fails the check
+			fakeServer.setProxyAuthorization("Bearer outage")
+			for failures := int32(1); failures <= 2; failures++ {
+				reconcileInstance()
+				Expect(instance.Status.Ready).To(BeTrue())
+				Expect(instance.Status.ConsecutiveHealthCheckFailures).To(Equal(failures))
+				Expect(instance.Status.LastHealthCheck.Equal(lastSuccess)).To(BeTrue())
+				Expect(instance.Status.LastHealthCheckAttempt.Before(lastSuccess)).To(BeFalse())
+				degraded := meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)
+				Expect(degraded).NotTo(BeNil())
+				Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+				Expect(degraded.Reason).To(Equal(n8nv1alpha1.InstanceReasonHealthCheckFailing))
+				Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeReady)).To(BeTrue())
+			}
+			Expect(drainEvents(recorder)).To(ConsistOf(ContainSubstring("HealthCheckDegraded")))
+
+			reconcileInstance()
+			Expect(instance.Status.Ready).To(BeFalse())
+			Expect(instance.Status.ConsecutiveHealthCheckFailures).To(Equal(int32(3)))
+			Expect(meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)).To(BeNil())
+			ready := meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeReady)
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.InstanceReasonConnectionError))
+			Expect(drainEvents(recorder)).To(ConsistOf(ContainSubstring("HealthCheckFailed")))
+
+			fakeServer.setProxyAuthorization("")
+			reconcileInstance()
+			Expect(instance.Status.Ready).To(BeTrue())
+			Expect(instance.Status.ConsecutiveHealthCheckFailures).To(BeZero())
+			Expect(instance.Status.LastHealthCheck.Equal(instance.Status.LastHealthCheckAttempt)).To(BeTrue())
+			Expect(meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)).To(BeNil())
+		})
+
+		It("should clear the failure count when a check passes before the threshold", func() {
+			reconcileInstance()
+			fakeServer.setProxyAuthorization("Bearer outage")
+			reconcileInstance()
+			Expect(instance.Status.ConsecutiveHealthCheckFailures).To(Equal(int32(1)))
+
+			fakeServer.setProxyAuthorization("")
+			reconcileInstance()
+			Expect(instance.Status.Ready).To(BeTrue())
+			Expect(instance.Status.ConsecutiveHealthCheckFailures).To(BeZero())
+			Expect(meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)).To(BeNil())
+		})
+
+		It("should turn not ready on the first failure with the default threshold", func() {
+			Expect(k8sClient.Get(ctx, key, instance)).To(Succeed())
+			instance.Spec.HealthCheckFailureThreshold = 0
+			Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+
+			fakeServer.setProxyAuthorization("Bearer outage")
+			reconcileInstance()
+			Expect(instance.Status.Ready).To(BeFalse())
+			Expect(meta.FindStatusCondition(instance.Status.Conditions, n8nv1alpha1.InstanceConditionTypeDegraded)).To(BeNil())
+		})
+	})
+
+	Context("When n8n stalls past the reconcile deadline", func() {
+		ctx := context.Background()
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "deadline-instance", "default", fakeServer.URL())
+		})
+
+		AfterEach(func() {
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should abort the health check and requeue without changing Ready", func() {
+			fakeServer.setDelay(5 * time.Second)
+			recorder := record.NewFakeRecorder(100)
+			controllerReconciler := &N8nInstanceReconciler{
+				Client:           k8sClient,
+				Scheme:           k8sClient.Scheme(),
+				Recorder:         recorder,
+				ReconcileTimeout: 200 * time.Millisecond,
+			}
+
+			key := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+			start := time.Now()
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+			Expect(result.RequeueAfter).To(Equal(instanceErrorRequeueInterval))
+			Expect(drainEvents(recorder)).To(ContainElement(ContainSubstring(n8nv1alpha1.InstanceReasonReconcileTimeout)))
+
+			updated := &n8nv1alpha1.N8nInstance{}
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			Expect(updated.Status.Ready).To(BeTrue())
+		})
+	})
+})
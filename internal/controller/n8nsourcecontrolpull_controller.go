@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// N8nSourceControlPullReconciler reconciles a N8nSourceControlPull object
+type N8nSourceControlPullReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// OperatorNamespace is the namespace where N8nInstance resources live
+	OperatorNamespace string
+	// AuditLogger receives an entry for every mutating n8n API request
+	AuditLogger logr.Logger
+	// RateLimiters bounds the request rate to each n8n instance across all clients
+	RateLimiters *n8n.RateLimiterRegistry
+	// Transports shares the connections to each n8n instance across all clients
+	Transports *n8n.TransportRegistry
+	// InstanceStates shares what was learned about each n8n instance across all clients
+	InstanceStates *n8n.InstanceStateRegistry
+	// CredentialsDir is the directory credentials.filePath of an N8nInstance
+	// must point into; empty rejects filePath
+	CredentialsDir string
+	// Retry sets how idempotent n8n requests are retried; zero values keep the
+	// client defaults
+	Retry RetryConfig
+}
+
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nsourcecontrolpulls,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nsourcecontrolpulls/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nsourcecontrolpulls/finalizers,verbs=update
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile pulls the instance's source control repository once per
+// generation and records what was imported. Failures that another attempt
+// can't fix, such as source control not being connected or a conflict without
+// spec.force, complete the generation; other failures are retried.
+func (r *N8nSourceControlPullReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.V(1).Info("Reconciling N8nSourceControlPull")
+
+	pull := &n8nv1alpha1.N8nSourceControlPull{}
+	if err := r.Get(ctx, req.NamespacedName, pull); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("N8nSourceControlPull resource not found, ignoring")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get N8nSourceControlPull")
+		return ctrl.Result{}, err
+	}
+
+	// A pull is a one-off action, so there is nothing to do once it completed
+	// for this generation or while it is being deleted
+	if !pull.DeletionTimestamp.IsZero() || pull.Status.ObservedGeneration == pull.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	n8nClient, _, err := instanceClient(ctx, r.Client, r.OperatorNamespace, pull.Spec.InstanceRef,
+		r.RateLimiters, r.Transports, r.InstanceStates, r.CredentialsDir, n8n.WithAuditLogger(r.AuditLogger), n8n.WithRetry(r.Retry.MaxAttempts, r.Retry.BaseDelay))
+	if err != nil {
+		log.Error(err, "Failed to create n8n client")
+		reason, message := clientErrorReason(err)
+		r.setCondition(pull, metav1.ConditionFalse, reason, message)
+		if statusErr := r.Status().Update(ctx, pull); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	log.Info("Pulling from source control", "instance", pull.Spec.InstanceRef, "force", pull.Spec.Force)
+	result, err := n8nClient.SourceControlPull(ctx, &n8n.SourceControlPullRequest{Force: pull.Spec.Force})
+	switch {
+	case err == nil:
+		r.recordResult(pull, result)
+		message := fmt.Sprintf("Pulled %d workflows, %d credentials, %d variables and %d tags from source control",
+			len(pull.Status.Workflows), len(pull.Status.Credentials),
+			len(pull.Status.AddedVariables)+len(pull.Status.ChangedVariables), len(pull.Status.Tags))
+		r.complete(pull, metav1.ConditionTrue, n8nv1alpha1.ReasonPullSucceeded, message)
+		recordChange(r.Recorder, pull, n8nv1alpha1.ReasonPullSucceeded, message)
+	case n8n.IsSourceControlUnavailable(err):
+		log.Info("Source control is not available on the instance", "error", err)
+		message := fmt.Sprintf("Source control is not licensed or connected to a repository on N8nInstance %q: %v",
+			pull.Spec.InstanceRef, err)
+		r.complete(pull, metav1.ConditionFalse, n8nv1alpha1.ReasonSourceControlUnavailable, message)
+		r.Recorder.Event(pull, corev1.EventTypeWarning, n8nv1alpha1.ReasonSourceControlUnavailable, message)
+	case n8n.IsConflict(err) && !pull.Spec.Force:
+		log.Info("Pull would overwrite changes made in n8n", "error", err)
+		message := fmt.Sprintf("Pull would overwrite changes made in n8n; set spec.force to overwrite them: %v", err)
+		r.complete(pull, metav1.ConditionFalse, n8nv1alpha1.ReasonPullConflict, message)
+		r.Recorder.Event(pull, corev1.EventTypeWarning, n8nv1alpha1.ReasonPullConflict, message)
+	default:
+		log.Error(err, "Failed to pull from source control")
+		// Only warn on the first failure rather than on every retry
+		if previous := meta.FindStatusCondition(pull.Status.Conditions, n8nv1alpha1.ConditionTypeCompleted); previous == nil ||
+			previous.Reason != n8nv1alpha1.ReasonPullFailed {
+			r.Recorder.Event(pull, corev1.EventTypeWarning, n8nv1alpha1.ReasonPullFailed, err.Error())
+		}
+		r.setCondition(pull, metav1.ConditionFalse, n8nv1alpha1.ReasonPullFailed,
+			fmt.Sprintf("Failed to pull from source control: %v", err))
+		if statusErr := r.Status().Update(ctx, pull); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	if err := r.Status().Update(ctx, pull); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// recordResult copies what the pull imported into status
+func (r *N8nSourceControlPullReconciler) recordResult(pull *n8nv1alpha1.N8nSourceControlPull, result *n8n.SourceControlPullResult) {
+	pull.Status.Workflows = pulledItems(result.Workflows)
+	pull.Status.Credentials = pulledItems(result.Credentials)
+	pull.Status.AddedVariables = result.Variables.Added
+	pull.Status.ChangedVariables = result.Variables.Changed
+	pull.Status.Tags = pulledItems(result.Tags.Tags)
+}
+
+// pulledItems converts the items of a pull result for status
+func pulledItems(items []n8n.SourceControlItem) []n8nv1alpha1.PulledItem {
+	if len(items) == 0 {
+		return nil
+	}
+	pulled := make([]n8nv1alpha1.PulledItem, len(items))
+	for i, item := range items {
+		pulled[i] = n8nv1alpha1.PulledItem{ID: item.ID, Name: item.Name, Type: item.Type}
+	}
+	return pulled
+}
+
+// complete finishes the pull for the current generation
+func (r *N8nSourceControlPullReconciler) complete(pull *n8nv1alpha1.N8nSourceControlPull, status metav1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	pull.Status.CompletionTime = &now
+	pull.Status.ObservedGeneration = pull.Generation
+	r.setCondition(pull, status, reason, message)
+}
+
+// setCondition sets the Completed condition on the pull status
+func (r *N8nSourceControlPullReconciler) setCondition(pull *n8nv1alpha1.N8nSourceControlPull, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               n8nv1alpha1.ConditionTypeCompleted,
+		Status:             status,
+		ObservedGeneration: pull.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	meta.SetStatusCondition(&pull.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *N8nSourceControlPullReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&n8nv1alpha1.N8nSourceControlPull{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("n8nsourcecontrolpull").
+		Complete(r)
+}
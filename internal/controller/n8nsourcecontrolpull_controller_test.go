@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("N8nSourceControlPull Controller", func() {
+	Context("When pulling from source control", func() {
+		const resourceName = "test-pull"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var recorder *record.FakeRecorder
+		var controllerReconciler *N8nSourceControlPullReconciler
+
+		createPull := func(force bool) {
+			resource := &n8nv1alpha1.N8nSourceControlPull{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: n8nv1alpha1.N8nSourceControlPullSpec{InstanceRef: instance.Name, Force: force},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		}
+
+		getPull := func() *n8nv1alpha1.N8nSourceControlPull {
+			resource := &n8nv1alpha1.N8nSourceControlPull{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		completed := func() *metav1.Condition {
+			return meta.FindStatusCondition(getPull().Status.Conditions, n8nv1alpha1.ConditionTypeCompleted)
+		}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "pull-instance", "default", fakeServer.URL())
+
+			recorder = record.NewFakeRecorder(100)
+			controllerReconciler = &N8nSourceControlPullReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				Recorder:          recorder,
+				OperatorNamespace: "default",
+			}
+		})
+
+		AfterEach(func() {
+			resource := &n8nv1alpha1.N8nSourceControlPull{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should pull once and record what was imported", func() {
+			result := &n8n.SourceControlPullResult{
+				Workflows:   []n8n.SourceControlItem{{ID: "wf-1", Name: "Orders"}},
+				Credentials: []n8n.SourceControlItem{{ID: "cred-1", Name: "Partner API", Type: "httpBasicAuth"}},
+			}
+			result.Variables.Added = []string{"REGION"}
+			fakeServer.setSourceControl(result, false)
+			createPull(false)
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := getPull()
+			Expect(resource.Status.Workflows).To(Equal([]n8nv1alpha1.PulledItem{{ID: "wf-1", Name: "Orders"}}))
+			Expect(resource.Status.Credentials).To(Equal([]n8nv1alpha1.PulledItem{
+				{ID: "cred-1", Name: "Partner API", Type: "httpBasicAuth"}}))
+			Expect(resource.Status.AddedVariables).To(Equal([]string{"REGION"}))
+			Expect(resource.Status.CompletionTime).NotTo(BeNil())
+			Expect(resource.Status.ObservedGeneration).To(Equal(resource.Generation))
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, n8nv1alpha1.ConditionTypeCompleted)).To(BeTrue())
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/source-control/pull")).To(Equal(1))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Pulled 1 workflows, 1 credentials, 1 variables and 0 tags")))
+		})
+
+		It("should complete with SourceControlUnavailable when source control isn't connected", func() {
+			createPull(false)
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			condition := completed()
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(n8nv1alpha1.ReasonSourceControlUnavailable))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/source-control/pull")).To(Equal(1))
+		})
+
+		It("should report a conflict and pull again once force is set", func() {
+			fakeServer.setSourceControl(&n8n.SourceControlPullResult{}, true)
+			createPull(false)
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+			Expect(completed().Reason).To(Equal(n8nv1alpha1.ReasonPullConflict))
+
+			resource := getPull()
+			resource.Spec.Force = true
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(completed().Reason).To(Equal(n8nv1alpha1.ReasonPullSucceeded))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/source-control/pull")).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// tagFinalizerName is the finalizer used to clean up tags in n8n
+const tagFinalizerName = "n8n.slys.dev/tag-cleanup"
+
+// N8nTagReconciler reconciles a N8nTag object
+type N8nTagReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// OperatorNamespace is the namespace where N8nInstance resources live
+	OperatorNamespace string
+	// AuditLogger receives an entry for every mutating n8n API request
+	AuditLogger logr.Logger
+	// RateLimiters bounds the request rate to each n8n instance across all clients
+	RateLimiters *n8n.RateLimiterRegistry
+	// Transports shares the connections to each n8n instance across all clients
+	Transports *n8n.TransportRegistry
+	// InstanceStates shares what was learned about each n8n instance across all clients
+	InstanceStates *n8n.InstanceStateRegistry
+	// CredentialsDir is the directory credentials.filePath of an N8nInstance
+	// must point into; empty rejects filePath
+	CredentialsDir string
+	// Retry sets how idempotent n8n requests are retried; zero values keep the
+	// client defaults
+	Retry RetryConfig
+}
+
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ntags,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ntags/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ntags/finalizers,verbs=update
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile creates or renames the tag in n8n and deletes it on cleanup. The
+// tag recorded in status is found by ID, so changing spec.name renames it; an
+// untracked tag with the same name is taken over instead of duplicated.
+// Workflows attach tags by name, so a workflow being deleted never removes an
+// N8nTag's tag.
+func (r *N8nTagReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.V(1).Info("Reconciling N8nTag")
+
+	tag := &n8nv1alpha1.N8nTag{}
+	if err := r.Get(ctx, req.NamespacedName, tag); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("N8nTag resource not found, ignoring")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get N8nTag")
+		return ctrl.Result{}, err
+	}
+
+	// A tag that was never created in n8n has nothing to clean up, so don't
+	// let an unavailable instance hold the finalizer
+	if !tag.DeletionTimestamp.IsZero() && tag.Status.TagID == "" {
+		return r.handleDeletion(ctx, tag, nil)
+	}
+
+	n8nClient, _, err := instanceClient(ctx, r.Client, r.OperatorNamespace, tag.Spec.InstanceRef,
+		r.RateLimiters, r.Transports, r.InstanceStates, r.CredentialsDir, n8n.WithAuditLogger(r.AuditLogger), n8n.WithRetry(r.Retry.MaxAttempts, r.Retry.BaseDelay))
+	if err != nil {
+		log.Error(err, "Failed to create n8n client")
+		reason, message := clientErrorReason(err)
+		r.setCondition(tag, metav1.ConditionFalse, reason, message)
+		if statusErr := r.Status().Update(ctx, tag); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	// Handle deletion
+	if !tag.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, tag, n8nClient)
+	}
+
+	// Add finalizer if it doesn't exist
+	if !controllerutil.ContainsFinalizer(tag, tagFinalizerName) {
+		controllerutil.AddFinalizer(tag, tagFinalizerName)
+		if err := r.Update(ctx, tag); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	tagID, err := r.syncTag(ctx, n8nClient, tag)
+	if err != nil {
+		log.Error(err, "Failed to sync tag to n8n")
+		r.Recorder.Event(tag, corev1.EventTypeWarning, "SyncFailed",
+			fmt.Sprintf("Failed to sync tag to n8n: %v", err))
+		r.setCondition(tag, metav1.ConditionFalse, n8nv1alpha1.ReasonSyncFailed,
+			fmt.Sprintf("Failed to sync tag: %v", err))
+		if statusErr := r.Status().Update(ctx, tag); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	tag.Status.TagID = tagID
+	tag.Status.ObservedGeneration = tag.Generation
+	r.setCondition(tag, metav1.ConditionTrue, n8nv1alpha1.ReasonSyncSucceeded,
+		fmt.Sprintf("Tag %s exists in n8n with ID %s", tag.Spec.Name, tagID))
+	if err := r.Status().Update(ctx, tag); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// syncTag makes the n8n tag match the spec and returns its ID. The tag
+// recorded in status wins over one matching by name, so changing the name
+// renames the tag rather than leaving the old one behind.
+func (r *N8nTagReconciler) syncTag(ctx context.Context, n8nClient *n8n.Client, tag *n8nv1alpha1.N8nTag) (string, error) {
+	log := logf.FromContext(ctx)
+	name := tag.Spec.Name
+
+	tags, err := n8nClient.ListTags(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var existing *n8n.Tag
+	for i := range tags {
+		if tag.Status.TagID != "" && tags[i].ID == tag.Status.TagID {
+			existing = &tags[i]
+			break
+		}
+		if existing == nil && tags[i].Name == name {
+			existing = &tags[i]
+		}
+	}
+
+	if existing == nil {
+		created, err := n8nClient.CreateTag(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		log.Info("Created tag in n8n", "name", name, "id", created.ID)
+		recordChange(r.Recorder, tag, "Created",
+			fmt.Sprintf("Tag %s created in n8n with ID %s", name, created.ID))
+		return created.ID, nil
+	}
+
+	if existing.Name == name {
+		return existing.ID, nil
+	}
+
+	if _, err := n8nClient.UpdateTag(ctx, existing.ID, name); err != nil {
+		return "", err
+	}
+	log.Info("Renamed tag in n8n", "from", existing.Name, "to", name, "id", existing.ID)
+	recordChange(r.Recorder, tag, "Updated",
+		fmt.Sprintf("Tag %s renamed to %s in n8n", existing.Name, name))
+	return existing.ID, nil
+}
+
+// handleDeletion deletes the tag from n8n, which detaches it from every
+// workflow, and releases the finalizer. A tag that is already gone from n8n
+// is treated as deleted.
+func (r *N8nTagReconciler) handleDeletion(ctx context.Context, tag *n8nv1alpha1.N8nTag, n8nClient *n8n.Client) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(tag, tagFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Handling deletion of N8nTag")
+
+	if tag.Status.TagID != "" {
+		log.Info("Deleting tag from n8n", "id", tag.Status.TagID)
+		if err := n8nClient.DeleteTag(ctx, tag.Status.TagID); err != nil {
+			if n8n.IsNotFound(err) {
+				log.Info("Tag already deleted from n8n", "id", tag.Status.TagID)
+				r.Recorder.Event(tag, corev1.EventTypeNormal, "AlreadyDeleted",
+					"Tag no longer exists in n8n, releasing finalizer")
+			} else {
+				// Log as warning but continue with finalizer removal
+				log.Info("Failed to delete tag from n8n (continuing with cleanup)", "error", err)
+				r.Recorder.Event(tag, corev1.EventTypeWarning, "DeleteFailed",
+					fmt.Sprintf("Failed to delete tag from n8n: %v", err))
+			}
+		} else {
+			recordChange(r.Recorder, tag, "Deleted", "Tag deleted from n8n")
+		}
+	}
+
+	controllerutil.RemoveFinalizer(tag, tagFinalizerName)
+	if err := r.Update(ctx, tag); err != nil {
+		log.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully deleted N8nTag")
+	return ctrl.Result{}, nil
+}
+
+// setCondition sets the Ready condition on the tag status
+func (r *N8nTagReconciler) setCondition(tag *n8nv1alpha1.N8nTag, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               n8nv1alpha1.ConditionTypeReady,
+		Status:             status,
+		ObservedGeneration: tag.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	meta.SetStatusCondition(&tag.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *N8nTagReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&n8nv1alpha1.N8nTag{}).
+		Named("n8ntag").
+		Complete(r)
+}
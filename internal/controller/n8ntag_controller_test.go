@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("N8nTag Controller", func() {
+	Context("When managing a tag", func() {
+		const resourceName = "test-tag"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nTagReconciler
+
+		createTag := func(name string) {
+			resource := &n8nv1alpha1.N8nTag{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: n8nv1alpha1.N8nTagSpec{
+					InstanceRef: instance.Name,
+					Name:        name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		}
+
+		getTag := func() *n8nv1alpha1.N8nTag {
+			resource := &n8nv1alpha1.N8nTag{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "tag-instance", "default", fakeServer.URL())
+
+			controllerReconciler = &N8nTagReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				Recorder:          record.NewFakeRecorder(100),
+				OperatorNamespace: "default",
+			}
+		})
+
+		AfterEach(func() {
+			resource := &n8nv1alpha1.N8nTag{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+			}
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should create the tag and record its ID", func() {
+			createTag("production")
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 3)
+
+			resource := getTag()
+			Expect(resource.Status.TagID).NotTo(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)).To(BeTrue())
+			Expect(fakeServer.tag(resource.Status.TagID).Name).To(Equal("production"))
+
+			// An unchanged tag is neither created again nor renamed
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/tags")).To(Equal(1))
+			Expect(fakeServer.countRequests(http.MethodPut, "/api/v1/tags/")).To(Equal(0))
+		})
+
+		It("should take over an existing tag with the same name", func() {
+			existingID := fakeServer.addTag("production")
+			createTag("production")
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			Expect(getTag().Status.TagID).To(Equal(existingID))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/tags")).To(Equal(0))
+		})
+
+		It("should rename the tracked tag when the name changes", func() {
+			createTag("production")
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+			tagID := getTag().Status.TagID
+
+			resource := getTag()
+			resource.Spec.Name = "prod"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(getTag().Status.TagID).To(Equal(tagID))
+			Expect(fakeServer.tag(tagID).Name).To(Equal("prod"))
+			Expect(fakeServer.tagNames()).To(Equal([]string{"prod"}))
+		})
+
+		It("should report a rename onto a name another tag already has", func() {
+			fakeServer.addTag("prod")
+			createTag("production")
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := getTag()
+			resource.Spec.Name = "prod"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).To(HaveOccurred())
+
+			ready := meta.FindStatusCondition(getTag().Status.Conditions, n8nv1alpha1.ConditionTypeReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.ReasonSyncFailed))
+		})
+
+		It("should delete the tag from n8n on deletion", func() {
+			createTag("production")
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := getTag()
+			tagID := resource.Status.TagID
+			Expect(fakeServer.tag(tagID)).NotTo(BeNil())
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(fakeServer.tag(tagID)).To(BeNil())
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,315 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// variableFinalizerName is the finalizer used to clean up variables in n8n
+const variableFinalizerName = "n8n.slys.dev/variable-cleanup"
+
+// N8nVariableReconciler reconciles a N8nVariable object
+type N8nVariableReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// OperatorNamespace is the namespace where N8nInstance resources live
+	OperatorNamespace string
+	// AuditLogger receives an entry for every mutating n8n API request
+	AuditLogger logr.Logger
+	// RateLimiters bounds the request rate to each n8n instance across all clients
+	RateLimiters *n8n.RateLimiterRegistry
+	// Transports shares the connections to each n8n instance across all clients
+	Transports *n8n.TransportRegistry
+	// InstanceStates shares what was learned about each n8n instance across all clients
+	InstanceStates *n8n.InstanceStateRegistry
+	// CredentialsDir is the directory credentials.filePath of an N8nInstance
+	// must point into; empty rejects filePath
+	CredentialsDir string
+	// Retry sets how idempotent n8n requests are retried; zero values keep the
+	// client defaults
+	Retry RetryConfig
+}
+
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nvariables,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nvariables/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nvariables/finalizers,verbs=update
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile creates or updates the variable in n8n and deletes it on cleanup.
+// The variables API addresses variables by ID, so the existing variable is
+// found by listing them and matching the recorded ID or, failing that, the key.
+func (r *N8nVariableReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.V(1).Info("Reconciling N8nVariable")
+
+	variable := &n8nv1alpha1.N8nVariable{}
+	if err := r.Get(ctx, req.NamespacedName, variable); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("N8nVariable resource not found, ignoring")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get N8nVariable")
+		return ctrl.Result{}, err
+	}
+
+	// A variable that was never created in n8n has nothing to clean up, so
+	// don't let an unavailable instance hold the finalizer
+	if !variable.DeletionTimestamp.IsZero() && variable.Status.VariableID == "" {
+		return r.handleDeletion(ctx, variable, nil)
+	}
+
+	n8nClient, _, err := instanceClient(ctx, r.Client, r.OperatorNamespace, variable.Spec.InstanceRef,
+		r.RateLimiters, r.Transports, r.InstanceStates, r.CredentialsDir, n8n.WithAuditLogger(r.AuditLogger), n8n.WithRetry(r.Retry.MaxAttempts, r.Retry.BaseDelay))
+	if err != nil {
+		log.Error(err, "Failed to create n8n client")
+		reason, message := clientErrorReason(err)
+		r.setCondition(variable, metav1.ConditionFalse, reason, message)
+		if statusErr := r.Status().Update(ctx, variable); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	// Handle deletion
+	if !variable.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, variable, n8nClient)
+	}
+
+	// Add finalizer if it doesn't exist
+	if !controllerutil.ContainsFinalizer(variable, variableFinalizerName) {
+		controllerutil.AddFinalizer(variable, variableFinalizerName)
+		if err := r.Update(ctx, variable); err != nil {
+			log.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	value, err := r.loadValue(ctx, variable)
+	if err != nil {
+		log.Info("Variable value unavailable", "reason", err.Error())
+		r.setCondition(variable, metav1.ConditionFalse, n8nv1alpha1.ReasonSecretUnavailable, err.Error())
+		if statusErr := r.Status().Update(ctx, variable); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, nil
+	}
+
+	desired := &n8n.Variable{Key: variable.Spec.Key, Value: value}
+	variableID, err := r.syncVariable(ctx, n8nClient, variable, desired)
+	if err != nil {
+		log.Error(err, "Failed to sync variable to n8n")
+		r.Recorder.Event(variable, corev1.EventTypeWarning, "SyncFailed",
+			fmt.Sprintf("Failed to sync variable to n8n: %v", err))
+		r.setCondition(variable, metav1.ConditionFalse, n8nv1alpha1.ReasonSyncFailed,
+			fmt.Sprintf("Failed to sync variable: %v", err))
+		if statusErr := r.Status().Update(ctx, variable); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+	}
+
+	variable.Status.VariableID = variableID
+	variable.Status.ObservedGeneration = variable.Generation
+	r.setCondition(variable, metav1.ConditionTrue, n8nv1alpha1.ReasonSyncSucceeded,
+		fmt.Sprintf("Variable %s exists in n8n with ID %s", variable.Spec.Key, variableID))
+	if err := r.Status().Update(ctx, variable); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// syncVariable makes the n8n variable match desired and returns its ID. The
+// variable recorded in status wins over one matching by key, so changing the
+// key renames the variable rather than leaving the old one behind.
+func (r *N8nVariableReconciler) syncVariable(ctx context.Context, n8nClient *n8n.Client,
+	variable *n8nv1alpha1.N8nVariable, desired *n8n.Variable) (string, error) {
+	log := logf.FromContext(ctx)
+
+	variables, err := n8nClient.ListVariables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var existing *n8n.Variable
+	for i := range variables {
+		if variable.Status.VariableID != "" && variables[i].ID == variable.Status.VariableID {
+			existing = &variables[i]
+			break
+		}
+		if existing == nil && variables[i].Key == desired.Key {
+			existing = &variables[i]
+		}
+	}
+
+	if existing == nil {
+		created, err := n8nClient.CreateVariable(ctx, desired)
+		if err != nil {
+			return "", err
+		}
+		log.Info("Created variable in n8n", "key", desired.Key, "id", created.ID)
+		recordChange(r.Recorder, variable, "Created",
+			fmt.Sprintf("Variable %s created in n8n with ID %s", desired.Key, created.ID))
+		return created.ID, nil
+	}
+
+	if existing.Key == desired.Key && existing.Value == desired.Value {
+		return existing.ID, nil
+	}
+
+	if err := n8nClient.UpdateVariable(ctx, existing.ID, desired); err != nil {
+		return "", err
+	}
+	log.Info("Updated variable in n8n", "key", desired.Key, "id", existing.ID)
+	recordChange(r.Recorder, variable, "Updated",
+		fmt.Sprintf("Variable %s updated in n8n", desired.Key))
+	return existing.ID, nil
+}
+
+// loadValue returns the inline value, or the value read from the Secret key
+// referenced by spec.valueFrom. An optional Secret or key that is missing
+// yields an empty value.
+func (r *N8nVariableReconciler) loadValue(ctx context.Context, variable *n8nv1alpha1.N8nVariable) (string, error) {
+	source := variable.Spec.ValueFrom
+	if source == nil {
+		return variable.Spec.Value, nil
+	}
+	optional := source.Optional != nil && *source.Optional
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: variable.Namespace, Name: source.Name}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			if optional {
+				return "", nil
+			}
+			return "", fmt.Errorf("secret %q not found", source.Name)
+		}
+		return "", fmt.Errorf("failed to get Secret %q: %w", source.Name, err)
+	}
+
+	value, ok := secret.Data[source.Key]
+	if !ok {
+		if optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("secret %q does not contain key %q", source.Name, source.Key)
+	}
+	return string(value), nil
+}
+
+// handleDeletion deletes the variable from n8n and releases the finalizer.
+// A variable that is already gone from n8n is treated as deleted.
+func (r *N8nVariableReconciler) handleDeletion(ctx context.Context, variable *n8nv1alpha1.N8nVariable, n8nClient *n8n.Client) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(variable, variableFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Handling deletion of N8nVariable")
+
+	if variable.Status.VariableID != "" {
+		log.Info("Deleting variable from n8n", "id", variable.Status.VariableID)
+		if err := n8nClient.DeleteVariable(ctx, variable.Status.VariableID); err != nil {
+			if n8n.IsNotFound(err) {
+				log.Info("Variable already deleted from n8n", "id", variable.Status.VariableID)
+				r.Recorder.Event(variable, corev1.EventTypeNormal, "AlreadyDeleted",
+					"Variable no longer exists in n8n, releasing finalizer")
+			} else {
+				// Log as warning but continue with finalizer removal
+				log.Info("Failed to delete variable from n8n (continuing with cleanup)", "error", err)
+				r.Recorder.Event(variable, corev1.EventTypeWarning, "DeleteFailed",
+					fmt.Sprintf("Failed to delete variable from n8n: %v", err))
+			}
+		} else {
+			recordChange(r.Recorder, variable, "Deleted", "Variable deleted from n8n")
+		}
+	}
+
+	controllerutil.RemoveFinalizer(variable, variableFinalizerName)
+	if err := r.Update(ctx, variable); err != nil {
+		log.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Successfully deleted N8nVariable")
+	return ctrl.Result{}, nil
+}
+
+// variablesForSecret maps a Secret to the variables in its namespace that
+// read their value from it
+func (r *N8nVariableReconciler) variablesForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	variables := &n8nv1alpha1.N8nVariableList{}
+	if err := r.List(ctx, variables, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list variables for Secret", "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, variable := range variables.Items {
+		if variable.Spec.ValueFrom != nil && variable.Spec.ValueFrom.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&variable)})
+		}
+	}
+	return requests
+}
+
+// setCondition sets the Ready condition on the variable status
+func (r *N8nVariableReconciler) setCondition(variable *n8nv1alpha1.N8nVariable, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               n8nv1alpha1.ConditionTypeReady,
+		Status:             status,
+		ObservedGeneration: variable.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	meta.SetStatusCondition(&variable.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *N8nVariableReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&n8nv1alpha1.N8nVariable{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.variablesForSecret)).
+		Named("n8nvariable").
+		Complete(r)
+}
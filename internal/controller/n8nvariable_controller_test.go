@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("N8nVariable Controller", func() {
+	Context("When managing a variable", func() {
+		const resourceName = "test-variable"
+		const secretName = "test-variable-value"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var fakeServer *fakeN8n
+		var instance *n8nv1alpha1.N8nInstance
+		var controllerReconciler *N8nVariableReconciler
+
+		createVariable := func(spec n8nv1alpha1.N8nVariableSpec) {
+			spec.InstanceRef = instance.Name
+			resource := &n8nv1alpha1.N8nVariable{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: spec,
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		}
+
+		getVariable := func() *n8nv1alpha1.N8nVariable {
+			resource := &n8nv1alpha1.N8nVariable{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			return resource
+		}
+
+		BeforeEach(func() {
+			fakeServer = newFakeN8n()
+			instance = createReadyInstance(ctx, "variable-instance", "default", fakeServer.URL())
+
+			controllerReconciler = &N8nVariableReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				Recorder:          record.NewFakeRecorder(100),
+				OperatorNamespace: "default",
+			}
+		})
+
+		AfterEach(func() {
+			resource := &n8nv1alpha1.N8nVariable{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, resource); err == nil {
+				resource.Finalizers = nil
+				Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+			}
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, secret))).To(Succeed())
+			deleteInstance(ctx, instance)
+			fakeServer.Close()
+		})
+
+		It("should create the variable and record its ID", func() {
+			createVariable(n8nv1alpha1.N8nVariableSpec{Key: "REGION", Value: "eu-west-1"})
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 3)
+
+			resource := getVariable()
+			Expect(resource.Status.VariableID).NotTo(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+			created := fakeServer.variable(resource.Status.VariableID)
+			Expect(created).NotTo(BeNil())
+			Expect(created.Key).To(Equal("REGION"))
+			Expect(created.Value).To(Equal("eu-west-1"))
+
+			// An unchanged variable is neither created again nor updated
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/variables")).To(Equal(1))
+			Expect(fakeServer.countRequests(http.MethodPut, "/api/v1/variables/")).To(Equal(0))
+		})
+
+		It("should take over an existing variable with the same key", func() {
+			existingID := fakeServer.addVariable("REGION", "us-east-1")
+			createVariable(n8nv1alpha1.N8nVariableSpec{Key: "REGION", Value: "eu-west-1"})
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			Expect(getVariable().Status.VariableID).To(Equal(existingID))
+			Expect(fakeServer.variable(existingID).Value).To(Equal("eu-west-1"))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/variables")).To(Equal(0))
+		})
+
+		It("should update the tracked variable when the key or value changes", func() {
+			createVariable(n8nv1alpha1.N8nVariableSpec{Key: "REGION", Value: "eu-west-1"})
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+			variableID := getVariable().Status.VariableID
+
+			resource := getVariable()
+			resource.Spec.Key = "AWS_REGION"
+			resource.Spec.Value = "eu-central-1"
+			Expect(k8sClient.Update(ctx, resource)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(getVariable().Status.VariableID).To(Equal(variableID))
+			updated := fakeServer.variable(variableID)
+			Expect(updated.Key).To(Equal("AWS_REGION"))
+			Expect(updated.Value).To(Equal("eu-central-1"))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/variables")).To(Equal(1))
+		})
+
+		It("should wait for the Secret holding the value", func() {
+			createVariable(n8nv1alpha1.N8nVariableSpec{
+				Key: "API_TOKEN",
+				ValueFrom: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  "token",
+				},
+			})
+
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := getVariable()
+			Expect(resource.Status.VariableID).To(BeEmpty())
+			ready := meta.FindStatusCondition(resource.Status.Conditions, n8nv1alpha1.ConditionTypeReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal(n8nv1alpha1.ReasonSecretUnavailable))
+			Expect(fakeServer.countRequests(http.MethodPost, "/api/v1/variables")).To(Equal(0))
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("s3cret")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			resource = getVariable()
+			Expect(resource.Status.VariableID).NotTo(BeEmpty())
+			Expect(fakeServer.variable(resource.Status.VariableID).Value).To(Equal("s3cret"))
+		})
+
+		It("should delete the variable from n8n on deletion", func() {
+			createVariable(n8nv1alpha1.N8nVariableSpec{Key: "REGION", Value: "eu-west-1"})
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 2)
+
+			resource := getVariable()
+			variableID := resource.Status.VariableID
+			Expect(fakeServer.variable(variableID)).NotTo(BeNil())
+
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			reconcileTimes(ctx, controllerReconciler, typeNamespacedName, 1)
+
+			Expect(fakeServer.variable(variableID)).To(BeNil())
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -19,12 +19,17 @@ package controller
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -33,9 +38,14 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
 	"github.com/jspanos/n8n-resource-operator/internal/n8n"
@@ -45,15 +55,38 @@ const (
 	// finalizerName is the finalizer used to clean up workflows in n8n
 	finalizerName = "n8n.slys.dev/workflow-cleanup"
 
-	// forceSyncAnnotation triggers a one-time sync even for CreateOnly/Manual policies
-	// After sync completes, the annotation is removed
+	// instanceRefIndexKey indexes workflows by the N8nInstance they reference
+	instanceRefIndexKey = "spec.instanceRef"
+
+	// forceSyncAnnotation forces a single update whatever the spec hash or sync
+	// policy, except Manual, each time its value (typically a timestamp) changes.
+	// The handled value is recorded in status.lastForceSync.
 	forceSyncAnnotation = "n8n.slys.dev/force-sync"
 
-	// Default requeue interval for periodic reconciliation
-	defaultRequeueInterval = 5 * time.Minute
+	// createOnlyOverrideAnnotation forces a single update under CreateOnly each time
+	// its value changes. Unlike force-sync it is left in place, so it can live in Git.
+	createOnlyOverrideAnnotation = "n8n.slys.dev/create-only-override"
+
+	// allowProtectedNameAnnotation set to "true" lets the workflow manage a name
+	// the instance protects
+	allowProtectedNameAnnotation = "n8n.slys.dev/allow-protected-name"
+
+	// Default requeue interval for periodic reconciliation, used for workflows
+	// without spec.reconcileInterval
+	defaultRequeueInterval = n8nv1alpha1.DefaultReconcileInterval
 
 	// Error requeue interval
 	errorRequeueInterval = 30 * time.Second
+
+	// First requeue interval while waiting for the instance to become Ready. It
+	// grows with the time spent waiting, up to the workflow's reconcile interval.
+	instanceWaitInterval = 10 * time.Second
+
+	// Requeue interval while n8n refuses writes (maintenance / read-only mode)
+	readOnlyRequeueInterval = 2 * time.Minute
+
+	// Default deadline for a whole reconcile, including every n8n call
+	defaultReconcileTimeout = 2 * time.Minute
 )
 
 // N8nWorkflowReconciler reconciles a N8nWorkflow object
@@ -64,19 +97,128 @@ type N8nWorkflowReconciler struct {
 
 	// OperatorNamespace is the namespace where N8nInstance resources live
 	OperatorNamespace string
+	// DefaultInstance names the N8nInstance in OperatorNamespace used by
+	// workflows without instanceRef whose namespace has no default instance
+	DefaultInstance string
+	// AuditLogger receives an entry for every mutating n8n API request
+	AuditLogger logr.Logger
+	// RateLimiters bounds the request rate to each n8n instance across all clients
+	RateLimiters *n8n.RateLimiterRegistry
+	// Transports shares the connections to each n8n instance across all clients
+	Transports *n8n.TransportRegistry
+	// InstanceStates shares what was learned about each n8n instance across all clients
+	InstanceStates *n8n.InstanceStateRegistry
+	// CredentialsDir is the directory credentials.filePath of an N8nInstance
+	// must point into; empty rejects filePath
+	CredentialsDir string
+	// Retry sets how idempotent n8n requests are retried; zero values keep the
+	// client defaults
+	Retry RetryConfig
+	// Heartbeat is renewed after every successful reconcile
+	Heartbeat *LeaseHeartbeat
+	// ManagedBy is injected into the meta of every synced workflow
+	ManagedBy ManagedByMarker
+	// NodeOrderSignificant makes reordering spec.workflow.nodes count as a change.
+	// Nodes are always sent in authored order either way.
+	NodeOrderSignificant bool
+	// Environment names the environment this operator runs in, matched against
+	// spec.activeIn
+	Environment string
+	// NodeTypeVersions upgrades nodes of the listed types to the given typeVersion
+	NodeTypeVersions NodeTypeVersions
+	// NodeTypePolicy restricts the node types workflows may use. Nil allows all.
+	NodeTypePolicy *NodeTypePolicySource
+	// BlockedNodeTypes are node type globs no workflow may use, e.g. to forbid
+	// running shell commands. An instance's spec.blockedNodeTypes replaces them.
+	BlockedNodeTypes []string
+	// ExecutionTimeoutPolicy caps the executionTimeout setting of every workflow.
+	// A zero Max disables it. An instance's spec.executionTimeoutPolicy replaces it.
+	ExecutionTimeoutPolicy n8nv1alpha1.ExecutionTimeoutPolicy
+	// ReconcileTimeout bounds a whole reconcile; a reconcile still running at the
+	// deadline is abandoned and requeued. Defaults to 2 minutes.
+	ReconcileTimeout time.Duration
+	// WorkflowCache remembers the workflow each name resolved to per instance, so
+	// lookups by name can skip listing workflows. Nil disables caching.
+	WorkflowCache *n8n.WorkflowCache
+	// Backoff doubles the delay before retrying a workflow each time it fails
+	// again. Nil retries every failure after 30 seconds.
+	Backoff *FailureBackoff
 }
 
 // +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nworkflows,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nworkflows/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nworkflows/finalizers,verbs=update
 // +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ninstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8nworkflowtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=n8n.slys.dev,resources=n8ncredentials,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *N8nWorkflowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	deadlineCtx, cancel, timeout := withReconcileDeadline(ctx, r.ReconcileTimeout)
+	defer cancel()
+
+	result, err := r.reconcile(deadlineCtx, req)
+	// Don't let a stalled n8n hold the worker; try again later from scratch
+	if deadlineExceeded(ctx, deadlineCtx) {
+		logf.FromContext(ctx).Info("Reconcile exceeded its deadline, abandoning", "timeout", timeout, "error", err)
+		r.recordReconcileTimeout(ctx, req, timeout)
+		result, err = r.Backoff.retry(req.NamespacedName, errorRequeueInterval), nil
+	}
+	r.Backoff.finish(req.NamespacedName, err)
+	return result, err
+}
+
+// errorRequeue counts a failed reconcile of workflow and returns the result
+// retrying it after the error interval, backed off while failures repeat
+func (r *N8nWorkflowReconciler) errorRequeue(workflow *n8nv1alpha1.N8nWorkflow) ctrl.Result {
+	return r.Backoff.retry(client.ObjectKeyFromObject(workflow), errorRequeueInterval)
+}
+
+// withReconcileDeadline bounds ctx by timeout, or by defaultReconcileTimeout
+// when timeout is unset, so every n8n call made under it is cancelled once a
+// reconcile runs too long. It returns the timeout applied.
+func withReconcileDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc, time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultReconcileTimeout
+	}
+	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
+	return deadlineCtx, cancel, timeout
+}
+
+// deadlineExceeded reports whether deadlineCtx ran out while its parent, the
+// manager's context, is still live
+func deadlineExceeded(parent, deadlineCtx context.Context) bool {
+	return deadlineCtx.Err() == context.DeadlineExceeded && parent.Err() == nil
+}
+
+// recordReconcileTimeout reports an abandoned reconcile on a fresh copy of the
+// resource, since the deadline context can no longer be used
+func (r *N8nWorkflowReconciler) recordReconcileTimeout(ctx context.Context, req ctrl.Request, timeout time.Duration) {
+	log := logf.FromContext(ctx)
+	workflow := &n8nv1alpha1.N8nWorkflow{}
+	if err := r.Get(ctx, req.NamespacedName, workflow); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Failed to get N8nWorkflow")
+		}
+		return
+	}
+	message := fmt.Sprintf("Reconcile did not finish within %s and was abandoned", timeout)
+	r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonReconcileTimeout, message)
+	r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+		n8nv1alpha1.ReasonReconcileTimeout, message)
+	if err := r.Status().Update(ctx, workflow); err != nil {
+		log.Error(err, "Failed to update status")
+	}
+}
+
+// reconcile does the work of Reconcile under its deadline
+func (r *N8nWorkflowReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 	log.V(1).Info("Reconciling N8nWorkflow")
+	reconcileStart := time.Now()
 
 	// Fetch the N8nWorkflow instance
 	workflow := &n8nv1alpha1.N8nWorkflow{}
@@ -90,17 +232,33 @@ func (r *N8nWorkflowReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// Get n8n API client
-	n8nClient, err := r.getN8nClient(ctx, workflow)
+	phaseStart := time.Now()
+	n8nClient, instance, err := r.getN8nClient(ctx, workflow)
+	timings := &n8nv1alpha1.ReconcileTimings{SecretFetchMillis: elapsedMillis(phaseStart)}
 	if err != nil {
+		// A resource being deleted that never got a workflow in n8n, or that
+		// orphans it, has nothing to clean up, so don't let an unavailable
+		// instance hold the finalizer
+		if !workflow.DeletionTimestamp.IsZero() &&
+			(workflow.Status.WorkflowID == "" || workflow.GetDeletionPolicy() == n8nv1alpha1.DeletionPolicyOrphan) {
+			return r.handleDeletion(ctx, workflow, nil)
+		}
+		// An instance that isn't Ready is expected while n8n restarts, so wait for
+		// it quietly; the instance watch requeues the workflow once it is Ready
+		if unavailable, ok := err.(*instanceUnavailableError); ok && unavailable.reason == n8nv1alpha1.ReasonInstanceNotReady {
+			return r.waitForInstance(ctx, workflow, unavailable.message)
+		}
 		log.Error(err, "Failed to create n8n client")
-		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-			n8nv1alpha1.ReasonAPIError, fmt.Sprintf("Failed to create n8n client: %v", err))
+		reason, message := clientErrorReason(err)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse, reason, message)
 		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
 			log.Error(statusErr, "Failed to update status")
 		}
-		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+		return r.errorRequeue(workflow), err
 	}
 
+	meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeWaiting)
+
 	// Handle deletion
 	if !workflow.DeletionTimestamp.IsZero() {
 		return r.handleDeletion(ctx, workflow, n8nClient)
@@ -116,234 +274,976 @@ func (r *N8nWorkflowReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// Writes will be refused, so say why up front rather than only via API
+	// errors. The event is only emitted when the key is first found read-only.
+	if instance.Status.APIKeyScope == n8nv1alpha1.APIKeyScopeRead {
+		message := fmt.Sprintf("N8nInstance %q has a read-only API key; changes cannot be synced", instance.Name)
+		if !meta.IsStatusConditionTrue(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReadOnlyAPIKey) {
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonReadOnlyAPIKey, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReadOnlyAPIKey, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonReadOnlyAPIKey, message)
+	} else {
+		meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReadOnlyAPIKey)
+	}
+
+	// Hold off until the instance's URL migration releases this workflow
+	if urlMigrationPending(instance, workflow) {
+		log.V(1).Info("Waiting for instance URL migration", "url", instance.Status.URLMigration.ToURL)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonURLMigrationPending,
+			fmt.Sprintf("Waiting for N8nInstance %q to release this workflow to %s", instance.Name, instance.Status.URLMigration.ToURL))
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: instance.GetMigrationBatchInterval()}, nil
+	}
+
+	// Export the workflow to import instead of syncing an empty spec
+	if importPending(workflow) {
+		return r.importWorkflow(ctx, workflow, n8nClient)
+	}
+
 	// Reconcile the workflow
-	return r.reconcileWorkflow(ctx, workflow, n8nClient)
+	result, err := r.reconcileWorkflow(ctx, workflow, instance, n8nClient, timings, reconcileStart)
+
+	// Back off while the instance reports high load
+	if multiplier := instance.GetRequeueMultiplier(); multiplier > 1 && result.RequeueAfter > 0 {
+		log.V(1).Info("Instance under high load, lengthening requeue", "multiplier", multiplier)
+		result.RequeueAfter *= time.Duration(multiplier)
+	}
+	return result, err
+}
+
+// waitForInstance marks the workflow as waiting for its N8nInstance to become
+// Ready and requeues it with a backoff that grows with the time spent waiting
+func (r *N8nWorkflowReconciler) waitForInstance(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, message string) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	waitingSince := time.Now()
+	if waiting := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeWaiting); waiting != nil &&
+		waiting.Status == metav1.ConditionTrue {
+		waitingSince = waiting.LastTransitionTime.Time
+	} else {
+		log.Info("Waiting for instance to become Ready", "instance", workflowInstance(workflow))
+	}
+	r.setCondition(workflow, n8nv1alpha1.ConditionTypeWaiting, metav1.ConditionTrue,
+		n8nv1alpha1.ReasonInstanceNotReady, message)
+	r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+		n8nv1alpha1.ReasonInstanceNotReady, message)
+	if err := r.Status().Update(ctx, workflow); err != nil {
+		log.Error(err, "Failed to update status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: instanceWaitBackoff(time.Since(waitingSince), workflow.GetReconcileInterval())}, nil
 }
 
-// getN8nClient creates an n8n API client by looking up the referenced N8nInstance
-func (r *N8nWorkflowReconciler) getN8nClient(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (*n8n.Client, error) {
+// instanceWaitBackoff returns the requeue interval after waiting for an
+// instance for waited: about as long again, so retries roughly double, between
+// instanceWaitInterval and limit
+func instanceWaitBackoff(waited, limit time.Duration) time.Duration {
+	return min(max(waited, instanceWaitInterval), max(limit, instanceWaitInterval))
+}
+
+// Write triggers record why a workflow is written, as part of its idempotency key
+const (
+	writeTriggerSpecChange = "spec-change"
+	writeTriggerDrift      = "drift"
+	writeTriggerForceSync  = "force-sync"
+	writeTriggerOverride   = "override"
+)
+
+// idempotencyKey derives a key for a mutating n8n request from the resource
+// UID, the desired spec hash, the write trigger, the last successful mutation
+// and the sync step. Retries of a failed write share a key, while writing
+// another spec, for another reason or after the previous write succeeded
+// produces a fresh one.
+func idempotencyKey(workflow *n8nv1alpha1.N8nWorkflow, specHash, trigger string, step SyncStep) string {
+	var lastMutation int64
+	if workflow.Status.LastMutationTime != nil {
+		lastMutation = workflow.Status.LastMutationTime.Unix()
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s/%d/%s", workflow.UID, specHash, trigger, lastMutation, step)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// elapsedMillis returns the milliseconds elapsed since start
+func elapsedMillis(start time.Time) int64 {
+	return time.Since(start).Milliseconds()
+}
+
+// getN8nClient creates an n8n API client by looking up the referenced or
+// default N8nInstance, which is also returned so callers can honour its status
+func (r *N8nWorkflowReconciler) getN8nClient(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (*n8n.Client, *n8nv1alpha1.N8nInstance, error) {
+	key, err := r.resolveInstance(ctx, workflow)
+	if err != nil {
+		return nil, nil, err
+	}
+	return instanceClient(ctx, r.Client, key.Namespace, key.Name,
+		r.RateLimiters, r.Transports, r.InstanceStates, r.CredentialsDir, n8n.WithAuditLogger(r.AuditLogger), n8n.WithRetry(r.Retry.MaxAttempts, r.Retry.BaseDelay))
+}
+
+// instanceClient creates an n8n API client for the N8nInstance named instanceRef
+// in the operator namespace, which is also returned so callers can honour its
+// status. opts are applied after the instance's rate limiter, transport, timeout
+// and request logger.
+func instanceClient(ctx context.Context, reader client.Reader, operatorNamespace, instanceRef string,
+	rateLimiters *n8n.RateLimiterRegistry, transports *n8n.TransportRegistry, states *n8n.InstanceStateRegistry,
+	credentialsDir string, opts ...n8n.Option) (*n8n.Client, *n8nv1alpha1.N8nInstance, error) {
 	// instanceRef is required
-	if workflow.Spec.InstanceRef == "" {
-		return nil, fmt.Errorf("instanceRef is required")
+	if instanceRef == "" {
+		return nil, nil, fmt.Errorf("instanceRef is required")
 	}
 
 	// Look up the N8nInstance in the operator namespace
 	instance := &n8nv1alpha1.N8nInstance{}
 	instanceKey := types.NamespacedName{
-		Name:      workflow.Spec.InstanceRef,
-		Namespace: r.OperatorNamespace,
+		Name:      instanceRef,
+		Namespace: operatorNamespace,
 	}
-	if err := r.Get(ctx, instanceKey, instance); err != nil {
+	if err := reader.Get(ctx, instanceKey, instance); err != nil {
 		if errors.IsNotFound(err) {
-			return nil, fmt.Errorf("N8nInstance %q not found in namespace %q", workflow.Spec.InstanceRef, r.OperatorNamespace)
+			return nil, nil, &instanceUnavailableError{
+				reason:  n8nv1alpha1.ReasonInstanceNotFound,
+				message: fmt.Sprintf("N8nInstance %q not found in namespace %q", instanceRef, operatorNamespace),
+			}
 		}
-		return nil, fmt.Errorf("failed to get N8nInstance %q: %w", workflow.Spec.InstanceRef, err)
+		return nil, nil, fmt.Errorf("failed to get N8nInstance %q: %w", instanceRef, err)
 	}
 
-	// Check if instance is ready
+	// Wait until the instance health check has passed
 	if !instance.Status.Ready {
-		return nil, fmt.Errorf("N8nInstance %q is not ready", workflow.Spec.InstanceRef)
+		return nil, nil, &instanceUnavailableError{
+			reason:  n8nv1alpha1.ReasonInstanceNotReady,
+			message: fmt.Sprintf("N8nInstance %q is not ready", instanceRef),
+		}
 	}
 
-	// Get the resolved URL
-	baseURL := instance.GetResolvedURL()
+	// Prefer the URL the instance last validated, so a URL change isn't used
+	// before its health check passes
+	baseURL := instance.Status.URL
+	if baseURL == "" {
+		baseURL = instance.GetResolvedURL()
+	}
 	if baseURL == "" {
-		return nil, fmt.Errorf("N8nInstance %q has no URL configured", workflow.Spec.InstanceRef)
+		return nil, nil, fmt.Errorf("N8nInstance %q has no URL configured", instanceRef)
+	}
+
+	// Get API key and any proxy credentials from secret (secret must be in operator namespace)
+	apiKey, auth, err := instanceCredentials(ctx, reader, instance, credentialsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig, err := instanceTLSConfig(ctx, reader, instance)
+	if err != nil {
+		return nil, nil, fmt.Errorf("N8nInstance %q: %w", instanceRef, err)
+	}
+
+	opts = append([]n8n.Option{instanceRateLimit(rateLimiters, instance, baseURL), n8n.WithTimeout(instance.GetTimeout()),
+		instanceTransport(transports, instance, baseURL, tlsConfig), n8n.WithInstanceState(states.For(baseURL)),
+		n8n.WithLogger(logf.FromContext(ctx).WithName("n8n")), auth}, opts...)
+	return n8n.NewClient(baseURL, apiKey, opts...), instance, nil
+}
+
+// instanceRateLimit makes the client share the request budget of the instance
+// at baseURL: its spec.rateLimit when set, otherwise the operator-wide one
+func instanceRateLimit(rateLimiters *n8n.RateLimiterRegistry, instance *n8nv1alpha1.N8nInstance, baseURL string) n8n.Option {
+	if requestsPerSecond, burst, ok := instance.GetRateLimit(); ok {
+		return n8n.WithRateLimiter(rateLimiters.ForLimit(baseURL, requestsPerSecond, burst))
 	}
+	return n8n.WithRateLimiter(rateLimiters.For(baseURL))
+}
 
-	// Get API key from secret (secret must be in operator namespace)
-	secret := &corev1.Secret{}
-	secretKey := types.NamespacedName{
-		Name:      instance.Spec.Credentials.SecretName,
-		Namespace: r.OperatorNamespace,
+// instanceTransport makes the client reuse the connections of every other
+// client of the instance at baseURL, keeping as many idle as its
+// spec.connectionPool allows. Without a registry the client gets a transport
+// of its own.
+func instanceTransport(transports *n8n.TransportRegistry, instance *n8nv1alpha1.N8nInstance, baseURL string,
+	tlsConfig *tls.Config) n8n.Option {
+	pool := instanceConnectionPool(instance)
+	if transport := transports.For(baseURL, pool, tlsConfig); transport != nil {
+		return n8n.WithTransport(transport)
 	}
-	if err := r.Get(ctx, secretKey, secret); err != nil {
-		return nil, fmt.Errorf("failed to get API key secret %q: %w", secretKey, err)
+	return func(c *n8n.Client) {
+		n8n.WithTLSConfig(tlsConfig)(c)
+		n8n.WithConnectionPool(pool)(c)
 	}
+}
 
-	key := instance.GetSecretKey()
-	apiKeyBytes, ok := secret.Data[key]
-	if !ok {
-		return nil, fmt.Errorf("secret %q does not contain key %q", secretKey, key)
+// instanceConnectionPool returns the instance's spec.connectionPool, with zero
+// values for the defaults
+func instanceConnectionPool(instance *n8nv1alpha1.N8nInstance) n8n.ConnectionPool {
+	spec := instance.Spec.ConnectionPool
+	if spec == nil {
+		return n8n.ConnectionPool{}
 	}
+	pool := n8n.ConnectionPool{
+		MaxIdleConns:        int(spec.MaxIdleConns),
+		MaxIdleConnsPerHost: int(spec.MaxIdleConnsPerHost),
+	}
+	if spec.IdleConnTimeout != nil {
+		pool.IdleConnTimeout = spec.IdleConnTimeout.Duration
+	}
+	return pool
+}
 
-	return n8n.NewClient(baseURL, string(apiKeyBytes)), nil
+// instanceUnavailableError reports that the referenced N8nInstance is missing or
+// not Ready. reason is the condition reason to surface on the dependent resource.
+type instanceUnavailableError struct {
+	reason  string
+	message string
 }
 
-// reconcileWorkflow syncs the workflow to n8n
-func (r *N8nWorkflowReconciler) reconcileWorkflow(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, n8nClient *n8n.Client) (ctrl.Result, error) {
+func (e *instanceUnavailableError) Error() string {
+	return e.message
+}
+
+// clientErrorReason returns the condition reason and message for an error from
+// instanceClient
+func clientErrorReason(err error) (string, string) {
+	if unavailable, ok := err.(*instanceUnavailableError); ok {
+		return unavailable.reason, unavailable.message
+	}
+	return n8nv1alpha1.ReasonAPIError, fmt.Sprintf("Failed to create n8n client: %v", err)
+}
+
+// reconcileWorkflow syncs the workflow to n8n, filling in timings for each phase
+// it runs. The timings are only published to status on a successful pass.
+func (r *N8nWorkflowReconciler) reconcileWorkflow(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow,
+	instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client,
+	timings *n8nv1alpha1.ReconcileTimings, reconcileStart time.Time) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	// Check for force-sync annotation
-	forceSync := false
-	if workflow.Annotations != nil {
-		if _, exists := workflow.Annotations[forceSyncAnnotation]; exists {
-			forceSync = true
-			log.Info("Force sync annotation detected, will sync regardless of policy")
+	// Get effective sync policy (default to Always), falling back when the
+	// instance doesn't allow the requested one
+	syncPolicy, allowed := instance.GetSyncPolicy(workflow.Spec.SyncPolicy)
+
+	// A force-sync token not yet recorded in status forces exactly one update.
+	// Manual pauses all writes, so the token waits until the policy changes.
+	forceSyncToken := workflow.Annotations[forceSyncAnnotation]
+	forceSync := forceSyncToken != "" && syncPolicy != n8nv1alpha1.SyncPolicyManual &&
+		(workflow.Status.LastForceSync == nil || workflow.Status.LastForceSync.Token != forceSyncToken)
+	if forceSync {
+		log.Info("Force sync annotation detected, will sync regardless of policy", "token", forceSyncToken)
+	}
+	if allowed {
+		meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypePolicyNotAllowed)
+	} else {
+		message := fmt.Sprintf("N8nInstance %q does not allow syncPolicy %s; using %s",
+			instance.Name, workflow.Spec.SyncPolicy, syncPolicy)
+		if !meta.IsStatusConditionTrue(workflow.Status.Conditions, n8nv1alpha1.ConditionTypePolicyNotAllowed) {
+			log.Info("Sync policy not allowed, falling back", "requested", workflow.Spec.SyncPolicy, "policy", syncPolicy)
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonPolicyNotAllowed, message)
 		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypePolicyNotAllowed, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonPolicyNotAllowed, message)
 	}
 
-	// Get effective sync policy (default to Always)
-	syncPolicy := workflow.Spec.SyncPolicy
-	if syncPolicy == "" {
-		syncPolicy = n8nv1alpha1.SyncPolicyAlways
+	// Refuse to touch protected workflow names unless explicitly allowed, so a
+	// mistyped name can't adopt and overwrite a critical workflow
+	protected, err := instance.IsProtectedWorkflowName(workflow.Spec.Workflow.Name)
+	if err != nil {
+		log.Error(err, "Failed to check protected workflow names")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonProtectedName, err.Error())
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
 	}
-
-	// Handle Manual sync policy - skip all sync operations unless force-sync is set
-	if syncPolicy == n8nv1alpha1.SyncPolicyManual && !forceSync {
-		log.V(1).Info("SyncPolicy is Manual, skipping reconciliation")
-		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionTrue,
-			"SyncPaused", "Sync is paused (syncPolicy: Manual)")
+	if protected && workflow.Annotations[allowProtectedNameAnnotation] != "true" {
+		message := fmt.Sprintf("Workflow name %q is protected by N8nInstance %q; set the %s: \"true\" annotation to manage it",
+			workflow.Spec.Workflow.Name, instance.Name, allowProtectedNameAnnotation)
+		if !meta.IsStatusConditionTrue(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeProtectedName) {
+			log.Info("Refusing to manage protected workflow name", "name", workflow.Spec.Workflow.Name)
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonProtectedName, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeProtectedName, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonProtectedName, message)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonProtectedName, message)
 		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
 			log.Error(statusErr, "Failed to update status")
 		}
-		return ctrl.Result{RequeueAfter: defaultRequeueInterval}, nil
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
 	}
+	meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeProtectedName)
 
-	// Calculate spec hash to detect CRD changes
-	currentSpecHash := r.calculateSpecHash(workflow)
-	specChanged := workflow.Status.SpecHash != currentSpecHash
-
-	// Convert CRD workflow spec to n8n workflow
-	n8nWorkflow, err := r.convertToN8nWorkflow(workflow)
+	// Two resources syncing the same n8n workflow would overwrite each other on
+	// every reconcile, so only the winner of the conflict is synced
+	winner, err := r.conflictWinner(ctx, workflow)
 	if err != nil {
-		log.Error(err, "Failed to convert workflow spec")
+		log.Error(err, "Failed to check for conflicting workflows")
+		return r.errorRequeue(workflow), err
+	}
+	if winner != nil {
+		message := fmt.Sprintf("N8nWorkflow %s/%s already targets workflow %q on N8nInstance %q; not syncing",
+			winner.Namespace, winner.Name, workflow.Spec.Workflow.Name, instance.Name)
+		if !meta.IsStatusConditionTrue(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeConflict) {
+			log.Info("Refusing to sync a workflow targeted by another resource", "winner", client.ObjectKeyFromObject(winner))
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonDuplicateTarget, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeConflict, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonDuplicateTarget, message)
 		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-			n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to convert workflow: %v", err))
+			n8nv1alpha1.ReasonDuplicateTarget, message)
 		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
 			log.Error(statusErr, "Failed to update status")
 		}
-		return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
 	}
+	meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeConflict)
 
-	var existingWorkflow *n8n.Workflow
+	// A new create-only-override token allows exactly one update under CreateOnly
+	overrideToken := workflow.Annotations[createOnlyOverrideAnnotation]
+	pendingOverride := syncPolicy == n8nv1alpha1.SyncPolicyCreateOnly && overrideToken != "" &&
+		(workflow.Status.LastOverride == nil || workflow.Status.LastOverride.Token != overrideToken)
 
-	// Check if workflow already exists in n8n
-	if workflow.Status.WorkflowID != "" {
-		// Try to get by ID first
-		existingWorkflow, err = n8nClient.GetWorkflow(ctx, workflow.Status.WorkflowID)
-		if err != nil {
-			log.V(1).Info("Failed to get workflow by ID, will search by name", "id", workflow.Status.WorkflowID, "error", err)
-			existingWorkflow = nil
+	// Handle Manual sync policy - skip all sync operations
+	if syncPolicy == n8nv1alpha1.SyncPolicyManual {
+		log.V(1).Info("SyncPolicy is Manual, skipping reconciliation")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionTrue,
+			"SyncPaused", "Sync is paused (syncPolicy: Manual)")
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
 		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
 	}
 
-	// If not found by ID, search by name
-	if existingWorkflow == nil {
-		existingWorkflow, err = n8nClient.GetWorkflowByName(ctx, workflow.Spec.Workflow.Name)
+	// Render the template the workflow is stamped out from, waiting until it
+	// exists. The rendered spec is only held in memory for this reconcile.
+	if workflow.Spec.TemplateRef != nil {
+		template, err := r.loadTemplate(ctx, workflow)
 		if err != nil {
-			log.Error(err, "Failed to search workflow by name")
+			log.Info("Workflow template unavailable", "reason", err.Error())
+			r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+				n8nv1alpha1.ReasonTemplateUnavailable, err.Error())
+			if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+				log.Error(statusErr, "Failed to update status")
+			}
+			return r.errorRequeue(workflow), nil
+		}
+		if err := applyTemplate(workflow, template); err != nil {
+			message := fmt.Sprintf("Failed to render workflow template: %v", err)
+			if ready := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReady); ready == nil ||
+				ready.Reason != n8nv1alpha1.ReasonTemplateParametersInvalid {
+				log.Info("Refusing to sync workflow with invalid template parameters", "reason", err.Error())
+				r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonTemplateParametersInvalid, message)
+			}
 			r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-				n8nv1alpha1.ReasonAPIError, fmt.Sprintf("Failed to search workflow: %v", err))
+				n8nv1alpha1.ReasonTemplateParametersInvalid, message)
 			if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
 				log.Error(statusErr, "Failed to update status")
 			}
-			return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+			return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
 		}
 	}
 
-	if existingWorkflow == nil {
-		// Create new workflow
-		log.Info("Creating new workflow in n8n", "name", workflow.Spec.Workflow.Name)
-		created, err := n8nClient.CreateWorkflow(ctx, n8nWorkflow)
+	// Build on the base workflow export, waiting until its ConfigMap exists.
+	// Like a template, the base is only copied into the in-memory spec.
+	if workflow.Spec.Workflow.BaseFrom != nil {
+		base, err := r.loadBaseWorkflow(ctx, workflow)
 		if err != nil {
-			log.Error(err, "Failed to create workflow")
+			log.Info("Base workflow unavailable", "reason", err.Error())
 			r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-				n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to create workflow: %v", err))
-			r.Recorder.Event(workflow, corev1.EventTypeWarning, "CreateFailed", err.Error())
+				n8nv1alpha1.ReasonBaseWorkflowUnavailable, err.Error())
 			if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
 				log.Error(statusErr, "Failed to update status")
 			}
-			return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+			return r.errorRequeue(workflow), nil
 		}
-		workflow.Status.WorkflowID = created.ID
-		workflow.Status.SpecHash = currentSpecHash
-		r.Recorder.Event(workflow, corev1.EventTypeNormal, "Created", fmt.Sprintf("Workflow created with ID %s", created.ID))
-		existingWorkflow = created
+		applyBaseWorkflow(workflow, base)
+	}
+
+	// Resolve sub-workflow references, waiting until every target exists in n8n
+	resolvedSubWorkflows, pendingMessage, err := r.resolveSubWorkflowRefs(ctx, workflow)
+	if err != nil {
+		log.Error(err, "Failed to resolve sub-workflow references")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to resolve sub-workflows: %v", err))
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), err
+	}
+	if pendingMessage != "" {
+		log.Info("Waiting for sub-workflow", "reason", pendingMessage)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonSubWorkflowPending, pendingMessage)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), nil
+	}
+
+	// Resolve the webhook credential managed by an N8nCredential, waiting until it is Ready
+	pendingMessage, err = r.resolveWebhookCredential(ctx, workflow)
+	if err != nil {
+		log.Error(err, "Failed to resolve webhook credential")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to resolve webhook credential: %v", err))
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), err
+	}
+	if pendingMessage != "" {
+		log.Info("Waiting for webhook credential", "reason", pendingMessage)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonCredentialPending, pendingMessage)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), nil
+	}
+
+	// Load pinData kept outside the resource, waiting until the source exists
+	pinData, pinDataRaw, err := r.loadPinData(ctx, workflow)
+	if err != nil {
+		log.Info("Pin data source unavailable", "reason", err.Error())
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonPinDataUnavailable, err.Error())
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), nil
+	}
+
+	// Resolve variables, waiting until their ConfigMaps and Secrets exist
+	vars, err := r.loadVariables(ctx, workflow)
+	if err != nil {
+		log.Info("Variables unavailable", "reason", err.Error())
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonVariablesUnavailable, err.Error())
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), nil
+	}
+	var variableValues map[string]string
+	if vars != nil {
+		variableValues = vars.values
+	}
+
+	// Load the node type policy; until it was loaded once nothing is synced unchecked
+	nodeTypePolicy, err := r.NodeTypePolicy.Policy(ctx)
+	if err != nil {
+		log.Info("Node type policy unavailable", "reason", err.Error())
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonNodeTypePolicyUnavailable, err.Error())
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), nil
+	}
+
+	// Calculate spec hash to detect CRD changes
+	currentSpecHash := subWorkflowHash(r.calculateSpecHash(workflow), resolvedSubWorkflows)
+	currentSpecHash = pinDataHash(currentSpecHash, pinDataRaw)
+	currentSpecHash = variablesHash(currentSpecHash, vars)
+
+	// Convert CRD workflow spec to n8n workflow
+	phaseStart := time.Now()
+	n8nWorkflow, err := r.convertWithVariables(workflow, variableValues)
+	if err == nil {
+		err = rewriteSubWorkflowIDs(n8nWorkflow, resolvedSubWorkflows)
+	}
+	if err == nil && pinData != nil {
+		n8nWorkflow.PinData = pinData
+		if err = validatePinData(n8nWorkflow); err != nil {
+			err = fmt.Errorf("pinDataFrom: %w", err)
+		}
+	}
+	if err == nil {
+		err = checkBlockedNodeTypes(n8nWorkflow, instance.GetBlockedNodeTypes(r.BlockedNodeTypes))
+	}
+	if err == nil {
+		err = nodeTypePolicy.Check(n8nWorkflow)
+	}
+	var clampedTimeout *ExecutionTimeoutError
+	if err == nil {
+		clampedTimeout, err = enforceExecutionTimeout(n8nWorkflow, instance.GetExecutionTimeoutPolicy(r.ExecutionTimeoutPolicy))
+	}
+	timings.ConvertMillis = elapsedMillis(phaseStart)
+	if _, ok := err.(*BlockedNodeTypeError); ok {
+		message := fmt.Sprintf("Workflow uses node types blocked by the security policy: %v", err)
+		if !meta.IsStatusConditionTrue(workflow.Status.Conditions, n8nv1alpha1.ConditionTypePolicyViolation) {
+			log.Info("Refusing to sync workflow with blocked node types", "reason", err.Error())
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonBlockedNodeType, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypePolicyViolation, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonBlockedNodeType, message)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonBlockedNodeType, message)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	}
+	if _, ok := err.(*ExecutionTimeoutError); ok {
+		message := fmt.Sprintf("Workflow settings break the execution timeout policy: %v", err)
+		if condition := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypePolicyViolation); condition == nil ||
+			condition.Reason != n8nv1alpha1.ReasonExecutionTimeoutExceeded {
+			log.Info("Refusing to sync workflow exceeding the execution timeout ceiling", "reason", err.Error())
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonExecutionTimeoutExceeded, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypePolicyViolation, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonExecutionTimeoutExceeded, message)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonExecutionTimeoutExceeded, message)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	}
+	if _, ok := err.(*NodeTypeError); ok {
+		message := fmt.Sprintf("Workflow uses node types not permitted by the node type policy: %v", err)
+		log.Info("Workflow uses node types not permitted", "reason", err.Error())
+		if ready := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReady); ready == nil ||
+			ready.Reason != n8nv1alpha1.ReasonNodeTypeNotAllowed {
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonNodeTypeNotAllowed, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonNodeTypeNotAllowed, message)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	}
+	if _, ok := err.(*PatchError); ok {
+		message := fmt.Sprintf("Workflow patches don't apply: %v", err)
+		if ready := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReady); ready == nil ||
+			ready.Reason != n8nv1alpha1.ReasonPatchFailed {
+			log.Info("Refusing to sync workflow with a failing patch", "reason", err.Error())
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonPatchFailed, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonPatchFailed, message)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	}
+	if err != nil {
+		log.Error(err, "Failed to convert workflow spec")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to convert workflow: %v", err))
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), err
+	}
+	meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypePolicyViolation)
+	if clampedTimeout != nil {
+		message := fmt.Sprintf("Lowered settings.executionTimeout to %gs: %v",
+			n8nWorkflow.Settings[executionTimeoutSetting], clampedTimeout)
+		if !meta.IsStatusConditionTrue(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeSettingsAdjusted) {
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonExecutionTimeoutClamped, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeSettingsAdjusted, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonExecutionTimeoutClamped, message)
+		currentSpecHash = executionTimeoutHash(currentSpecHash, clampedTimeout)
 	} else {
-		// Workflow exists - check sync policy before updating
-		workflow.Status.WorkflowID = existingWorkflow.ID
+		meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeSettingsAdjusted)
+	}
 
-		if syncPolicy == n8nv1alpha1.SyncPolicyCreateOnly && !forceSync {
-			// CreateOnly: Don't update, just track the workflow
-			log.V(1).Info("SyncPolicy is CreateOnly, skipping update", "id", existingWorkflow.ID)
-			workflow.Status.SpecHash = currentSpecHash
-		} else {
-			// Always (or force-sync): Update only if spec changed or forceSync is set
-			if specChanged || forceSync {
-				if forceSync {
-					log.Info("Force sync requested, updating workflow in n8n", "id", existingWorkflow.ID, "name", workflow.Spec.Workflow.Name)
-				} else {
-					log.Info("Spec changed, updating workflow in n8n", "id", existingWorkflow.ID, "name", workflow.Spec.Workflow.Name)
-				}
-				updated, err := n8nClient.UpdateWorkflow(ctx, existingWorkflow.ID, n8nWorkflow)
-				if err != nil {
-					log.Error(err, "Failed to update workflow")
-					r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-						n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to update workflow: %v", err))
-					r.Recorder.Event(workflow, corev1.EventTypeWarning, "UpdateFailed", err.Error())
-					if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
-						log.Error(statusErr, "Failed to update status")
-					}
-					return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
-				}
-				if forceSync {
-					r.Recorder.Event(workflow, corev1.EventTypeNormal, "ForceSynced", "Workflow force-synced successfully")
-				} else {
-					r.Recorder.Event(workflow, corev1.EventTypeNormal, "Updated", "Workflow updated successfully")
+	// n8n accepts any connections, so a broken graph would only show up as a
+	// workflow that never runs. Unreachable nodes are reported but still synced.
+	graph := analyzeGraph(n8nWorkflow)
+	switch {
+	case graph.Blocking():
+		message := "Workflow connections are invalid: " + graph.String()
+		if condition := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeGraphInvalid); condition == nil ||
+			condition.Reason != n8nv1alpha1.ReasonGraphInvalid {
+			log.Info("Refusing to sync workflow with invalid connections", "reason", graph.String())
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonGraphInvalid, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeGraphInvalid, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonGraphInvalid, message)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonGraphInvalid, message)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	case !graph.Empty():
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeGraphInvalid, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonUnreachableNodes, "Workflow nodes will never run: "+graph.String())
+	default:
+		meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeGraphInvalid)
+	}
+
+	// Raise node typeVersions to the configured targets, never lowering them
+	upgraded, refused := upgradeNodeTypeVersions(n8nWorkflow, r.NodeTypeVersions)
+	currentSpecHash = nodeUpgradeHash(currentSpecHash, upgraded)
+	specChanged := workflow.Status.SpecHash != currentSpecHash
+	if specChanged && len(upgraded) > 0 {
+		recordChange(r.Recorder, workflow, "NodesUpgraded",
+			fmt.Sprintf("Upgraded node typeVersions: %s", strings.Join(upgraded, ", ")))
+	}
+	if specChanged && len(refused) > 0 {
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, "DowngradeRefused",
+			fmt.Sprintf("Kept node typeVersions above the configured target: %s", strings.Join(refused, ", ")))
+	}
+
+	// The instance's deactivateSelector overrides spec.active. Invalid selectors
+	// keep the instance from becoming Ready, so the error can't happen here.
+	heldInactive, _ := instance.HoldsInactive(workflow.Labels)
+	if heldInactive {
+		n8nWorkflow.Active = false
+	}
+
+	// Mark the workflow so lookups can tell it apart from other operators' workflows
+	managedBy := r.ManagedBy.orDefault()
+	managedBy.apply(n8nWorkflow)
+
+	// Sync under another name when opted in and the name belongs to someone else
+	previousName := workflow.Status.EffectiveName
+	effectiveName, err := effectiveWorkflowName(ctx, n8nClient, workflow, n8nWorkflow.Name)
+	if err != nil {
+		log.Error(err, "Failed to choose a unique workflow name")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to choose a unique workflow name: %v", err))
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), err
+	}
+	if effectiveName != n8nWorkflow.Name && effectiveName != previousName {
+		log.Info("Workflow name is taken in n8n, using a unique name", "name", n8nWorkflow.Name, "effectiveName", effectiveName)
+		recordChange(r.Recorder, workflow, "NameUniquified",
+			fmt.Sprintf("Workflow name %q is taken in n8n; syncing as %q", n8nWorkflow.Name, effectiveName))
+	}
+	n8nWorkflow.Name = effectiveName
+
+	// Report only compares against n8n, so it never writes unless force-synced
+	if syncPolicy == n8nv1alpha1.SyncPolicyReport && !forceSync {
+		return r.reportDrift(ctx, workflow, instance, n8nClient, n8nWorkflow)
+	}
+
+	// Check the node count before n8n rejects the workflow with a cryptic error
+	if maxNodes := instance.GetMaxNodesPerWorkflow(); maxNodes > 0 && len(n8nWorkflow.Nodes) > maxNodes {
+		message := fmt.Sprintf("Workflow has %d nodes, exceeding the limit of %d set on N8nInstance %q",
+			len(n8nWorkflow.Nodes), maxNodes, instance.Name)
+		log.Info("Workflow exceeds node limit", "nodes", len(n8nWorkflow.Nodes), "limit", maxNodes)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonExceedsNodeLimit, message)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonExceedsNodeLimit, message)
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	}
+
+	// Flag node parameters that look like hard-coded secrets
+	if mode := instance.GetSecretDetection(); mode != n8nv1alpha1.SecretDetectionDisabled {
+		if findings := detectSecrets(n8nWorkflow); len(findings) > 0 {
+			message := summarizeSecretFindings(findings)
+			if mode == n8nv1alpha1.SecretDetectionBlock {
+				log.Info("Refusing to sync workflow with possible hard-coded secrets", "findings", len(findings))
+				r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+					n8nv1alpha1.ReasonSecretDetected, message)
+				if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+					log.Error(statusErr, "Failed to update status")
 				}
-				workflow.Status.SpecHash = currentSpecHash
-				existingWorkflow = updated
-			} else {
-				log.V(1).Info("No spec changes, skipping update", "id", existingWorkflow.ID)
+				r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonSecretDetected, message)
+				return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+			}
+			// Only warn once per spec change rather than on every resync
+			if specChanged {
+				r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonSecretDetected, message)
 			}
 		}
 	}
 
-	// Handle activation/deactivation
-	if workflow.Spec.Active && !existingWorkflow.Active {
-		log.Info("Activating workflow", "id", workflow.Status.WorkflowID)
-		activated, err := n8nClient.ActivateWorkflow(ctx, workflow.Status.WorkflowID)
-		if err != nil {
-			log.Error(err, "Failed to activate workflow")
-			r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-				n8nv1alpha1.ReasonActivationError, fmt.Sprintf("Failed to activate workflow: %v", err))
-			r.Recorder.Event(workflow, corev1.EventTypeWarning, "ActivationFailed", err.Error())
-			if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
-				log.Error(statusErr, "Failed to update status")
+	// Push the spec over an existing workflow unless CreateOnly leaves it alone
+	update := (syncPolicy != n8nv1alpha1.SyncPolicyCreateOnly && specChanged) || forceSync || pendingOverride
+	switch {
+	case pendingOverride:
+		log.Info("Create-only override requested", "token", overrideToken)
+	case forceSync:
+		log.Info("Force sync requested", "name", workflow.Spec.Workflow.Name)
+	}
+
+	// Outside the sync window an existing workflow is left as it is until the
+	// window opens. Creating it and changing its activation go ahead.
+	overwriteDrift := syncPolicy == n8nv1alpha1.SyncPolicyAlways
+	windowClosed, windowChange, err := r.checkSyncWindow(workflow)
+	if err != nil {
+		message := fmt.Sprintf("Invalid spec.syncWindow: %v", err)
+		if ready := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReady); ready == nil ||
+			ready.Reason != n8nv1alpha1.ReasonInvalidSyncWindow {
+			log.Info("Refusing to sync workflow with an invalid sync window", "reason", err.Error())
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonInvalidSyncWindow, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonInvalidSyncWindow, message)
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	}
+	deferred := windowClosed && update && !forceSync && !pendingOverride
+	if windowClosed && !forceSync && !pendingOverride {
+		update, overwriteDrift = false, false
+	}
+
+	// Activation needs write access, so don't attempt it with a read-only key
+	var denyActivation error
+	if instance.Status.APIKeyScope == n8nv1alpha1.APIKeyScopeRead {
+		denyActivation = &insufficientScopeError{instance: instance.Name}
+	}
+
+	// The state last recorded tells a correction of n8n apart from a change of spec.active
+	trackedID, lastActive := workflow.Status.WorkflowID, workflow.Status.Active
+
+	// Attach the tags in the create request where the instance's release allows it
+	resolvedTags := r.inlineTags(ctx, n8nClient, workflow, instance, n8nWorkflow)
+
+	// A write the spec didn't ask for corrects drift, e.g. a workflow
+	// deactivated in the n8n UI
+	trigger := writeTriggerSpecChange
+	switch {
+	case forceSync:
+		trigger = writeTriggerForceSync + "/" + forceSyncToken
+	case pendingOverride:
+		trigger = writeTriggerOverride + "/" + overrideToken
+	}
+	stepTrigger := func(step SyncStep) string {
+		if !update && step != SyncStepCreate {
+			return writeTriggerDrift
+		}
+		return trigger
+	}
+
+	// Payload capture only records the single create or update request made
+	var capture *payloadCapture
+	result, err := SyncWorkflow(ctx, n8nClient, n8nWorkflow, SyncOptions{
+		TrackedID:          workflow.Status.WorkflowID,
+		AdoptID:            adoptID(workflow),
+		AdoptionMode:       instance.GetAdoptionMode(),
+		ManagedBy:          managedBy,
+		OwnerUID:           string(workflow.UID),
+		OwnerName:          workflow.Namespace + "/" + workflow.Name,
+		Update:             update,
+		PartialUpdate:      instance.Spec.PartialUpdates,
+		OverwriteDrift:     overwriteDrift,
+		BackupBeforeUpdate: workflow.Spec.BackupBeforeUpdate,
+		Active:             n8nWorkflow.Active,
+		DenyActivation:     denyActivation,
+		Cache:              r.WorkflowCache.For(n8nClient.BaseURL()),
+		RequestContext: func(ctx context.Context, step SyncStep) context.Context {
+			keyed := n8n.WithIdempotencyKey(ctx, idempotencyKey(workflow, currentSpecHash, stepTrigger(step), step))
+			switch step {
+			case SyncStepCreate, SyncStepUpdate:
+				ctx, capture = withPayloadCapture(keyed, workflow)
+			case SyncStepActivate, SyncStepDeactivate:
+				ctx = keyed
+			}
+			return ctx
+		},
+	})
+	if capture != nil && vars != nil {
+		capture.secretValues = vars.secretValues
+	}
+	r.savePayloadCapture(ctx, workflow, capture)
+	timings.ListMillis = result.LookupDuration.Milliseconds()
+	timings.UpdateMillis = result.WriteDuration.Milliseconds()
+	timings.ActivateMillis = result.ActivationDuration.Milliseconds()
+
+	// Record what exists in n8n even if a later step failed
+	syncErr, _ := err.(*SyncError)
+	if result.Workflow != nil {
+		workflow.Status.WorkflowID = result.Workflow.ID
+	}
+	recordSync(workflow, syncRecord(result, syncErr, time.Now()))
+	// A created workflow already has the spec, so nothing waits for the window
+	deferred = deferred && !result.Did(SyncStepCreate)
+	// Deactivation runs before the write, so its failure may have left an update
+	// undone. A deferred update keeps the old hash so it is pushed later.
+	if !deferred && (err == nil || syncErr.Step == SyncStepActivate || syncErr.Step == SyncStepReadBack ||
+		(syncErr.Step == SyncStepDeactivate && (!update || result.Did(SyncStepUpdate)))) {
+		workflow.Status.SpecHash = currentSpecHash
+	}
+	// Report the closed window along with when the pending changes go out
+	switch {
+	case !windowClosed:
+		meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeOutsideSyncWindow)
+	case deferred:
+		message := fmt.Sprintf("Sync window is closed; changes are pushed when it opens at %s",
+			windowChange.UTC().Format(time.RFC3339))
+		if condition := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeOutsideSyncWindow); condition == nil ||
+			condition.Reason != n8nv1alpha1.ReasonSyncDeferred {
+			log.Info("Deferring workflow update until the sync window opens", "opens", windowChange)
+			r.Recorder.Event(workflow, corev1.EventTypeNormal, n8nv1alpha1.ReasonSyncDeferred, message)
+		}
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeOutsideSyncWindow, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonSyncDeferred, message)
+	default:
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeOutsideSyncWindow, metav1.ConditionTrue,
+			n8nv1alpha1.ReasonSyncWindowClosed, fmt.Sprintf("Sync window is closed until %s",
+				windowChange.UTC().Format(time.RFC3339)))
+	}
+	if len(result.Drift) > 0 {
+		log.Info("Overwriting changes made in n8n", "differences", len(result.Drift))
+		recordDrift(workflow)
+		workflow.Status.DriftDetails = capDrift(result.Drift)
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonDriftDetected,
+			fmt.Sprintf("Overwriting changes made in n8n: %s", strings.Join(workflow.Status.DriftDetails, "; ")))
+	}
+	// The backup is kept even if the update it preceded failed
+	if result.Backup != nil {
+		workflow.Status.BackupWorkflowID = result.Backup.ID
+		recordChange(r.Recorder, workflow, "BackedUp",
+			fmt.Sprintf("Workflow copied to %q with ID %s before updating it", result.Backup.Name, result.Backup.ID))
+	}
+	if result.Did(SyncStepCreate) {
+		recordChange(r.Recorder, workflow, "Created", fmt.Sprintf("Workflow created with ID %s", result.Workflow.ID))
+	}
+	if result.Did(SyncStepUpdate) {
+		switch {
+		case pendingOverride:
+			workflow.Status.LastOverride = &n8nv1alpha1.OverrideRecord{
+				Token:    overrideToken,
+				Time:     metav1.Now(),
+				SpecHash: currentSpecHash,
+			}
+			recordChange(r.Recorder, workflow, "OverrideUpdated",
+				fmt.Sprintf("Workflow updated under CreateOnly by override %q", overrideToken))
+		case forceSync:
+			recordChange(r.Recorder, workflow, "ForceSynced",
+				fmt.Sprintf("Workflow force-synced by %s=%q", forceSyncAnnotation, forceSyncToken))
+		default:
+			recordChange(r.Recorder, workflow, "Updated", "Workflow updated successfully")
+		}
+	}
+	// A create pushes the spec just as well, so it also uses up the token
+	if forceSync && (result.Did(SyncStepUpdate) || result.Did(SyncStepCreate)) {
+		workflow.Status.LastForceSync = &n8nv1alpha1.OverrideRecord{
+			Token:    forceSyncToken,
+			Time:     metav1.Now(),
+			SpecHash: currentSpecHash,
+		}
+	}
+	if result.Did(SyncStepActivate) {
+		recordChange(r.Recorder, workflow, "Activated", "Workflow activated successfully")
+	}
+	if result.Did(SyncStepDeactivate) {
+		if heldInactive {
+			recordChange(r.Recorder, workflow, "Deactivated",
+				fmt.Sprintf("Workflow deactivated by the deactivateSelector of N8nInstance %q", instance.Name))
+		} else {
+			recordChange(r.Recorder, workflow, "Deactivated", "Workflow deactivated successfully")
+		}
+	}
+	if err != nil {
+		return r.handleSyncError(ctx, workflow, syncErr)
+	}
+	existingWorkflow := result.Workflow
+	mutated := len(result.Actions) > 0
+	workflow.Status.Active = existingWorkflow.Active
+	workflow.Status.Credentials = workflowCredentials(existingWorkflow)
+
+	// spec.active is authoritative, so a state changed in n8n is reported once corrected
+	if activationDrifted(trackedID, lastActive, n8nWorkflow.Active, result) {
+		reason, message := n8nv1alpha1.ReasonActivated, "Workflow was inactive in n8n and has been activated to match spec.active"
+		if !n8nWorkflow.Active {
+			reason, message = n8nv1alpha1.ReasonDeactivated, "Workflow was active in n8n and has been deactivated to match spec.active"
+		}
+		log.Info("Corrected workflow activation changed in n8n", "active", n8nWorkflow.Active)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeActivationDrift, metav1.ConditionTrue, reason, message)
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonActivationDrift, message)
+	} else if meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeActivationDrift) != nil {
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeActivationDrift, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonInSync, "Workflow activation in n8n matches spec.active")
+	}
+
+	// Attach the declared tags, creating any that don't exist in n8n yet
+	tagsChanged, err := r.syncTags(ctx, n8nClient, workflow, existingWorkflow, resolvedTags)
+	if err != nil {
+		if n8n.IsServerReadOnly(err) {
+			return r.handleServerReadOnly(ctx, workflow, err)
+		}
+		log.Error(err, "Failed to sync workflow tags")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to sync tags: %v", err))
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, "TagSyncFailed", err.Error())
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
+		}
+		return r.errorRequeue(workflow), err
+	}
+	if tagsChanged {
+		mutated = true
+		recordChange(r.Recorder, workflow, "TagsUpdated",
+			fmt.Sprintf("Workflow tags set to [%s]", strings.Join(desiredTags(workflow), ", ")))
+	}
+
+	// Move the workflow into its project; editions without projects won't
+	// change on retry, so wait for the next resync
+	transferred, err := r.syncProject(ctx, n8nClient, workflow, existingWorkflow)
+	if err != nil {
+		if n8n.IsServerReadOnly(err) {
+			return r.handleServerReadOnly(ctx, workflow, err)
+		}
+		if n8n.IsProjectsUnavailable(err) {
+			message := fmt.Sprintf("Cannot transfer workflow to project %q; projects may not be available on this n8n edition: %v",
+				workflow.Spec.ProjectID, err)
+			if ready := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeReady); ready == nil ||
+				ready.Reason != n8nv1alpha1.ReasonProjectsUnavailable {
+				log.Info("Workflow project transfer refused", "project", workflow.Spec.ProjectID, "error", err.Error())
+				r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonProjectsUnavailable, message)
 			}
-			return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
-		}
-		workflow.Status.Active = true
-		r.Recorder.Event(workflow, corev1.EventTypeNormal, "Activated", "Workflow activated successfully")
-		existingWorkflow = activated
-	} else if !workflow.Spec.Active && existingWorkflow.Active {
-		log.Info("Deactivating workflow", "id", workflow.Status.WorkflowID)
-		deactivated, err := n8nClient.DeactivateWorkflow(ctx, workflow.Status.WorkflowID)
-		if err != nil {
-			log.Error(err, "Failed to deactivate workflow")
 			r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
-				n8nv1alpha1.ReasonActivationError, fmt.Sprintf("Failed to deactivate workflow: %v", err))
-			r.Recorder.Event(workflow, corev1.EventTypeWarning, "DeactivationFailed", err.Error())
+				n8nv1alpha1.ReasonProjectsUnavailable, message)
 			if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
 				log.Error(statusErr, "Failed to update status")
 			}
-			return ctrl.Result{RequeueAfter: errorRequeueInterval}, err
+			return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+		}
+		log.Error(err, "Failed to transfer workflow to project")
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonSyncFailed, fmt.Sprintf("Failed to transfer workflow to project: %v", err))
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, "TransferFailed", err.Error())
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
 		}
-		workflow.Status.Active = false
-		r.Recorder.Event(workflow, corev1.EventTypeNormal, "Deactivated", "Workflow deactivated successfully")
-		existingWorkflow = deactivated
+		return r.errorRequeue(workflow), err
+	}
+	if transferred {
+		mutated = true
+		recordChange(r.Recorder, workflow, "Transferred",
+			fmt.Sprintf("Workflow transferred to project %s", workflow.Spec.ProjectID))
+	}
+
+	// Record where the workflow's webhook and form triggers can be called
+	workflow.Status.Webhooks = workflowWebhooks(existingWorkflow, instance.GetWebhookBaseURL())
+	workflow.Status.WebhookURL = ""
+	if len(workflow.Status.Webhooks) > 0 {
+		workflow.Status.WebhookURL = workflow.Status.Webhooks[0].ProductionURL
+	}
+	workflow.Status.NodeCount, workflow.Status.TriggerTypes = workflowNodeSummary(existingWorkflow)
+
+	// Report whether the workflow is succeeding at runtime, not just active
+	if existingWorkflow.Active {
+		r.recordLastExecution(ctx, n8nClient, workflow)
 	} else {
-		workflow.Status.Active = existingWorkflow.Active
+		meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeExecutionHealthy)
 	}
 
-	// Extract webhook URL if present
-	workflow.Status.WebhookURL = r.extractWebhookURL(existingWorkflow)
+	// A successful sync leaves nothing to report from an earlier Report policy
+	workflow.Status.DriftDetails = nil
+	meta.RemoveStatusCondition(&workflow.Status.Conditions, n8nv1alpha1.ConditionTypeDrift)
 
 	// Update status
 	now := metav1.Now()
 	workflow.Status.LastSyncTime = &now
+	if mutated {
+		workflow.Status.LastMutationTime = &now
+	}
 	workflow.Status.ObservedGeneration = workflow.Generation
+	timings.TotalMillis = elapsedMillis(reconcileStart)
+	workflow.Status.Timings = timings
 
 	r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionTrue,
 		n8nv1alpha1.ReasonSyncSucceeded, "Workflow synced successfully")
@@ -355,29 +1255,104 @@ func (r *N8nWorkflowReconciler) reconcileWorkflow(ctx context.Context, workflow
 		return ctrl.Result{}, err
 	}
 
-	// Remove force-sync annotation after successful sync
-	if forceSync {
-		log.Info("Removing force-sync annotation after successful sync")
-		// Re-fetch the workflow to get the latest version before patching
-		if err := r.Get(ctx, types.NamespacedName{Name: workflow.Name, Namespace: workflow.Namespace}, workflow); err != nil {
-			log.Error(err, "Failed to re-fetch workflow for annotation removal")
-			return ctrl.Result{Requeue: true}, nil
-		}
-		if workflow.Annotations != nil {
-			delete(workflow.Annotations, forceSyncAnnotation)
-			if err := r.Update(ctx, workflow); err != nil {
-				log.Error(err, "Failed to remove force-sync annotation")
-				// Don't fail reconciliation, annotation will be removed on next cycle
-				return ctrl.Result{Requeue: true}, nil
-			}
+	r.Heartbeat.RecordSuccess(ctx)
+	log.V(1).Info("Reconciliation complete", "workflowId", workflow.Status.WorkflowID, "active", workflow.Status.Active)
+	requeueAfter := workflow.GetReconcileInterval()
+	if untilOpen := time.Until(windowChange); windowClosed && untilOpen > 0 && untilOpen < requeueAfter {
+		requeueAfter = untilOpen
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// activationDrifted reports whether SyncWorkflow activated or deactivated a
+// workflow it was already tracking in the desired state, meaning the state was
+// changed in n8n rather than in the spec. Created and adopted workflows and
+// changes of the desired state are not drift.
+func activationDrifted(trackedID string, lastActive, desired bool, result SyncResult) bool {
+	if trackedID == "" || result.Workflow == nil || result.Workflow.ID != trackedID || result.Adopted || lastActive != desired {
+		return false
+	}
+	return result.Did(SyncStepActivate) || result.Did(SyncStepDeactivate)
+}
+
+// insufficientScopeError explains why activation was not attempted
+type insufficientScopeError struct {
+	instance string
+}
+
+func (e *insufficientScopeError) Error() string {
+	return fmt.Sprintf("N8nInstance %q has a read-only API key; changing activation requires read-write scope", e.instance)
+}
+
+// handleSyncError reports a failed SyncWorkflow step on the workflow's status
+// and events
+func (r *N8nWorkflowReconciler) handleSyncError(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, syncErr *SyncError) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	err := syncErr.Err
+
+	// A missing scope won't fix itself on retry, so wait for the next resync
+	if _, ok := err.(*insufficientScopeError); ok {
+		log.Info("Skipping activation change without write scope", "step", syncErr.Step)
+		r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+			n8nv1alpha1.ReasonInsufficientScope, err.Error())
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, n8nv1alpha1.ReasonInsufficientScope, err.Error())
+		if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+			log.Error(statusErr, "Failed to update status")
 		}
+		return ctrl.Result{RequeueAfter: workflow.GetReconcileInterval()}, nil
+	}
+	if syncErr.Step != SyncStepLookup && n8n.IsServerReadOnly(err) {
+		return r.handleServerReadOnly(ctx, workflow, err)
 	}
 
-	log.V(1).Info("Reconciliation complete", "workflowId", workflow.Status.WorkflowID, "active", workflow.Status.Active)
-	return ctrl.Result{RequeueAfter: defaultRequeueInterval}, nil
+	reason, message, event := n8nv1alpha1.ReasonSyncFailed, "", ""
+	switch syncErr.Step {
+	case SyncStepLookup:
+		reason, message = n8nv1alpha1.ReasonAPIError, "Failed to search workflow"
+	case SyncStepCreate:
+		message, event = "Failed to create workflow", "CreateFailed"
+	case SyncStepBackup:
+		message, event = "Failed to back up workflow before updating it", "BackupFailed"
+	case SyncStepUpdate:
+		message, event = "Failed to update workflow", "UpdateFailed"
+	case SyncStepActivate:
+		reason, message, event = n8nv1alpha1.ReasonActivationError, "Failed to activate workflow", "ActivationFailed"
+	case SyncStepDeactivate:
+		reason, message, event = n8nv1alpha1.ReasonActivationError, "Failed to deactivate workflow", "DeactivationFailed"
+	case SyncStepReadBack:
+		reason, message = n8nv1alpha1.ReasonAPIError, "Failed to read back workflow"
+	}
+
+	log.Error(err, message)
+	r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+		reason, fmt.Sprintf("%s: %v", message, err))
+	if event != "" {
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, event, err.Error())
+	}
+	if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+		log.Error(statusErr, "Failed to update status")
+	}
+	return r.errorRequeue(workflow), err
+}
+
+// handleServerReadOnly reports a write rejected because n8n is in maintenance or
+// read-only mode. The condition is transient, so it is retried on a longer
+// interval without a warning event and resumes once writes are accepted again.
+func (r *N8nWorkflowReconciler) handleServerReadOnly(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, err error) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	log.Info("n8n is refusing writes, will retry later", "error", err.Error(), "requeueAfter", readOnlyRequeueInterval)
+
+	r.setCondition(workflow, n8nv1alpha1.ConditionTypeReady, metav1.ConditionFalse,
+		n8nv1alpha1.ReasonServerReadOnly, fmt.Sprintf("n8n is in read-only mode, sync deferred: %v", err))
+	if statusErr := r.Status().Update(ctx, workflow); statusErr != nil {
+		log.Error(statusErr, "Failed to update status")
+	}
+	return ctrl.Result{RequeueAfter: readOnlyRequeueInterval}, nil
 }
 
-// handleDeletion handles the deletion of an N8nWorkflow
+// handleDeletion handles the deletion of an N8nWorkflow.
+// It is safe to re-run after a crash: a workflow that is already gone from n8n
+// is treated as deleted, so a stuck finalizer is released on the next reconcile.
 func (r *N8nWorkflowReconciler) handleDeletion(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, n8nClient *n8n.Client) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
@@ -385,25 +1360,29 @@ func (r *N8nWorkflowReconciler) handleDeletion(ctx context.Context, workflow *n8
 		return ctrl.Result{}, nil
 	}
 
-	log.Info("Handling deletion of N8nWorkflow")
-
-	// Delete the workflow from n8n if it exists
-	if workflow.Status.WorkflowID != "" {
-		log.Info("Deleting workflow from n8n", "id", workflow.Status.WorkflowID)
-		err := n8nClient.DeleteWorkflow(ctx, workflow.Status.WorkflowID)
-		if err != nil {
-			// Check if the workflow was already deleted (not found is acceptable)
-			if strings.Contains(err.Error(), "Not Found") || strings.Contains(err.Error(), "not found") {
-				log.Info("Workflow already deleted from n8n", "id", workflow.Status.WorkflowID)
-			} else {
-				// Log as warning but continue with finalizer removal
-				log.Info("Failed to delete workflow from n8n (continuing with cleanup)", "error", err)
-				r.Recorder.Event(workflow, corev1.EventTypeWarning, "DeleteFailed",
-					fmt.Sprintf("Failed to delete workflow from n8n: %v", err))
-			}
-		} else {
-			r.Recorder.Event(workflow, corev1.EventTypeNormal, "Deleted", "Workflow deleted from n8n")
+	policy := workflow.GetDeletionPolicy()
+	log.Info("Handling deletion of N8nWorkflow", "deletionPolicy", policy)
+
+	conflict := meta.FindStatusCondition(workflow.Status.Conditions, n8nv1alpha1.ConditionTypeConflict)
+	switch {
+	case workflow.Status.WorkflowID == "":
+	case conflict != nil && conflict.Status == metav1.ConditionTrue:
+		// A resource that lost a conflict doesn't manage the workflow, which
+		// belongs to the winner, so it must not touch it on the way out
+		log.Info("Leaving workflow managed by another resource in n8n", "id", workflow.Status.WorkflowID)
+		r.Recorder.Event(workflow, corev1.EventTypeNormal, "Orphaned",
+			fmt.Sprintf("Deletion policy %s not applied: %s", policy, conflict.Message))
+	case policy == n8nv1alpha1.DeletionPolicyOrphan:
+		log.Info("Leaving workflow in n8n", "id", workflow.Status.WorkflowID)
+		r.releaseWorkflow(ctx, workflow, n8nClient)
+		r.Recorder.Event(workflow, corev1.EventTypeNormal, "Orphaned",
+			fmt.Sprintf("Deletion policy %s: workflow %s left in n8n", policy, workflow.Status.WorkflowID))
+	case policy == n8nv1alpha1.DeletionPolicyDeactivate:
+		if r.deactivateForDeletion(ctx, workflow, n8nClient, policy) {
+			r.releaseWorkflow(ctx, workflow, n8nClient)
 		}
+	default:
+		r.deleteFromN8n(ctx, workflow, n8nClient, policy)
 	}
 
 	// Remove finalizer
@@ -417,11 +1396,126 @@ func (r *N8nWorkflowReconciler) handleDeletion(ctx context.Context, workflow *n8
 	return ctrl.Result{}, nil
 }
 
-// convertToN8nWorkflow converts the CRD spec to an n8n API workflow
+// deleteFromN8n deletes the workflow from n8n for the Delete deletion policy.
+// An active workflow is deactivated first so its triggers are torn down before
+// the workflow disappears, instead of a schedule firing once more during the
+// delete. Failures are reported in an event but don't hold the finalizer.
+func (r *N8nWorkflowReconciler) deleteFromN8n(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, n8nClient *n8n.Client, policy n8nv1alpha1.DeletionPolicy) {
+	log := logf.FromContext(ctx)
+
+	if workflow.Status.Active {
+		log.Info("Deactivating workflow before deleting it", "id", workflow.Status.WorkflowID)
+		if _, err := n8nClient.DeactivateWorkflow(ctx, workflow.Status.WorkflowID); err != nil && !n8n.IsNotFound(err) {
+			log.Info("Failed to deactivate workflow before deleting it (continuing with delete)", "error", err)
+		}
+	}
+
+	log.Info("Deleting workflow from n8n", "id", workflow.Status.WorkflowID)
+	err := n8nClient.DeleteWorkflow(ctx, workflow.Status.WorkflowID)
+	if err == nil || n8n.IsNotFound(err) {
+		r.WorkflowCache.For(n8nClient.BaseURL()).Forget(workflow.Status.WorkflowID)
+	}
+	if err != nil {
+		// Check if the workflow was already deleted (not found is acceptable)
+		if n8n.IsNotFound(err) {
+			log.Info("Workflow already deleted from n8n", "id", workflow.Status.WorkflowID)
+			r.Recorder.Event(workflow, corev1.EventTypeNormal, "AlreadyDeleted",
+				"Workflow no longer exists in n8n, releasing finalizer")
+		} else {
+			// Log as warning but continue with finalizer removal
+			log.Info("Failed to delete workflow from n8n (continuing with cleanup)", "error", err)
+			r.Recorder.Event(workflow, corev1.EventTypeWarning, "DeleteFailed",
+				fmt.Sprintf("Deletion policy %s: failed to delete workflow from n8n: %v", policy, err))
+		}
+		return
+	}
+	recordChange(r.Recorder, workflow, "Deleted",
+		fmt.Sprintf("Deletion policy %s: workflow deleted from n8n", policy))
+	r.recordDeletionMutation(ctx, workflow)
+}
+
+// deactivateForDeletion deactivates the workflow in n8n for the Deactivate
+// deletion policy, leaving it there, and reports whether the workflow is still
+// there. Failures are reported in an event but don't hold the finalizer.
+func (r *N8nWorkflowReconciler) deactivateForDeletion(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, n8nClient *n8n.Client, policy n8nv1alpha1.DeletionPolicy) bool {
+	log := logf.FromContext(ctx)
+
+	log.Info("Deactivating workflow in n8n", "id", workflow.Status.WorkflowID)
+	if _, err := n8nClient.DeactivateWorkflow(ctx, workflow.Status.WorkflowID); err != nil {
+		if n8n.IsNotFound(err) {
+			log.Info("Workflow already deleted from n8n", "id", workflow.Status.WorkflowID)
+			r.WorkflowCache.For(n8nClient.BaseURL()).Forget(workflow.Status.WorkflowID)
+			r.Recorder.Event(workflow, corev1.EventTypeNormal, "AlreadyDeleted",
+				"Workflow no longer exists in n8n, releasing finalizer")
+			return false
+		}
+		log.Info("Failed to deactivate workflow in n8n (continuing with cleanup)", "error", err)
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, "DeactivateFailed",
+			fmt.Sprintf("Deletion policy %s: failed to deactivate workflow in n8n: %v", policy, err))
+		return true
+	}
+	recordChange(r.Recorder, workflow, "Orphaned",
+		fmt.Sprintf("Deletion policy %s: workflow %s deactivated and left in n8n", policy, workflow.Status.WorkflowID))
+	r.recordDeletionMutation(ctx, workflow)
+	return true
+}
+
+// releaseWorkflow strips the owner marker from a workflow the deletion policy
+// leaves in n8n, so the instance's orphan sweep doesn't delete it. Without a
+// client, e.g. when the instance is gone, the workflow keeps its marker.
+// Failures are reported in an event but don't hold the finalizer.
+func (r *N8nWorkflowReconciler) releaseWorkflow(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, n8nClient *n8n.Client) {
+	log := logf.FromContext(ctx)
+
+	id := workflow.Status.WorkflowID
+	if n8nClient == nil {
+		log.Info("Instance unavailable, leaving owner marker on workflow", "id", id)
+		return
+	}
+	existing, err := n8nClient.GetWorkflow(ctx, id)
+	if err == nil {
+		release(existing, workflow.Namespace+"/"+workflow.Name)
+		_, err = n8nClient.UpdateWorkflow(ctx, id, existing)
+	}
+	if err != nil {
+		if n8n.IsNotFound(err) {
+			return
+		}
+		log.Info("Failed to release workflow in n8n (continuing with cleanup)", "error", err)
+		r.Recorder.Event(workflow, corev1.EventTypeWarning, "ReleaseFailed",
+			fmt.Sprintf("Failed to remove the owner marker from workflow %s, orphan cleanup may still report it: %v", id, err))
+		return
+	}
+	r.WorkflowCache.For(n8nClient.BaseURL()).Forget(id)
+}
+
+// recordDeletionMutation records a change made to n8n during deletion so it is
+// visible while the finalizer is still held
+func (r *N8nWorkflowReconciler) recordDeletionMutation(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) {
+	now := metav1.Now()
+	workflow.Status.LastMutationTime = &now
+	if err := r.Status().Update(ctx, workflow); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to update status")
+	}
+}
+
+// convertToN8nWorkflow converts the CRD spec to an n8n API workflow. Only the
+// inline variables are substituted, and none when variablesFrom is set, since
+// resolving those needs the cluster.
 func (r *N8nWorkflowReconciler) convertToN8nWorkflow(workflow *n8nv1alpha1.N8nWorkflow) (*n8n.Workflow, error) {
+	var values map[string]string
+	if len(workflow.Spec.Workflow.VariablesFrom) == 0 {
+		values = workflow.Spec.Workflow.Variables
+	}
+	return r.convertWithVariables(workflow, values)
+}
+
+// convertWithVariables converts the CRD spec to an n8n API workflow, replacing
+// ${NAME} placeholders in the node JSON with values unless values is nil
+func (r *N8nWorkflowReconciler) convertWithVariables(workflow *n8nv1alpha1.N8nWorkflow, values map[string]string) (*n8n.Workflow, error) {
 	n8nWorkflow := &n8n.Workflow{
 		Name:   workflow.Spec.Workflow.Name,
-		Active: workflow.Spec.Active,
+		Active: r.desiredActive(workflow),
 	}
 
 	// Convert nodes
@@ -436,6 +1530,13 @@ func (r *N8nWorkflowReconciler) convertToN8nWorkflow(workflow *n8nv1alpha1.N8nWo
 		}
 	}
 
+	// Fill in environment-specific values before anything inspects the nodes
+	if values != nil {
+		if err := substituteVariables(n8nWorkflow, values); err != nil {
+			return nil, err
+		}
+	}
+
 	// Convert connections
 	if workflow.Spec.Workflow.Connections != nil && workflow.Spec.Workflow.Connections.Raw != nil {
 		var connections map[string]any
@@ -454,6 +1555,16 @@ func (r *N8nWorkflowReconciler) convertToN8nWorkflow(workflow *n8nv1alpha1.N8nWo
 		n8nWorkflow.Settings = settings
 	}
 
+	// Apply the sub-workflow caller policy
+	if err := applyCallerPolicy(n8nWorkflow, &workflow.Spec.Workflow); err != nil {
+		return nil, err
+	}
+
+	// Apply the typed webhook authentication
+	if err := applyWebhookAuth(n8nWorkflow, workflow.Spec.Workflow.WebhookAuth); err != nil {
+		return nil, err
+	}
+
 	// Convert static data
 	if workflow.Spec.Workflow.StaticData != nil && workflow.Spec.Workflow.StaticData.Raw != nil {
 		var staticData map[string]any
@@ -463,7 +1574,15 @@ func (r *N8nWorkflowReconciler) convertToN8nWorkflow(workflow *n8nv1alpha1.N8nWo
 		n8nWorkflow.StaticData = staticData
 	}
 
-	// Convert pin data
+	// Convert pin data; pinDataFrom is loaded by the reconciler
+	if source := workflow.Spec.Workflow.PinDataFrom; source != nil {
+		if workflow.Spec.Workflow.PinData != nil {
+			return nil, fmt.Errorf("pinData and pinDataFrom are mutually exclusive")
+		}
+		if (source.ConfigMapKeyRef == nil) == (source.SecretKeyRef == nil) {
+			return nil, fmt.Errorf("pinDataFrom must set exactly one of configMapKeyRef and secretKeyRef")
+		}
+	}
 	if workflow.Spec.Workflow.PinData != nil && workflow.Spec.Workflow.PinData.Raw != nil {
 		var pinData map[string]any
 		if err := json.Unmarshal(workflow.Spec.Workflow.PinData.Raw, &pinData); err != nil {
@@ -472,19 +1591,119 @@ func (r *N8nWorkflowReconciler) convertToN8nWorkflow(workflow *n8nv1alpha1.N8nWo
 		n8nWorkflow.PinData = pinData
 	}
 
+	// Apply JSON Patch overlays to the assembled workflow
+	n8nWorkflow, err := applyWorkflowPatches(n8nWorkflow, workflow.Spec.Workflow.Patches)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check pinned data against the final node names
+	if err := validatePinData(n8nWorkflow); err != nil {
+		return nil, err
+	}
 	return n8nWorkflow, nil
 }
 
+// PatchError reports a JSON Patch operation from spec.workflow.patches that
+// can't be decoded or applied, such as one whose path doesn't exist
+type PatchError struct {
+	Index int
+	Op    string
+	Path  string
+	Err   error
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("failed to apply patch %d (%s %s): %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// applyWorkflowPatches applies RFC 6902 operations to the workflow JSON one at a
+// time, so a failure names the offending operation
+func applyWorkflowPatches(n8nWorkflow *n8n.Workflow, patches []n8nv1alpha1.JSONPatchOperation) (*n8n.Workflow, error) {
+	if len(patches) == 0 {
+		return n8nWorkflow, nil
+	}
+
+	doc, err := json.Marshal(n8nWorkflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal workflow for patching: %w", err)
+	}
+
+	for i, op := range patches {
+		opJSON, err := json.Marshal([]n8nv1alpha1.JSONPatchOperation{op})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal patch %d: %w", i, err)
+		}
+		patch, err := jsonpatch.DecodePatch(opJSON)
+		if err == nil {
+			doc, err = patch.Apply(doc)
+		}
+		if err != nil {
+			return nil, &PatchError{Index: i, Op: op.Op, Path: op.Path, Err: err}
+		}
+	}
+
+	patched := &n8n.Workflow{}
+	if err := json.Unmarshal(doc, patched); err != nil {
+		return nil, fmt.Errorf("patched workflow is not a valid n8n workflow: %w", err)
+	}
+	return patched, nil
+}
+
+// applyCallerPolicy injects the typed caller policy into the workflow settings
+func applyCallerPolicy(n8nWorkflow *n8n.Workflow, spec *n8nv1alpha1.WorkflowSpec) error {
+	if spec.CallerPolicy == "" {
+		if len(spec.CallerIDs) > 0 {
+			return fmt.Errorf("callerIds requires callerPolicy %q", n8nv1alpha1.CallerPolicyWorkflowsFromAList)
+		}
+		return nil
+	}
+
+	switch spec.CallerPolicy {
+	case n8nv1alpha1.CallerPolicyAny, n8nv1alpha1.CallerPolicyNone, n8nv1alpha1.CallerPolicyWorkflowsFromSameOwner:
+		if len(spec.CallerIDs) > 0 {
+			return fmt.Errorf("callerIds is only valid with callerPolicy %q", n8nv1alpha1.CallerPolicyWorkflowsFromAList)
+		}
+	case n8nv1alpha1.CallerPolicyWorkflowsFromAList:
+		if len(spec.CallerIDs) == 0 {
+			return fmt.Errorf("callerPolicy %q requires at least one entry in callerIds", spec.CallerPolicy)
+		}
+	default:
+		return fmt.Errorf("unsupported callerPolicy %q", spec.CallerPolicy)
+	}
+
+	if n8nWorkflow.Settings == nil {
+		n8nWorkflow.Settings = map[string]any{}
+	}
+	n8nWorkflow.Settings["callerPolicy"] = string(spec.CallerPolicy)
+	if spec.CallerPolicy == n8nv1alpha1.CallerPolicyWorkflowsFromAList {
+		// n8n stores the allowlist as a comma-separated string
+		n8nWorkflow.Settings["callerIds"] = strings.Join(spec.CallerIDs, ",")
+	} else {
+		delete(n8nWorkflow.Settings, "callerIds")
+	}
+	return nil
+}
+
 // calculateSpecHash calculates a SHA256 hash of the workflow spec
 // Used to detect changes in the CRD without comparing to n8n
 func (r *N8nWorkflowReconciler) calculateSpecHash(workflow *n8nv1alpha1.N8nWorkflow) string {
+	spec := workflow.Spec.Workflow
+	if !r.NodeOrderSignificant {
+		spec.Nodes = sortedNodes(spec.Nodes)
+	}
+
 	// Create a struct with just the fields we care about for comparison
 	specData := struct {
-		Active   bool                             `json:"active"`
-		Workflow n8nv1alpha1.WorkflowSpec         `json:"workflow"`
+		Active   bool                     `json:"active"`
+		Workflow n8nv1alpha1.WorkflowSpec `json:"workflow"`
 	}{
-		Active:   workflow.Spec.Active,
-		Workflow: workflow.Spec.Workflow,
+		Active:   r.desiredActive(workflow),
+		Workflow: spec,
 	}
 
 	data, err := json.Marshal(specData)
@@ -497,30 +1716,54 @@ func (r *N8nWorkflowReconciler) calculateSpecHash(workflow *n8nv1alpha1.N8nWorkf
 	return hex.EncodeToString(hash[:])
 }
 
-// extractWebhookURL extracts the webhook URL from a workflow if it has a webhook trigger
-func (r *N8nWorkflowReconciler) extractWebhookURL(workflow *n8n.Workflow) string {
-	if workflow == nil || len(workflow.Nodes) == 0 {
-		return ""
-	}
+// desiredActive reports whether the workflow should be active in this
+// operator's environment
+func (r *N8nWorkflowReconciler) desiredActive(workflow *n8nv1alpha1.N8nWorkflow) bool {
+	return desiredActiveIn(workflow, r.Environment)
+}
 
-	for _, node := range workflow.Nodes {
-		nodeType, ok := node["type"].(string)
-		if !ok {
-			continue
-		}
-		if nodeType == "n8n-nodes-base.webhook" {
-			params, ok := node["parameters"].(map[string]any)
-			if !ok {
+// desiredActiveIn reports whether the workflow should be active in environment:
+// spec.active must be set and, when spec.activeIn is given, one of its entries
+// must match the environment name or the workflow's namespace
+func desiredActiveIn(workflow *n8nv1alpha1.N8nWorkflow, environment string) bool {
+	if !workflow.Spec.Active || len(workflow.Spec.ActiveIn) == 0 {
+		return workflow.Spec.Active
+	}
+	for _, pattern := range workflow.Spec.ActiveIn {
+		for _, name := range []string{environment, workflow.Namespace} {
+			if name == "" {
 				continue
 			}
-			path, ok := params["path"].(string)
-			if ok {
-				return "/webhook/" + path
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
 			}
 		}
 	}
+	return false
+}
 
-	return ""
+// sortedNodes returns a copy of the nodes in a canonical order, so the spec hash
+// doesn't change when nodes are only reordered
+func sortedNodes(nodes []runtime.RawExtension) []runtime.RawExtension {
+	sorted := append([]runtime.RawExtension(nil), nodes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return string(sorted[i].Raw) < string(sorted[j].Raw)
+	})
+	return sorted
+}
+
+// workflowCredentials lists the credentials referenced by the nodes of the
+// workflow in n8n for status
+func workflowCredentials(wf *n8n.Workflow) []n8nv1alpha1.WorkflowCredential {
+	refs := wf.Credentials()
+	if len(refs) == 0 {
+		return nil
+	}
+	credentials := make([]n8nv1alpha1.WorkflowCredential, len(refs))
+	for i, ref := range refs {
+		credentials[i] = n8nv1alpha1.WorkflowCredential{ID: ref.ID, Name: ref.Name, Type: ref.Type}
+	}
+	return credentials
 }
 
 // setCondition sets a condition on the workflow status
@@ -536,10 +1779,100 @@ func (r *N8nWorkflowReconciler) setCondition(workflow *n8nv1alpha1.N8nWorkflow,
 	meta.SetStatusCondition(&workflow.Status.Conditions, condition)
 }
 
+// workflowInstanceRef indexes workflows by spec.instanceRef, or by the
+// namespace/name of the default instance recorded in status
+func workflowInstanceRef(obj client.Object) []string {
+	workflow, ok := obj.(*n8nv1alpha1.N8nWorkflow)
+	if !ok || workflowInstance(workflow) == "" {
+		return nil
+	}
+	return []string{workflowInstance(workflow)}
+}
+
+// workflowsForInstance maps an N8nInstance to the workflows referencing it, so
+// they are re-reconciled when it becomes Ready, changes or is deleted. An
+// instance outside the operator namespace may be the default of the workflows
+// in its namespace that set no instanceRef, so those are included too.
+func (r *N8nWorkflowReconciler) workflowsForInstance(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := logf.FromContext(ctx)
+	var matched []n8nv1alpha1.N8nWorkflow
+	keys := []string{client.ObjectKeyFromObject(obj).String()}
+	if obj.GetNamespace() == r.OperatorNamespace {
+		keys = append(keys, obj.GetName())
+	}
+	for _, key := range keys {
+		workflows := &n8nv1alpha1.N8nWorkflowList{}
+		if err := r.List(ctx, workflows, client.MatchingFields{instanceRefIndexKey: key}); err != nil {
+			log.Error(err, "Failed to list workflows for N8nInstance", "name", obj.GetName())
+			return nil
+		}
+		matched = append(matched, workflows.Items...)
+	}
+
+	workflows := &n8nv1alpha1.N8nWorkflowList{}
+	if err := r.List(ctx, workflows, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "Failed to list workflows for N8nInstance", "name", obj.GetName())
+		return nil
+	}
+	for _, workflow := range workflows.Items {
+		if workflow.Spec.InstanceRef == "" {
+			matched = append(matched, workflow)
+		}
+	}
+
+	seen := map[client.ObjectKey]bool{}
+	requests := make([]reconcile.Request, 0, len(matched))
+	for _, workflow := range matched {
+		key := client.ObjectKeyFromObject(&workflow)
+		if !seen[key] {
+			seen[key] = true
+			requests = append(requests, reconcile.Request{NamespacedName: key})
+		}
+	}
+	return requests
+}
+
+// instanceAvailabilityChanged passes instance events that can change whether
+// dependent workflows can sync, ignoring the periodic health check status updates
+var instanceAvailabilityChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldInstance, ok := e.ObjectOld.(*n8nv1alpha1.N8nInstance)
+		if !ok {
+			return true
+		}
+		newInstance, ok := e.ObjectNew.(*n8nv1alpha1.N8nInstance)
+		if !ok {
+			return true
+		}
+		return oldInstance.Generation != newInstance.Generation ||
+			oldInstance.Labels[defaultInstanceLabel] != newInstance.Labels[defaultInstanceLabel] ||
+			oldInstance.Status.Ready != newInstance.Status.Ready ||
+			oldInstance.Status.URL != newInstance.Status.URL ||
+			!newInstance.DeletionTimestamp.IsZero()
+	},
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *N8nWorkflowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &n8nv1alpha1.N8nWorkflow{},
+		instanceRefIndexKey, workflowInstanceRef); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &n8nv1alpha1.N8nWorkflow{},
+		workflowTargetIndexKey, r.workflowTargets); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&n8nv1alpha1.N8nWorkflow{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.workflowsForPinDataSource)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.workflowsForPinDataSource)).
+		Watches(&n8nv1alpha1.N8nWorkflowTemplate{}, handler.EnqueueRequestsFromMapFunc(r.workflowsForTemplate)).
+		Watches(&n8nv1alpha1.N8nCredential{}, handler.EnqueueRequestsFromMapFunc(r.workflowsForCredential)).
+		Watches(&n8nv1alpha1.N8nInstance{}, handler.EnqueueRequestsFromMapFunc(r.workflowsForInstance),
+			builder.WithPredicates(instanceAvailabilityChanged)).
+		Watches(&n8nv1alpha1.N8nWorkflow{}, handler.EnqueueRequestsFromMapFunc(r.workflowsSharingTarget),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named("n8nworkflow").
 		Complete(r)
 }
@@ -0,0 +1,248 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+const (
+	// nodeTypePolicyAllowedKey lists the node types workflows may use. When
+	// set, every other type is rejected as unknown.
+	nodeTypePolicyAllowedKey = "allowed"
+
+	// nodeTypePolicyDeniedKey lists node types workflows may never use. Denied
+	// types are rejected even when they are also allowed.
+	nodeTypePolicyDeniedKey = "denied"
+
+	// DefaultNodeTypePolicyRefresh is how often the node type policy ConfigMap is
+	// re-read when no other interval is configured
+	DefaultNodeTypePolicyRefresh = time.Minute
+)
+
+// NodeTypePolicy restricts the node types workflows may use. Entries are globs
+// matched against the whole type, e.g. "n8n-nodes-base.*".
+type NodeTypePolicy struct {
+	// Allowed lists the permitted node types; empty permits every type
+	Allowed []string
+	// Denied lists node types that are never permitted
+	Denied []string
+}
+
+// ParseNodeTypePolicy reads the allowed and denied keys of a ConfigMap. Each
+// holds node types separated by newlines or commas; lines starting with # are
+// comments.
+func ParseNodeTypePolicy(data map[string]string) (*NodeTypePolicy, error) {
+	allowed, err := parseNodeTypeList(nodeTypePolicyAllowedKey, data[nodeTypePolicyAllowedKey])
+	if err != nil {
+		return nil, err
+	}
+	denied, err := parseNodeTypeList(nodeTypePolicyDeniedKey, data[nodeTypePolicyDeniedKey])
+	if err != nil {
+		return nil, err
+	}
+	return &NodeTypePolicy{Allowed: allowed, Denied: denied}, nil
+}
+
+// parseNodeTypeList splits a list of node type globs, rejecting malformed ones
+func parseNodeTypeList(key, value string) ([]string, error) {
+	var patterns []string
+	for _, line := range strings.Split(value, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, pattern := range strings.Split(line, ",") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern == "" {
+				continue
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid %s node type %q: %w", key, pattern, err)
+			}
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns, nil
+}
+
+// NodeTypeError lists the nodes of a workflow whose types the policy rejects.
+// Each entry reads `node "name" (type)`.
+type NodeTypeError struct {
+	// Denied are nodes whose type is on the denylist
+	Denied []string
+	// Unknown are nodes whose type is not on the allowlist, typically a typo
+	Unknown []string
+}
+
+func (e *NodeTypeError) Error() string {
+	var parts []string
+	if len(e.Denied) > 0 {
+		parts = append(parts, "denied node types: "+strings.Join(e.Denied, ", "))
+	}
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "unknown node types not on the allowlist: "+strings.Join(e.Unknown, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Check returns a *NodeTypeError naming every node of wf whose type is denied
+// or, when an allowlist is set, not allowed. A nil policy permits everything.
+func (p *NodeTypePolicy) Check(wf *n8n.Workflow) error {
+	if p == nil {
+		return nil
+	}
+	result := &NodeTypeError{}
+	for _, node := range wf.Nodes {
+		nodeType, _ := node["type"].(string)
+		entry := fmt.Sprintf("node %q (%s)", node["name"], nodeType)
+		switch {
+		case matchesAny(p.Denied, nodeType):
+			result.Denied = append(result.Denied, entry)
+		case len(p.Allowed) > 0 && !matchesAny(p.Allowed, nodeType):
+			result.Unknown = append(result.Unknown, entry)
+		}
+	}
+	if len(result.Denied) == 0 && len(result.Unknown) == 0 {
+		return nil
+	}
+	sort.Strings(result.Denied)
+	sort.Strings(result.Unknown)
+	return result
+}
+
+// matchesAny reports whether nodeType matches one of the glob patterns
+func matchesAny(patterns []string, nodeType string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, nodeType); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBlockedNodeTypes splits a comma separated list of node type globs that
+// the security policy blocks, rejecting malformed ones
+func ParseBlockedNodeTypes(value string) ([]string, error) {
+	return parseNodeTypeList("blocked", value)
+}
+
+// BlockedNodeTypeError lists the nodes of a workflow whose types the security
+// policy blocks. Each entry reads `node "name" (type)`.
+type BlockedNodeTypeError struct {
+	Nodes []string
+}
+
+func (e *BlockedNodeTypeError) Error() string {
+	return "blocked node types: " + strings.Join(e.Nodes, ", ")
+}
+
+// checkBlockedNodeTypes returns a *BlockedNodeTypeError naming every node of wf
+// whose type matches one of the blocked globs. Malformed globs are an error so
+// that a typo never lets a blocked node through.
+func checkBlockedNodeTypes(wf *n8n.Workflow, blocked []string) error {
+	for _, pattern := range blocked {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid blocked node type %q: %w", pattern, err)
+		}
+	}
+	err := (&NodeTypePolicy{Denied: blocked}).Check(wf)
+	if nodeTypeErr, ok := err.(*NodeTypeError); ok {
+		return &BlockedNodeTypeError{Nodes: nodeTypeErr.Denied}
+	}
+	return err
+}
+
+// NodeTypePolicySource loads the NodeTypePolicy from a ConfigMap, re-reading it
+// at most once per RefreshInterval so edits take effect without a restart. A
+// nil source is valid and enforces no policy.
+type NodeTypePolicySource struct {
+	Client client.Reader
+
+	// Name and Namespace identify the ConfigMap
+	Name      string
+	Namespace string
+
+	// RefreshInterval is how long a loaded policy is used before the
+	// ConfigMap is read again. Defaults to DefaultNodeTypePolicyRefresh.
+	RefreshInterval time.Duration
+
+	mu       sync.Mutex
+	policy   *NodeTypePolicy
+	loadedAt time.Time
+}
+
+// Policy returns the current policy, refreshing it when it is older than the
+// refresh interval. When a refresh fails the last loaded policy stays in force;
+// an error is only returned while no policy was ever loaded, so workflows are
+// never synced unchecked.
+func (s *NodeTypePolicySource) Policy(ctx context.Context) (*NodeTypePolicy, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	refresh := s.RefreshInterval
+	if refresh <= 0 {
+		refresh = DefaultNodeTypePolicyRefresh
+	}
+	now := time.Now()
+	if s.policy != nil && now.Sub(s.loadedAt) < refresh {
+		return s.policy, nil
+	}
+
+	policy, err := s.load(ctx)
+	if err != nil {
+		if s.policy == nil {
+			return nil, err
+		}
+		logf.FromContext(ctx).Error(err, "Failed to refresh node type policy, keeping the last one",
+			"configMap", s.Namespace+"/"+s.Name)
+		// Retry on the next refresh rather than on every reconcile
+		s.loadedAt = now
+		return s.policy, nil
+	}
+	s.policy = policy
+	s.loadedAt = now
+	return policy, nil
+}
+
+// load reads and parses the ConfigMap
+func (s *NodeTypePolicySource) load(ctx context.Context) (*NodeTypePolicy, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to read node type policy ConfigMap %s/%s: %w", s.Namespace, s.Name, err)
+	}
+	policy, err := ParseNodeTypePolicy(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("node type policy ConfigMap %s/%s: %w", s.Namespace, s.Name, err)
+	}
+	return policy, nil
+}
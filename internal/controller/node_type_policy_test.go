@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("NodeTypePolicy", func() {
+	workflowWith := func(types ...string) *n8n.Workflow {
+		wf := &n8n.Workflow{}
+		for i, nodeType := range types {
+			wf.Nodes = append(wf.Nodes, map[string]any{"name": string(rune('A' + i)), "type": nodeType})
+		}
+		return wf
+	}
+
+	It("should parse newline and comma separated lists, skipping comments", func() {
+		policy, err := ParseNodeTypePolicy(map[string]string{
+			"allowed": "# core nodes\nn8n-nodes-base.*\n@n8n/n8n-nodes-langchain.agent, @n8n/n8n-nodes-langchain.lmChat*\n",
+			"denied":  "n8n-nodes-base.executeCommand",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy.Allowed).To(Equal([]string{
+			"n8n-nodes-base.*", "@n8n/n8n-nodes-langchain.agent", "@n8n/n8n-nodes-langchain.lmChat*"}))
+		Expect(policy.Denied).To(Equal([]string{"n8n-nodes-base.executeCommand"}))
+	})
+
+	It("should reject malformed globs", func() {
+		_, err := ParseNodeTypePolicy(map[string]string{"denied": "n8n-nodes-base.[code"})
+		Expect(err).To(MatchError(ContainSubstring(`invalid denied node type "n8n-nodes-base.[code"`)))
+	})
+
+	It("should allow types on the allowlist", func() {
+		policy := &NodeTypePolicy{Allowed: []string{"n8n-nodes-base.*"}}
+		Expect(policy.Check(workflowWith("n8n-nodes-base.httpRequest", "n8n-nodes-base.set"))).To(Succeed())
+	})
+
+	It("should allow every type without an allowlist", func() {
+		policy := &NodeTypePolicy{Denied: []string{"n8n-nodes-base.executeCommand"}}
+		Expect(policy.Check(workflowWith("n8n-nodes-base.htppRequest"))).To(Succeed())
+	})
+
+	It("should reject denied types even when they are allowed", func() {
+		policy := &NodeTypePolicy{
+			Allowed: []string{"n8n-nodes-base.*"},
+			Denied:  []string{"n8n-nodes-base.executeCommand"},
+		}
+		err := policy.Check(workflowWith("n8n-nodes-base.set", "n8n-nodes-base.executeCommand"))
+		Expect(err).To(BeAssignableToTypeOf(&NodeTypeError{}))
+		Expect(err.(*NodeTypeError).Denied).To(Equal([]string{`node "B" (n8n-nodes-base.executeCommand)`}))
+		Expect(err.(*NodeTypeError).Unknown).To(BeEmpty())
+	})
+
+	It("should reject unknown types not on the allowlist", func() {
+		policy := &NodeTypePolicy{Allowed: []string{"n8n-nodes-base.httpRequest", "n8n-nodes-base.set"}}
+		err := policy.Check(workflowWith("n8n-nodes-base.htppRequest", "n8n-nodes-base.set"))
+		Expect(err).To(MatchError(`unknown node types not on the allowlist: node "A" (n8n-nodes-base.htppRequest)`))
+	})
+
+	It("should permit everything without a policy", func() {
+		var policy *NodeTypePolicy
+		Expect(policy.Check(workflowWith("n8n-nodes-base.executeCommand"))).To(Succeed())
+	})
+
+	It("should name the nodes whose types are blocked", func() {
+		blocked, err := ParseBlockedNodeTypes("n8n-nodes-base.executeCommand, n8n-nodes-base.code")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkBlockedNodeTypes(workflowWith("n8n-nodes-base.set"), blocked)).To(Succeed())
+
+		err = checkBlockedNodeTypes(workflowWith("n8n-nodes-base.set", "n8n-nodes-base.code"), blocked)
+		Expect(err).To(BeAssignableToTypeOf(&BlockedNodeTypeError{}))
+		Expect(err.(*BlockedNodeTypeError).Nodes).To(Equal([]string{`node "B" (n8n-nodes-base.code)`}))
+	})
+
+	It("should reject malformed blocked globs", func() {
+		_, err := ParseBlockedNodeTypes("n8n-nodes-base.[code")
+		Expect(err).To(MatchError(ContainSubstring(`invalid blocked node type "n8n-nodes-base.[code"`)))
+		err = checkBlockedNodeTypes(workflowWith("n8n-nodes-base.set"), []string{"n8n-nodes-base.[code"})
+		Expect(err).To(MatchError(ContainSubstring(`invalid blocked node type "n8n-nodes-base.[code"`)))
+	})
+})
+
+var _ = Describe("NodeTypePolicySource", func() {
+	ctx := context.Background()
+
+	newConfigMap := func(denied string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-types", Namespace: "operator"},
+			Data:       map[string]string{"denied": denied},
+		}
+	}
+
+	It("should enforce nothing when unset", func() {
+		var source *NodeTypePolicySource
+		policy, err := source.Policy(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(BeNil())
+	})
+
+	It("should fail until the ConfigMap can be loaded", func() {
+		source := &NodeTypePolicySource{
+			Client:    fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			Name:      "node-types",
+			Namespace: "operator",
+		}
+		_, err := source.Policy(ctx)
+		Expect(err).To(MatchError(ContainSubstring("operator/node-types")))
+	})
+
+	It("should refresh the policy once the interval has passed", func() {
+		configMap := newConfigMap("n8n-nodes-base.executeCommand")
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build()
+		source := &NodeTypePolicySource{
+			Client:          fakeClient,
+			Name:            "node-types",
+			Namespace:       "operator",
+			RefreshInterval: 50 * time.Millisecond,
+		}
+		policy, err := source.Policy(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy.Denied).To(Equal([]string{"n8n-nodes-base.executeCommand"}))
+
+		configMap.Data["denied"] = "n8n-nodes-base.code"
+		Expect(fakeClient.Update(ctx, configMap)).To(Succeed())
+
+		// The loaded policy is used until the interval passes
+		policy, _ = source.Policy(ctx)
+		Expect(policy.Denied).To(Equal([]string{"n8n-nodes-base.executeCommand"}))
+
+		Eventually(func() []string {
+			policy, _ := source.Policy(ctx)
+			return policy.Denied
+		}).Should(Equal([]string{"n8n-nodes-base.code"}))
+	})
+
+	It("should keep the last policy when a refresh fails", func() {
+		configMap := newConfigMap("n8n-nodes-base.executeCommand")
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(configMap).Build()
+		source := &NodeTypePolicySource{
+			Client:          fakeClient,
+			Name:            "node-types",
+			Namespace:       "operator",
+			RefreshInterval: time.Millisecond,
+		}
+		_, err := source.Policy(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Delete(ctx, configMap)).To(Succeed())
+		time.Sleep(5 * time.Millisecond)
+
+		policy, err := source.Policy(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy.Denied).To(Equal([]string{"n8n-nodes-base.executeCommand"}))
+	})
+})
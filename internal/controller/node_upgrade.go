@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// NodeTypeVersions maps n8n node types to the typeVersion their nodes are
+// upgraded to during conversion
+type NodeTypeVersions map[string]float64
+
+// ParseNodeTypeVersions parses a comma-separated list of type=version pairs,
+// e.g. "n8n-nodes-base.httpRequest=4.2,n8n-nodes-base.set=3.4"
+func ParseNodeTypeVersions(value string) (NodeTypeVersions, error) {
+	versions := NodeTypeVersions{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		nodeType, version, ok := strings.Cut(pair, "=")
+		if !ok || nodeType == "" {
+			return nil, fmt.Errorf("invalid node type version %q: must be type=version", pair)
+		}
+		parsed, err := strconv.ParseFloat(version, 64)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid typeVersion %q for %s", version, nodeType)
+		}
+		versions[nodeType] = parsed
+	}
+	return versions, nil
+}
+
+// upgradeNodeTypeVersions raises the typeVersion of nodes whose type has a
+// configured target. Nodes already above the target are left as authored and
+// reported as refused downgrades. Both lists describe nodes as "name (from -> to)".
+func upgradeNodeTypeVersions(wf *n8n.Workflow, targets NodeTypeVersions) (upgraded, refused []string) {
+	for _, node := range wf.Nodes {
+		nodeType, _ := node["type"].(string)
+		target, ok := targets[nodeType]
+		if !ok {
+			continue
+		}
+		current, ok := node["typeVersion"].(float64)
+		if !ok || current == target {
+			continue
+		}
+		change := fmt.Sprintf("%v (%v -> %v)", node["name"], current, target)
+		if current > target {
+			refused = append(refused, change)
+			continue
+		}
+		node["typeVersion"] = target
+		upgraded = append(upgraded, change)
+	}
+	return upgraded, refused
+}
+
+// nodeUpgradeHash folds applied typeVersion upgrades into the spec hash, so
+// raising a target pushes the upgraded workflow even though the spec is unchanged
+func nodeUpgradeHash(specHash string, upgraded []string) string {
+	if len(upgraded) == 0 {
+		return specHash
+	}
+
+	h := sha256.New()
+	h.Write([]byte(specHash))
+	for _, change := range upgraded {
+		fmt.Fprintf(h, "\x00%s", change)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseNodeTypeVersions", func() {
+	It("should parse type=version pairs", func() {
+		versions, err := ParseNodeTypeVersions("n8n-nodes-base.httpRequest=4.2, n8n-nodes-base.set=3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(Equal(NodeTypeVersions{"n8n-nodes-base.httpRequest": 4.2, "n8n-nodes-base.set": 3}))
+	})
+
+	It("should accept an empty value", func() {
+		versions, err := ParseNodeTypeVersions("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(BeEmpty())
+	})
+
+	DescribeTable("should reject malformed pairs",
+		func(value string) {
+			_, err := ParseNodeTypeVersions(value)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing version", "n8n-nodes-base.set"),
+		Entry("missing type", "=2"),
+		Entry("non-numeric version", "n8n-nodes-base.set=latest"),
+		Entry("non-positive version", "n8n-nodes-base.set=0"),
+	)
+})
@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// nodeTypeOf returns the type of node and whether it is enabled. Disabled
+// nodes are skipped by n8n at runtime and register no trigger or webhook.
+func nodeTypeOf(node map[string]any) (nodeType string, enabled bool) {
+	nodeType, _ = node["type"].(string)
+	disabled, _ := node["disabled"].(bool)
+	return nodeType, !disabled
+}
+
+// workflowNodeSummary counts the nodes of the workflow in n8n, leaving out
+// sticky notes, and lists the sorted, distinct types of its enabled trigger
+// nodes
+func workflowNodeSummary(wf *n8n.Workflow) (int32, []string) {
+	if wf == nil {
+		return 0, nil
+	}
+	var count int32
+	seen := map[string]bool{}
+	var triggers []string
+	for _, node := range wf.Nodes {
+		if isStickyNote(node) {
+			continue
+		}
+		count++
+		nodeType, enabled := nodeTypeOf(node)
+		if !enabled || !isTriggerNode(node) || seen[nodeType] {
+			continue
+		}
+		seen[nodeType] = true
+		triggers = append(triggers, nodeType)
+	}
+	sort.Strings(triggers)
+	return count, triggers
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("Workflow node summary", func() {
+	It("should count nodes and list the enabled trigger types", func() {
+		wf := &n8n.Workflow{Nodes: []map[string]any{
+			{"name": "Orders", "type": "n8n-nodes-base.webhook"},
+			{"name": "Refunds", "type": "n8n-nodes-base.webhook"},
+			{"name": "Nightly", "type": "n8n-nodes-base.scheduleTrigger"},
+			{"name": "Manual", "type": "n8n-nodes-base.manualTrigger"},
+			{"name": "Paused", "type": "n8n-nodes-base.formTrigger", "disabled": true},
+			{"name": "Set", "type": "n8n-nodes-base.set"},
+			{"name": "Note", "type": "n8n-nodes-base.stickyNote"},
+		}}
+
+		count, triggers := workflowNodeSummary(wf)
+		Expect(count).To(Equal(int32(6)))
+		Expect(triggers).To(Equal([]string{
+			"n8n-nodes-base.manualTrigger", "n8n-nodes-base.scheduleTrigger", "n8n-nodes-base.webhook",
+		}))
+	})
+
+	It("should report nothing for empty or missing workflows", func() {
+		count, triggers := workflowNodeSummary(&n8n.Workflow{Nodes: []map[string]any{
+			{"name": "Note", "type": "n8n-nodes-base.stickyNote"},
+		}})
+		Expect(count).To(BeZero())
+		Expect(triggers).To(BeNil())
+
+		count, triggers = workflowNodeSummary(nil)
+		Expect(count).To(BeZero())
+		Expect(triggers).To(BeNil())
+	})
+})
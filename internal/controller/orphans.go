@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// maxReportedOrphans bounds the orphaned workflows listed in instance status
+const maxReportedOrphans = 50
+
+// findOrphanedWorkflows returns the workflows carrying managedBy and an owner
+// UID whose N8nWorkflow is gone. A workflow is still owned while any resource
+// has its owner UID, tracks its ID or has the namespace/name it records, since
+// a resource deleted and created again takes its workflow back. Workflows
+// without an owner UID predate owner marking and can't be told apart from
+// workflows being synced, so they are never orphans, and neither are workflows
+// released by a deletion policy that leaves them in n8n.
+func findOrphanedWorkflows(workflows []n8n.Workflow, resources []n8nv1alpha1.N8nWorkflow, managedBy ManagedByMarker) []n8n.Workflow {
+	uids := make(map[string]bool, len(resources))
+	ids := make(map[string]bool, len(resources))
+	names := make(map[string]bool, len(resources))
+	for i := range resources {
+		resource := &resources[i]
+		uids[string(resource.UID)] = true
+		if resource.Status.WorkflowID != "" {
+			ids[resource.Status.WorkflowID] = true
+		}
+		names[resource.Namespace+"/"+resource.Name] = true
+	}
+
+	var orphans []n8n.Workflow
+	for _, wf := range workflows {
+		uid, _ := wf.Meta[OwnerUIDMetaKey].(string)
+		owner, _ := wf.Meta[OwnerMetaKey].(string)
+		if !managedBy.marks(&wf) || uid == "" || released(&wf) || uids[uid] || ids[wf.ID] || (owner != "" && names[owner]) {
+			continue
+		}
+		orphans = append(orphans, wf)
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].ID < orphans[j].ID })
+	return orphans
+}
+
+// reconcileOrphans finds the instance's orphaned workflows, deletes them under
+// the Delete action, and records those remaining in status. A warning is
+// emitted whenever the number of orphans remaining changes, unless none are left.
+func (r *N8nInstanceReconciler) reconcileOrphans(ctx context.Context, instance *n8nv1alpha1.N8nInstance, n8nClient *n8n.Client) error {
+	log := logf.FromContext(ctx)
+
+	resources := &n8nv1alpha1.N8nWorkflowList{}
+	if err := r.List(ctx, resources); err != nil {
+		return fmt.Errorf("failed to list workflows: %w", err)
+	}
+	workflows, err := n8nClient.ListWorkflows(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list n8n workflows: %w", err)
+	}
+	orphans := findOrphanedWorkflows(workflows, resources.Items, r.ManagedBy.orDefault())
+
+	var remaining []n8nv1alpha1.OrphanedWorkflow
+	var failures []string
+	for _, wf := range orphans {
+		owner, _ := wf.Meta[OwnerMetaKey].(string)
+		if instance.GetOrphanAction() == n8nv1alpha1.OrphanActionDelete {
+			if err := deleteOrphanedWorkflow(ctx, n8nClient, &wf); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", wf.ID, err))
+			} else {
+				log.Info("Deleted orphaned workflow", "workflowId", wf.ID, "name", wf.Name, "owner", owner)
+				recordChange(r.Recorder, instance, "OrphanDeleted",
+					fmt.Sprintf("Deleted workflow %q (%s) left behind by N8nWorkflow %s", wf.Name, wf.ID, owner))
+				continue
+			}
+		}
+		remaining = append(remaining, n8nv1alpha1.OrphanedWorkflow{ID: wf.ID, Name: wf.Name, Owner: owner})
+	}
+
+	if int32(len(remaining)) != instance.Status.OrphanedWorkflowCount && len(remaining) > 0 {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, "OrphanedWorkflows",
+			fmt.Sprintf("Found %d workflows in n8n whose N8nWorkflow no longer exists, see status.orphanedWorkflows", len(remaining)))
+	}
+	instance.Status.OrphanedWorkflowCount = int32(len(remaining))
+	if len(remaining) > maxReportedOrphans {
+		remaining = remaining[:maxReportedOrphans]
+	}
+	instance.Status.OrphanedWorkflows = remaining
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to delete orphaned workflows: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// deleteOrphanedWorkflow deletes wf from n8n, deactivating it first so its
+// triggers stop before it disappears
+func deleteOrphanedWorkflow(ctx context.Context, n8nClient *n8n.Client, wf *n8n.Workflow) error {
+	if wf.Active {
+		if _, err := n8nClient.DeactivateWorkflow(ctx, wf.ID); err != nil && !n8n.IsNotFound(err) {
+			return err
+		}
+	}
+	if err := n8nClient.DeleteWorkflow(ctx, wf.ID); err != nil && !n8n.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
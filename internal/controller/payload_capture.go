@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+const (
+	// capturePayloadAnnotation opts a workflow into recording the JSON payload of
+	// its last create or update request in a debug ConfigMap
+	capturePayloadAnnotation = "n8n.slys.dev/capture-payload"
+
+	// payloadConfigMapSuffix is appended to the workflow name to name the debug ConfigMap
+	payloadConfigMapSuffix = "-n8n-payload"
+
+	// maxCapturedPayloadBytes keeps the debug ConfigMap well under the 1MiB object limit
+	maxCapturedPayloadBytes = 256 * 1024
+)
+
+// sensitiveKeyFragments mark object keys whose string values are redacted from
+// captured payloads, matched case-insensitively
+var sensitiveKeyFragments = []string{"password", "secret", "token", "apikey", "api_key", "authorization", "privatekey"}
+
+// payloadCapture holds the last request body sent to n8n
+type payloadCapture struct {
+	method string
+	path   string
+	body   []byte
+
+	// secretValues are variable values sourced from Secrets, redacted wherever
+	// they appear in the body
+	secretValues []string
+}
+
+// withPayloadCapture returns a context that records request bodies when the
+// workflow carries the capture-payload annotation, otherwise ctx and nil
+func withPayloadCapture(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (context.Context, *payloadCapture) {
+	if workflow.Annotations[capturePayloadAnnotation] != "true" {
+		return ctx, nil
+	}
+	capture := &payloadCapture{}
+	return n8n.WithPayloadCapture(ctx, func(method, path string, body []byte) {
+		capture.method = method
+		capture.path = path
+		capture.body = body
+	}), capture
+}
+
+// savePayloadCapture writes the captured payload to the workflow's debug
+// ConfigMap. Failures are logged rather than returned, since debugging aids
+// must never block a sync.
+func (r *N8nWorkflowReconciler) savePayloadCapture(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow, capture *payloadCapture) {
+	if capture == nil || capture.body == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+
+	payload, truncated := redactPayload(capture.body, capture.secretValues)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workflow.Name + payloadConfigMapSuffix,
+			Namespace: workflow.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{
+			"payload.json": payload,
+			"request":      capture.method + " " + capture.path,
+			"capturedAt":   time.Now().UTC().Format(time.RFC3339),
+			"truncated":    strconv.FormatBool(truncated),
+		}
+		return controllerutil.SetControllerReference(workflow, configMap, r.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Failed to save captured payload", "configMap", configMap.Name)
+	}
+}
+
+// redactPayload replaces the values of sensitive keys and any of secretValues
+// in the JSON body and truncates the result to maxCapturedPayloadBytes
+func redactPayload(body []byte, secretValues []string) (string, bool) {
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if redacted, err := json.MarshalIndent(redactValue(decoded, secretValues), "", "  "); err == nil {
+			body = redacted
+		}
+	}
+	if len(body) > maxCapturedPayloadBytes {
+		return string(body[:maxCapturedPayloadBytes]), true
+	}
+	return string(body), false
+}
+
+// redactValue walks decoded JSON, replacing non-empty strings held by sensitive
+// keys and occurrences of secretValues in any string
+func redactValue(value any, secretValues []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if s, ok := child.(string); ok && s != "" && isSensitiveKey(key) {
+				v[key] = n8n.RedactedValue
+				continue
+			}
+			v[key] = redactValue(child, secretValues)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child, secretValues)
+		}
+	case string:
+		for _, secret := range secretValues {
+			v = strings.ReplaceAll(v, secret, n8n.RedactedValue)
+		}
+		return v
+	}
+	return value
+}
+
+// isSensitiveKey reports whether key names a credential-like value
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// loadPinData reads the pinData JSON referenced by spec.workflow.pinDataFrom.
+// It returns nil data when there is no reference or an optional source is
+// missing, and the raw bytes so changes to the source can be hashed.
+func (r *N8nWorkflowReconciler) loadPinData(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (map[string]any, []byte, error) {
+	source := workflow.Spec.Workflow.PinDataFrom
+	if source == nil {
+		return nil, nil, nil
+	}
+
+	var raw []byte
+	var found, optional bool
+	var what string
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		what = fmt.Sprintf("key %q of ConfigMap %q", ref.Key, ref.Name)
+		optional = ref.Optional != nil && *ref.Optional
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: ref.Name}, configMap)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("failed to get ConfigMap %q: %w", ref.Name, err)
+		}
+		if err == nil {
+			if value, ok := configMap.Data[ref.Key]; ok {
+				raw, found = []byte(value), true
+			} else {
+				raw, found = configMap.BinaryData[ref.Key]
+			}
+		}
+	case source.SecretKeyRef != nil:
+		ref := source.SecretKeyRef
+		what = fmt.Sprintf("key %q of Secret %q", ref.Key, ref.Name)
+		optional = ref.Optional != nil && *ref.Optional
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: ref.Name}, secret)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("failed to get Secret %q: %w", ref.Name, err)
+		}
+		if err == nil {
+			raw, found = secret.Data[ref.Key]
+		}
+	default:
+		// Conversion reports the missing reference
+		return nil, nil, nil
+	}
+
+	if !found {
+		if optional {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("pinDataFrom: %s not found", what)
+	}
+	var pinData map[string]any
+	if err := json.Unmarshal(raw, &pinData); err != nil {
+		return nil, nil, fmt.Errorf("pinDataFrom: %s is not a JSON object: %w", what, err)
+	}
+	return pinData, raw, nil
+}
+
+// validatePinData checks that pinned data is keyed by the names of nodes in the
+// workflow and holds an array of items for each, which n8n would otherwise
+// reject only once the update is sent
+func validatePinData(wf *n8n.Workflow) error {
+	if len(wf.PinData) == 0 {
+		return nil
+	}
+	names := nodeNames(wf)
+	var unknown []string
+	for name, items := range wf.PinData {
+		if !names[name] {
+			unknown = append(unknown, fmt.Sprintf("%q", name))
+			continue
+		}
+		if _, ok := items.([]any); !ok {
+			return fmt.Errorf("pinData for node %q must be an array of items, e.g. [{\"json\": {...}}]", name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("pinData is keyed by node name, but the workflow has no node named %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// pinDataHash folds the pinData loaded from pinDataFrom into the spec hash, so
+// editing the ConfigMap or Secret triggers an update
+func pinDataHash(specHash string, raw []byte) string {
+	if raw == nil {
+		return specHash
+	}
+
+	h := sha256.New()
+	h.Write([]byte(specHash))
+	h.Write([]byte{0})
+	h.Write(raw)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// workflowsForPinDataSource maps a ConfigMap or Secret to the workflows in its
+// namespace that load pinData, variables or their base workflow from it
+func (r *N8nWorkflowReconciler) workflowsForPinDataSource(ctx context.Context, obj client.Object) []reconcile.Request {
+	workflows := &n8nv1alpha1.N8nWorkflowList{}
+	if err := r.List(ctx, workflows, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list workflows for pinData source", "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, workflow := range workflows.Items {
+		if slices.Contains(sourceNames(&workflow, obj), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&workflow)})
+		}
+	}
+	return requests
+}
+
+// sourceNames returns the names of the ConfigMaps or Secrets, matching the kind
+// of obj, that the workflow loads pinData, variables or its base workflow from
+func sourceNames(workflow *n8nv1alpha1.N8nWorkflow, obj client.Object) []string {
+	var names []string
+	pinData := workflow.Spec.Workflow.PinDataFrom
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		if pinData != nil && pinData.ConfigMapKeyRef != nil {
+			names = append(names, pinData.ConfigMapKeyRef.Name)
+		}
+		for _, source := range workflow.Spec.Workflow.VariablesFrom {
+			if source.ConfigMapRef != nil {
+				names = append(names, source.ConfigMapRef.Name)
+			}
+		}
+		if base := workflow.Spec.Workflow.BaseFrom; base != nil && base.ConfigMapKeyRef != nil {
+			names = append(names, base.ConfigMapKeyRef.Name)
+		}
+	case *corev1.Secret:
+		if pinData != nil && pinData.SecretKeyRef != nil {
+			names = append(names, pinData.SecretKeyRef.Name)
+		}
+		for _, source := range workflow.Spec.Workflow.VariablesFrom {
+			if source.SecretRef != nil {
+				names = append(names, source.SecretRef.Name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// syncProject transfers the synced workflow to the project named by
+// spec.projectId and records the project in status. When n8n doesn't report
+// which project owns the workflow, a project already recorded in status is
+// trusted, so the transfer isn't repeated on every resync. It reports whether
+// the workflow was transferred.
+func (r *N8nWorkflowReconciler) syncProject(ctx context.Context, n8nClient *n8n.Client, workflow *n8nv1alpha1.N8nWorkflow, current *n8n.Workflow) (bool, error) {
+	desired := workflow.Spec.ProjectID
+	actual := current.ProjectID()
+	if desired == "" {
+		if actual != "" {
+			workflow.Status.ProjectID = actual
+		}
+		return false, nil
+	}
+	if actual == desired || (actual == "" && workflow.Status.ProjectID == desired) {
+		workflow.Status.ProjectID = desired
+		return false, nil
+	}
+
+	logf.FromContext(ctx).Info("Transferring workflow to project", "id", current.ID, "from", actual, "project", desired)
+	if err := n8nClient.TransferWorkflow(ctx, current.ID, desired); err != nil {
+		return false, err
+	}
+	workflow.Status.ProjectID = desired
+	return true, nil
+}
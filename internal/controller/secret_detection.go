@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+const (
+	// minSecretLength is the shortest unlabelled string checked for entropy
+	minSecretLength = 24
+
+	// minSecretEntropy is the Shannon entropy, in bits per character, above
+	// which an unlabelled string looks like a generated key. Hex strings and
+	// UUIDs stay below it.
+	minSecretEntropy = 4.2
+)
+
+// secretFinding is a node parameter that looks like a hard-coded secret
+type secretFinding struct {
+	Node   string
+	Path   string
+	Reason string
+}
+
+func (f secretFinding) String() string {
+	return fmt.Sprintf("node %q parameter %s: %s", f.Node, f.Path, f.Reason)
+}
+
+// detectSecrets scans node parameters for literal values under credential-like
+// keys and for high-entropy strings. Expressions are skipped since they resolve
+// at runtime. This is a guardrail that catches common mistakes, not a scanner.
+func detectSecrets(n8nWorkflow *n8n.Workflow) []secretFinding {
+	var findings []secretFinding
+	for _, node := range n8nWorkflow.Nodes {
+		name, _ := node["name"].(string)
+		params, _ := node["parameters"].(map[string]any)
+		findings = scanSecrets(findings, name, "parameters", "", params)
+	}
+	return findings
+}
+
+// scanSecrets walks value recursively, appending findings for node. key is the
+// name the value was stored under, or the enclosing key for array elements.
+func scanSecrets(findings []secretFinding, node, path, key string, value any) []secretFinding {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		// n8n stores header and query parameters as {name, value} pairs
+		label := key
+		if pairName, ok := v["name"].(string); ok {
+			label = pairName
+		}
+		for _, k := range keys {
+			childKey := k
+			if k == "value" {
+				childKey = label
+			}
+			findings = scanSecrets(findings, node, path+"."+k, childKey, v[k])
+		}
+	case []any:
+		for i, child := range v {
+			findings = scanSecrets(findings, node, fmt.Sprintf("%s[%d]", path, i), key, child)
+		}
+	case string:
+		if v == "" || isExpression(v) {
+			return findings
+		}
+		switch {
+		case isSensitiveKey(key):
+			findings = append(findings, secretFinding{Node: node, Path: path,
+				Reason: fmt.Sprintf("literal value for sensitive key %q", key)})
+		case looksLikeSecret(v):
+			findings = append(findings, secretFinding{Node: node, Path: path,
+				Reason: "high-entropy string"})
+		}
+	}
+	return findings
+}
+
+// isExpression reports whether an n8n parameter value is evaluated at runtime
+func isExpression(value string) bool {
+	return strings.HasPrefix(value, "=") || strings.Contains(value, "{{")
+}
+
+// looksLikeSecret reports whether value is a long, random-looking token
+func looksLikeSecret(value string) bool {
+	if len(value) < minSecretLength || strings.Contains(value, "://") {
+		return false
+	}
+	hasLetter, hasDigit := false, false
+	for _, r := range value {
+		switch {
+		case unicode.IsSpace(r):
+			return false
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	return hasLetter && hasDigit && shannonEntropy(value) >= minSecretEntropy
+}
+
+// shannonEntropy returns the entropy of value in bits per character
+func shannonEntropy(value string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range value {
+		counts[r]++
+		total++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// summarizeSecretFindings joins findings into a single condition/event message
+func summarizeSecretFindings(findings []secretFinding) string {
+	parts := make([]string, len(findings))
+	for i, f := range findings {
+		parts[i] = f.String()
+	}
+	return fmt.Sprintf("Possible hard-coded secrets; use n8n credentials instead: %s", strings.Join(parts, "; "))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// resolveSubWorkflowRefs looks up the n8n IDs of the workflows referenced by
+// spec.workflow.subWorkflowRefs, keyed by node name. The returned message is
+// non-empty when a referenced workflow doesn't exist or hasn't been created in
+// n8n yet, in which case the sync should wait.
+func (r *N8nWorkflowReconciler) resolveSubWorkflowRefs(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (map[string]string, string, error) {
+	refs := workflow.Spec.Workflow.SubWorkflowRefs
+	if len(refs) == 0 {
+		return nil, "", nil
+	}
+
+	resolved := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		target := &n8nv1alpha1.N8nWorkflow{}
+		key := types.NamespacedName{Name: ref.WorkflowRef, Namespace: workflow.Namespace}
+		if err := r.Get(ctx, key, target); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, fmt.Sprintf("Sub-workflow %q referenced by node %q not found", ref.WorkflowRef, ref.Node), nil
+			}
+			return nil, "", fmt.Errorf("failed to get sub-workflow %q: %w", ref.WorkflowRef, err)
+		}
+		if target.Status.WorkflowID == "" {
+			return nil, fmt.Sprintf("Sub-workflow %q referenced by node %q has not been created in n8n yet", ref.WorkflowRef, ref.Node), nil
+		}
+		resolved[ref.Node] = target.Status.WorkflowID
+	}
+	return resolved, "", nil
+}
+
+// rewriteSubWorkflowIDs points each referenced executeWorkflow node at its resolved
+// workflow ID. Both the plain string and the resource locator forms of the
+// workflowId parameter are supported.
+func rewriteSubWorkflowIDs(n8nWorkflow *n8n.Workflow, resolved map[string]string) error {
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(resolved))
+	for _, node := range n8nWorkflow.Nodes {
+		name, _ := node["name"].(string)
+		id, ok := resolved[name]
+		if !ok {
+			continue
+		}
+		seen[name] = true
+
+		params, _ := node["parameters"].(map[string]any)
+		if params == nil {
+			params = map[string]any{}
+			node["parameters"] = params
+		}
+		if locator, ok := params["workflowId"].(map[string]any); ok {
+			locator["value"] = id
+			locator["mode"] = "id"
+			// The cached URL embeds the old ID
+			delete(locator, "cachedResultUrl")
+		} else {
+			params["workflowId"] = id
+		}
+	}
+
+	for name := range resolved {
+		if !seen[name] {
+			return fmt.Errorf("subWorkflowRefs node %q not found in workflow", name)
+		}
+	}
+	return nil
+}
+
+// subWorkflowHash folds the resolved sub-workflow IDs into the spec hash, so a
+// referenced workflow being recreated under a new ID triggers an update
+func subWorkflowHash(specHash string, resolved map[string]string) string {
+	if len(resolved) == 0 {
+		return specHash
+	}
+
+	nodes := make([]string, 0, len(resolved))
+	for node := range resolved {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	h := sha256.New()
+	h.Write([]byte(specHash))
+	for _, node := range nodes {
+		fmt.Fprintf(h, "\x00%s=%s", node, resolved[node])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
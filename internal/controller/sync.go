@@ -0,0 +1,538 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// WorkflowClient is the part of the n8n API used by SyncWorkflow. *n8n.Client
+// implements it.
+type WorkflowClient interface {
+	ListWorkflows(ctx context.Context, opts *n8n.ListWorkflowsOptions) ([]n8n.Workflow, error)
+	GetWorkflow(ctx context.Context, id string) (*n8n.Workflow, error)
+	CreateWorkflow(ctx context.Context, workflow *n8n.Workflow) (*n8n.Workflow, error)
+	UpdateWorkflow(ctx context.Context, id string, workflow *n8n.Workflow) (*n8n.Workflow, error)
+	ActivateWorkflow(ctx context.Context, id string) (*n8n.Workflow, error)
+	DeactivateWorkflow(ctx context.Context, id string) (*n8n.Workflow, error)
+}
+
+var _ WorkflowClient = (*n8n.Client)(nil)
+
+// WorkflowPatcher is implemented by clients that can send only the changed
+// fields of a workflow. *n8n.Client implements it.
+type WorkflowPatcher interface {
+	PatchWorkflow(ctx context.Context, id string, patch *n8n.WorkflowPatch) (*n8n.Workflow, error)
+}
+
+var _ WorkflowPatcher = (*n8n.Client)(nil)
+
+// WorkflowCopier is implemented by clients that can duplicate a workflow under
+// a new name. *n8n.Client implements it.
+type WorkflowCopier interface {
+	CopyWorkflow(ctx context.Context, id, name string) (*n8n.Workflow, error)
+}
+
+var _ WorkflowCopier = (*n8n.Client)(nil)
+
+// SyncStep is one step of SyncWorkflow
+type SyncStep string
+
+const (
+	// SyncStepLookup finds the existing workflow
+	SyncStepLookup SyncStep = "lookup"
+	// SyncStepCreate creates a workflow when none exists
+	SyncStepCreate SyncStep = "create"
+	// SyncStepBackup copies the existing workflow before it is updated
+	SyncStepBackup SyncStep = "backup"
+	// SyncStepUpdate pushes the desired workflow over the existing one
+	SyncStepUpdate SyncStep = "update"
+	// SyncStepActivate activates the workflow
+	SyncStepActivate SyncStep = "activate"
+	// SyncStepDeactivate deactivates the workflow
+	SyncStepDeactivate SyncStep = "deactivate"
+	// SyncStepReadBack reads the workflow again after creating or updating it
+	SyncStepReadBack SyncStep = "readback"
+)
+
+// SyncOptions control the decisions SyncWorkflow makes
+type SyncOptions struct {
+	// TrackedID is the n8n ID of the workflow previously synced, if any
+	TrackedID string
+
+	// AdoptID is the n8n ID of an existing workflow to take over when TrackedID
+	// is unset or gone. Ignored in AdoptionModeNever.
+	AdoptID string
+
+	// AdoptionMode controls whether an existing workflow is looked up by name
+	// before creating one. Defaults to AdoptionModeByName.
+	AdoptionMode n8nv1alpha1.AdoptionMode
+
+	// ManagedBy is the marker identifying workflows this operator manages. Name
+	// lookups skip workflows another operator marked under the same key, and
+	// AdoptionModeByMarkerOnly only adopts workflows carrying it. Defaults to
+	// managedBy=n8n-resource-operator.
+	ManagedBy ManagedByMarker
+
+	// OwnerUID and OwnerName identify the resource syncing the workflow, e.g.
+	// the UID and namespace/name of an N8nWorkflow. They are written to the
+	// desired workflow's meta under OwnerUIDMetaKey and OwnerMetaKey. Lookups
+	// prefer a workflow carrying them over name matching, find it even after
+	// a rename, and never adopt a workflow owned by another resource.
+	OwnerUID  string
+	OwnerName string
+
+	// Update pushes the desired workflow to an existing one. When false the
+	// existing workflow is only tracked, as under the CreateOnly sync policy.
+	Update bool
+
+	// PartialUpdate sends only the fields that differ from the existing
+	// workflow when the client is a WorkflowPatcher, falling back to a full
+	// update when n8n rejects the patch or a change can't be expressed as one
+	PartialUpdate bool
+
+	// OverwriteDrift compares an existing workflow with desired when Update is
+	// not set and updates it anyway if its content differs, e.g. after an edit
+	// in the n8n UI. The differences are reported in SyncResult.Drift.
+	OverwriteDrift bool
+
+	// BackupBeforeUpdate copies the existing workflow to one named by
+	// backupName before updating it, so the version being overwritten can be
+	// recovered. The client must be a WorkflowCopier. A failed copy fails the
+	// sync before anything is updated.
+	BackupBeforeUpdate bool
+
+	// Active is the desired activation state
+	Active bool
+
+	// DenyActivation, when set, fails an activation or deactivation step with
+	// this error instead of calling n8n, e.g. when the API key can't write
+	DenyActivation error
+
+	// RequestContext, when set, decorates the context of each mutating request,
+	// e.g. to attach an idempotency key
+	RequestContext func(ctx context.Context, step SyncStep) context.Context
+
+	// Cache, when set, remembers which workflow a name resolved to, so a later
+	// lookup by name can fetch it directly instead of listing workflows
+	Cache *n8n.InstanceWorkflowCache
+}
+
+// SyncResult describes what SyncWorkflow found and did
+type SyncResult struct {
+	// Workflow is the latest known state of the workflow in n8n. It is nil when
+	// no workflow exists, which only happens if the lookup or create failed.
+	Workflow *n8n.Workflow
+
+	// Adopted is true when an existing workflow other than TrackedID was taken over
+	Adopted bool
+
+	// Drift lists how the existing workflow differed from desired before it was
+	// overwritten, when OverwriteDrift found any differences
+	Drift []string
+
+	// Backup is the copy made of the existing workflow before the update, when
+	// BackupBeforeUpdate is set and an update was sent
+	Backup *n8n.Workflow
+
+	// Actions lists the mutating steps performed, in order
+	Actions []SyncStep
+
+	// LookupDuration, WriteDuration and ActivationDuration time each phase
+	LookupDuration     time.Duration
+	WriteDuration      time.Duration
+	ActivationDuration time.Duration
+}
+
+// Did reports whether the step was performed
+func (r *SyncResult) Did(step SyncStep) bool {
+	for _, action := range r.Actions {
+		if action == step {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncError reports the step at which SyncWorkflow failed
+type SyncError struct {
+	Step SyncStep
+	Err  error
+}
+
+func (e *SyncError) Error() string {
+	return fmt.Sprintf("%s failed: %v", e.Step, e.Err)
+}
+
+func (e *SyncError) Unwrap() error {
+	return e.Err
+}
+
+// SyncWorkflow brings the n8n workflow in line with desired: it finds the
+// existing workflow (by tracked ID, adopted ID or name, depending on
+// opts.AdoptionMode), creates it when missing or updates it when opts.Update is
+// set, and activates or deactivates it to match opts.Active. Deactivation comes
+// before the write, so a workflow being taken down stops running without
+// waiting for the update, while activation comes after it. After a write the
+// workflow is read back, since the write response doesn't reliably report
+// whether n8n kept the workflow active, and activation is decided on the
+// state read. It doesn't depend
+// on the N8nWorkflow CRD, so it can be embedded in other controllers. Errors
+// are *SyncError, and the result reflects the steps completed before the failure.
+func SyncWorkflow(ctx context.Context, client WorkflowClient, desired *n8n.Workflow, opts SyncOptions) (SyncResult, error) {
+	log := logf.FromContext(ctx)
+	result := SyncResult{}
+	requestContext := func(step SyncStep) context.Context {
+		if opts.RequestContext == nil {
+			return ctx
+		}
+		return opts.RequestContext(ctx, step)
+	}
+
+	applyOwner(desired, opts)
+
+	start := time.Now()
+	existing, err := findWorkflow(ctx, client, desired.Name, opts)
+	if err == nil && existing == nil {
+		existing, err = recheckBeforeCreate(ctx, client, desired.Name, opts)
+		if existing != nil {
+			// Whatever created it meanwhile may have written another spec
+			opts.Update = true
+		}
+	}
+	result.LookupDuration = time.Since(start)
+	if err != nil {
+		return result, &SyncError{Step: SyncStepLookup, Err: err}
+	}
+	result.Workflow = existing
+	result.Adopted = existing != nil && existing.ID != opts.TrackedID
+	if existing != nil && !opts.Update && opts.OverwriteDrift {
+		result.Drift = contentDrift(desired, existing)
+		opts.Update = len(result.Drift) > 0
+	}
+
+	if existing != nil && existing.Active && !opts.Active {
+		if err := changeActivation(ctx, client, &result, SyncStepDeactivate, opts, requestContext); err != nil {
+			return result, err
+		}
+	}
+
+	start = time.Now()
+	switch {
+	case existing == nil:
+		log.Info("Creating new workflow in n8n", "name", desired.Name)
+		created, err := client.CreateWorkflow(requestContext(SyncStepCreate), desired)
+		result.WriteDuration = time.Since(start)
+		if err != nil {
+			return result, &SyncError{Step: SyncStepCreate, Err: err}
+		}
+		result.Workflow = created
+		result.Actions = append(result.Actions, SyncStepCreate)
+		opts.Cache.Store(created.Name, created.ID)
+	case opts.Update:
+		if opts.BackupBeforeUpdate {
+			backup, err := backupWorkflow(requestContext(SyncStepBackup), client, existing)
+			if err != nil {
+				result.WriteDuration = time.Since(start)
+				return result, &SyncError{Step: SyncStepBackup, Err: err}
+			}
+			log.Info("Copied workflow before updating it", "id", existing.ID, "backupId", backup.ID, "backupName", backup.Name)
+			result.Backup = backup
+			result.Actions = append(result.Actions, SyncStepBackup)
+		}
+		log.Info("Updating workflow in n8n", "id", existing.ID, "name", desired.Name)
+		updated, err := updateWorkflow(requestContext(SyncStepUpdate), client, existing, desired, opts.PartialUpdate)
+		result.WriteDuration = time.Since(start)
+		if err != nil {
+			return result, &SyncError{Step: SyncStepUpdate, Err: err}
+		}
+		result.Workflow = updated
+		result.Actions = append(result.Actions, SyncStepUpdate)
+		opts.Cache.Store(updated.Name, updated.ID)
+	default:
+		log.V(1).Info("Leaving existing workflow unchanged", "id", existing.ID)
+	}
+
+	if result.Did(SyncStepCreate) || result.Did(SyncStepUpdate) {
+		current, err := client.GetWorkflow(ctx, result.Workflow.ID)
+		if err != nil {
+			return result, &SyncError{Step: SyncStepReadBack, Err: err}
+		}
+		result.Workflow = current
+	}
+
+	current := result.Workflow
+	switch {
+	case opts.Active && !current.Active:
+		return result, changeActivation(ctx, client, &result, SyncStepActivate, opts, requestContext)
+	case !opts.Active && current.Active:
+		// Only reached when the workflow was still active after the write
+		return result, changeActivation(ctx, client, &result, SyncStepDeactivate, opts, requestContext)
+	}
+	return result, nil
+}
+
+// updateWorkflow pushes desired over existing, as a patch of the changed fields
+// when partial is set and the client supports it, or else as a full update
+func updateWorkflow(ctx context.Context, client WorkflowClient, existing, desired *n8n.Workflow, partial bool) (*n8n.Workflow, error) {
+	patcher, ok := client.(WorkflowPatcher)
+	if !partial || !ok {
+		return client.UpdateWorkflow(ctx, existing.ID, desired)
+	}
+	patch := workflowPatch(desired, existing)
+	if patch == nil {
+		// Nothing differs, e.g. a forced sync, or a field must be cleared
+		return client.UpdateWorkflow(ctx, existing.ID, desired)
+	}
+	updated, err := patcher.PatchWorkflow(ctx, existing.ID, patch)
+	if err == n8n.ErrPatchUnsupported {
+		logf.FromContext(ctx).V(1).Info("n8n rejected a partial update, sending the full workflow", "id", existing.ID)
+		return client.UpdateWorkflow(ctx, existing.ID, desired)
+	}
+	return updated, err
+}
+
+// backupWorkflow copies existing to a workflow named by backupName
+func backupWorkflow(ctx context.Context, client WorkflowClient, existing *n8n.Workflow) (*n8n.Workflow, error) {
+	copier, ok := client.(WorkflowCopier)
+	if !ok {
+		return nil, errors.New("the n8n client can't copy workflows")
+	}
+	return copier.CopyWorkflow(ctx, existing.ID, backupName(existing.Name, time.Now()))
+}
+
+// backupName returns the name of a copy of the workflow named name made at t,
+// e.g. "Orders-backup-20261016T093000Z"
+func backupName(name string, t time.Time) string {
+	return name + "-backup-" + t.UTC().Format("20060102T150405Z")
+}
+
+// changeActivation activates or deactivates result.Workflow, recording the step
+// in result. Errors are *SyncError.
+func changeActivation(ctx context.Context, client WorkflowClient, result *SyncResult, step SyncStep,
+	opts SyncOptions, requestContext func(SyncStep) context.Context) error {
+	if opts.DenyActivation != nil {
+		return &SyncError{Step: step, Err: opts.DenyActivation}
+	}
+
+	current := result.Workflow
+	start := time.Now()
+	logf.FromContext(ctx).Info("Changing workflow activation", "id", current.ID, "step", step)
+	var changed *n8n.Workflow
+	var err error
+	if step == SyncStepActivate {
+		changed, err = client.ActivateWorkflow(requestContext(step), current.ID)
+	} else {
+		changed, err = client.DeactivateWorkflow(requestContext(step), current.ID)
+	}
+	result.ActivationDuration += time.Since(start)
+	if err != nil {
+		return &SyncError{Step: step, Err: err}
+	}
+	result.Workflow = changed
+	result.Actions = append(result.Actions, step)
+	return nil
+}
+
+// findWorkflow looks up the n8n workflow to sync, or returns nil when a new one
+// should be created
+func findWorkflow(ctx context.Context, client WorkflowClient, name string, opts SyncOptions) (*n8n.Workflow, error) {
+	log := logf.FromContext(ctx)
+	mode := opts.AdoptionMode
+	if mode == "" {
+		mode = n8nv1alpha1.AdoptionModeByName
+	}
+
+	ids := []string{opts.TrackedID}
+	if mode != n8nv1alpha1.AdoptionModeNever {
+		ids = append(ids, opts.AdoptID)
+	}
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		existing, err := client.GetWorkflow(ctx, id)
+		if err == nil {
+			opts.Cache.Store(existing.Name, existing.ID)
+			return existing, nil
+		}
+		if n8n.IsNotFound(err) {
+			opts.Cache.Forget(id)
+		}
+		if mode == n8nv1alpha1.AdoptionModeByName {
+			log.V(1).Info("Failed to get workflow by ID, will search by name", "id", id, "error", err)
+			continue
+		}
+		// Without a name fallback, only a definite 404 may lead to creating a new workflow
+		if !n8n.IsNotFound(err) {
+			return nil, err
+		}
+		log.V(1).Info("Workflow no longer exists in n8n", "id", id)
+	}
+
+	if mode == n8nv1alpha1.AdoptionModeNever {
+		return nil, nil
+	}
+
+	// Try the workflow this name last resolved to before listing
+	if id, ok := opts.Cache.Lookup(name); ok {
+		cached, err := client.GetWorkflow(ctx, id)
+		if err == nil && cached.Name == name && adoptable(cached, mode, opts) {
+			log.V(1).Info("Found workflow by cached name lookup", "id", id)
+			opts.Cache.Store(cached.Name, cached.ID)
+			return cached, nil
+		}
+		opts.Cache.Forget(id)
+	}
+
+	existing, err := findWorkflowByName(ctx, client, name, mode, opts)
+	if err != nil || existing != nil || !hasOwner(opts) {
+		return existing, err
+	}
+	// The name may have changed in the spec since the workflow was created
+	return findWorkflowByOwner(ctx, client, mode, opts)
+}
+
+// hasOwner reports whether opts identify the syncing resource
+func hasOwner(opts SyncOptions) bool {
+	return opts.OwnerUID != "" || opts.OwnerName != ""
+}
+
+// adoptable reports whether a same-named workflow may be synced in mode: one
+// owned by the syncing resource always, otherwise, unless another resource
+// owns it, one carrying the managed-by marker, or in AdoptionModeByName one
+// without another operator's marker
+func adoptable(wf *n8n.Workflow, mode n8nv1alpha1.AdoptionMode, opts SyncOptions) bool {
+	marker := opts.ManagedBy.orDefault()
+	switch ownershipOf(wf, opts) {
+	case ownedBySelf:
+		return true
+	case ownedByOther:
+		return false
+	}
+	switch mode {
+	case n8nv1alpha1.AdoptionModeNever:
+		return false
+	case n8nv1alpha1.AdoptionModeByMarkerOnly:
+		return marker.marks(wf)
+	default:
+		return !marker.foreign(wf)
+	}
+}
+
+// findWorkflowByName lists the workflows named name and picks the one to sync,
+// preferring one owned by the syncing resource, then one it owned before being
+// recreated, then one carrying the managed-by marker, then any adoptable one
+func findWorkflowByName(ctx context.Context, client WorkflowClient, name string, mode n8nv1alpha1.AdoptionMode,
+	opts SyncOptions) (*n8n.Workflow, error) {
+	workflows, err := client.ListWorkflows(ctx, &n8n.ListWorkflowsOptions{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return pickWorkflow(ctx, workflows, name, mode, opts), nil
+}
+
+// findWorkflowByOwner lists all workflows and picks the one owned by the
+// syncing resource whatever its name, e.g. after the name changed in the spec
+func findWorkflowByOwner(ctx context.Context, client WorkflowClient, mode n8nv1alpha1.AdoptionMode,
+	opts SyncOptions) (*n8n.Workflow, error) {
+	workflows, err := client.ListWorkflows(ctx, &n8n.ListWorkflowsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return pickWorkflow(ctx, workflows, "", mode, opts), nil
+}
+
+// pickWorkflow chooses among workflows as findWorkflowByName describes. An
+// empty name only considers workflows owned by the syncing resource.
+func pickWorkflow(ctx context.Context, workflows []n8n.Workflow, name string, mode n8nv1alpha1.AdoptionMode,
+	opts SyncOptions) *n8n.Workflow {
+	log := logf.FromContext(ctx)
+	marker := opts.ManagedBy.orDefault()
+
+	var sameName, marked, unmarked *n8n.Workflow
+	for i := range workflows {
+		candidate := &workflows[i]
+		owner := ownershipOf(candidate, opts)
+		switch {
+		case owner == ownedBySelf:
+			opts.Cache.Store(candidate.Name, candidate.ID)
+			return candidate
+		case name != "" && candidate.Name != name:
+		case name == "" && owner != ownedBySameName:
+		case owner == ownedByOther:
+			log.Info("Same-named workflow is owned by another resource, not adopting", "id", candidate.ID,
+				"owner", candidate.Meta[OwnerMetaKey])
+		case mode == n8nv1alpha1.AdoptionModeNever:
+		case owner == ownedBySameName:
+			if sameName == nil {
+				sameName = candidate
+			}
+		case marker.marks(candidate):
+			if marked == nil {
+				marked = candidate
+			}
+		case marker.foreign(candidate):
+			log.Info("Same-named workflow is managed by another operator, not adopting", "id", candidate.ID, "marker", marker.Key)
+		case unmarked == nil:
+			unmarked = candidate
+		}
+	}
+	found := sameName
+	if found == nil {
+		found = marked
+	}
+	// Only ByName falls back to a same-named workflow without the marker
+	if found == nil && mode == n8nv1alpha1.AdoptionModeByName {
+		found = unmarked
+	}
+	if found != nil {
+		opts.Cache.Store(found.Name, found.ID)
+	}
+	return found
+}
+
+// recheckBeforeCreate looks for the workflow once more right before creating
+// it, so a workflow created meanwhile by an overlapping reconcile, or by one
+// whose status update was lost, is taken over instead of duplicated. With an
+// owner it looks for the owner's workflow under any name, otherwise by name.
+func recheckBeforeCreate(ctx context.Context, client WorkflowClient, name string, opts SyncOptions) (*n8n.Workflow, error) {
+	mode := opts.AdoptionMode
+	if mode == "" {
+		mode = n8nv1alpha1.AdoptionModeByName
+	}
+	var existing *n8n.Workflow
+	var err error
+	switch {
+	case hasOwner(opts):
+		existing, err = findWorkflowByOwner(ctx, client, mode, opts)
+	case mode != n8nv1alpha1.AdoptionModeNever:
+		existing, err = findWorkflowByName(ctx, client, name, mode, opts)
+	}
+	if existing != nil {
+		logf.FromContext(ctx).Info("Found workflow right before creating it, taking it over", "id", existing.ID, "name", name)
+	}
+	return existing, err
+}
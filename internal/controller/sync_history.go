@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// maxSyncHistory caps the attempts kept in status.syncHistory
+const maxSyncHistory = 10
+
+// maxSyncMessageLength caps the error message kept with a failed attempt
+const maxSyncMessageLength = 256
+
+// syncRecord describes the outcome of SyncWorkflow for status.syncHistory.
+// syncErr is nil when the sync succeeded.
+func syncRecord(result SyncResult, syncErr *SyncError, now time.Time) n8nv1alpha1.SyncRecord {
+	steps := make([]string, 0, len(result.Actions)+1)
+	for _, step := range result.Actions {
+		steps = append(steps, string(step))
+	}
+	record := n8nv1alpha1.SyncRecord{Time: metav1.NewTime(now), Result: n8nv1alpha1.SyncResultSucceeded}
+	if syncErr != nil {
+		steps = append(steps, string(syncErr.Step))
+		record.Result = n8nv1alpha1.SyncResultFailed
+		record.Message = truncateMessage(syncErr.Err.Error(), maxSyncMessageLength)
+	}
+	record.Action = strings.Join(steps, ",")
+	if record.Action == "" {
+		record.Action = "none"
+	}
+	return record
+}
+
+// recordSync adds record to the front of the workflow's sync history, dropping
+// the oldest attempts beyond maxSyncHistory. A sync that changed nothing after
+// another one that changed nothing only moves the time of the newest entry, so
+// routine resyncs don't push failures out of the history.
+func recordSync(workflow *n8nv1alpha1.N8nWorkflow, record n8nv1alpha1.SyncRecord) {
+	history := workflow.Status.SyncHistory
+	if len(history) > 0 && unchangedSync(history[0]) && unchangedSync(record) {
+		history[0].Time = record.Time
+		return
+	}
+	if len(history) >= maxSyncHistory {
+		history = history[:maxSyncHistory-1]
+	}
+	workflow.Status.SyncHistory = append([]n8nv1alpha1.SyncRecord{record}, history...)
+}
+
+// unchangedSync reports whether record is a successful sync that changed nothing
+func unchangedSync(record n8nv1alpha1.SyncRecord) bool {
+	return record.Result == n8nv1alpha1.SyncResultSucceeded && record.Action == "none"
+}
+
+// truncateMessage shortens message to at most limit characters
+func truncateMessage(message string, limit int) string {
+	runes := []rune(message)
+	if len(runes) <= limit {
+		return message
+	}
+	return string(runes[:limit-3]) + "..."
+}
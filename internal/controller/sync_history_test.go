@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Sync history", func() {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	updated := SyncResult{Actions: []SyncStep{SyncStepUpdate}}
+	failed := &SyncError{Step: SyncStepUpdate, Err: errors.New("boom")}
+
+	It("should describe the steps taken and the failed one", func() {
+		record := syncRecord(SyncResult{Actions: []SyncStep{SyncStepUpdate, SyncStepActivate}}, nil, start)
+		Expect(record.Action).To(Equal("update,activate"))
+		Expect(record.Result).To(Equal(n8nv1alpha1.SyncResultSucceeded))
+		Expect(record.Message).To(BeEmpty())
+		Expect(record.Time.Time).To(Equal(start))
+
+		record = syncRecord(SyncResult{Actions: []SyncStep{SyncStepBackup}}, failed, start)
+		Expect(record.Action).To(Equal("backup,update"))
+		Expect(record.Result).To(Equal(n8nv1alpha1.SyncResultFailed))
+		Expect(record.Message).To(Equal("boom"))
+
+		Expect(syncRecord(SyncResult{}, nil, start).Action).To(Equal("none"))
+	})
+
+	It("should cap the error message", func() {
+		record := syncRecord(SyncResult{}, &SyncError{Step: SyncStepLookup, Err: errors.New(strings.Repeat("x", 1000))}, start)
+		Expect(record.Message).To(HaveLen(maxSyncMessageLength))
+		Expect(record.Message).To(HaveSuffix("..."))
+	})
+
+	It("should keep the newest attempts first and stay bounded", func() {
+		workflow := &n8nv1alpha1.N8nWorkflow{}
+		for i := 0; i < maxSyncHistory+5; i++ {
+			var syncErr *SyncError
+			if i%2 == 1 {
+				syncErr = failed
+			}
+			recordSync(workflow, syncRecord(updated, syncErr, start.Add(time.Duration(i)*time.Minute)))
+		}
+
+		history := workflow.Status.SyncHistory
+		Expect(history).To(HaveLen(maxSyncHistory))
+		Expect(history[0].Time.Time).To(Equal(start.Add(time.Duration(maxSyncHistory+4) * time.Minute)))
+		Expect(history[maxSyncHistory-1].Time.Time).To(Equal(start.Add(5 * time.Minute)))
+		for i := 1; i < len(history); i++ {
+			Expect(history[i].Time.Before(&history[i-1].Time)).To(BeTrue())
+		}
+		Expect(history[0].Result).To(Equal(n8nv1alpha1.SyncResultSucceeded))
+		Expect(history[1].Result).To(Equal(n8nv1alpha1.SyncResultFailed))
+	})
+
+	It("should fold syncs in a row that changed nothing into one entry", func() {
+		workflow := &n8nv1alpha1.N8nWorkflow{}
+		recordSync(workflow, syncRecord(updated, nil, start))
+		recordSync(workflow, syncRecord(SyncResult{}, nil, start.Add(time.Minute)))
+		recordSync(workflow, syncRecord(SyncResult{}, nil, start.Add(2*time.Minute)))
+		Expect(workflow.Status.SyncHistory).To(HaveLen(2))
+		Expect(workflow.Status.SyncHistory[0].Action).To(Equal("none"))
+		Expect(workflow.Status.SyncHistory[0].Time.Time).To(Equal(start.Add(2 * time.Minute)))
+
+		// A failure in between starts a new entry for the next unchanged sync
+		recordSync(workflow, syncRecord(SyncResult{}, failed, start.Add(3*time.Minute)))
+		recordSync(workflow, syncRecord(SyncResult{}, nil, start.Add(4*time.Minute)))
+		Expect(workflow.Status.SyncHistory).To(HaveLen(4))
+		Expect(workflow.Status.SyncHistory[0].Action).To(Equal("none"))
+		Expect(workflow.Status.SyncHistory[1].Result).To(Equal(n8nv1alpha1.SyncResultFailed))
+	})
+})
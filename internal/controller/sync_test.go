@@ -0,0 +1,662 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// memoryWorkflowClient is an in-memory WorkflowClient that can fail chosen steps
+type memoryWorkflowClient struct {
+	workflows map[string]*n8n.Workflow
+	failures  map[SyncStep]error
+	nextID    int
+	lists     int
+
+	// onList, when set, runs before each list with the number of lists so far,
+	// e.g. to simulate a workflow created by another reconcile
+	onList func(lists int)
+}
+
+func newMemoryWorkflowClient(existing ...n8n.Workflow) *memoryWorkflowClient {
+	c := &memoryWorkflowClient{workflows: map[string]*n8n.Workflow{}, failures: map[SyncStep]error{}}
+	for i := range existing {
+		c.workflows[existing[i].ID] = &existing[i]
+	}
+	return c
+}
+
+func (c *memoryWorkflowClient) get(id string) (*n8n.Workflow, error) {
+	if wf, ok := c.workflows[id]; ok {
+		copied := *wf
+		return &copied, nil
+	}
+	return nil, &n8n.ErrorResponse{Message: "not found", StatusCode: http.StatusNotFound}
+}
+
+func (c *memoryWorkflowClient) GetWorkflow(_ context.Context, id string) (*n8n.Workflow, error) {
+	if err := c.failures[SyncStepLookup]; err != nil {
+		return nil, err
+	}
+	return c.get(id)
+}
+
+func (c *memoryWorkflowClient) ListWorkflows(_ context.Context, _ *n8n.ListWorkflowsOptions) ([]n8n.Workflow, error) {
+	if err := c.failures[SyncStepLookup]; err != nil {
+		return nil, err
+	}
+	c.lists++
+	if c.onList != nil {
+		c.onList(c.lists)
+	}
+	workflows := make([]n8n.Workflow, 0, len(c.workflows))
+	for _, wf := range c.workflows {
+		workflows = append(workflows, *wf)
+	}
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].ID < workflows[j].ID })
+	return workflows, nil
+}
+
+func (c *memoryWorkflowClient) CreateWorkflow(_ context.Context, workflow *n8n.Workflow) (*n8n.Workflow, error) {
+	if err := c.failures[SyncStepCreate]; err != nil {
+		return nil, err
+	}
+	c.nextID++
+	created := *workflow
+	created.ID = fmt.Sprintf("new-%d", c.nextID)
+	created.Active = false
+	c.workflows[created.ID] = &created
+	return c.get(created.ID)
+}
+
+func (c *memoryWorkflowClient) UpdateWorkflow(_ context.Context, id string, workflow *n8n.Workflow) (*n8n.Workflow, error) {
+	if err := c.failures[SyncStepUpdate]; err != nil {
+		return nil, err
+	}
+	existing, err := c.get(id)
+	if err != nil {
+		return nil, err
+	}
+	updated := *workflow
+	updated.ID, updated.Active = id, existing.Active
+	c.workflows[id] = &updated
+	return c.get(id)
+}
+
+func (c *memoryWorkflowClient) CopyWorkflow(ctx context.Context, id, name string) (*n8n.Workflow, error) {
+	if err := c.failures[SyncStepBackup]; err != nil {
+		return nil, err
+	}
+	original, err := c.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateWorkflow(ctx, &n8n.Workflow{Name: name, Nodes: original.Nodes, Connections: original.Connections})
+}
+
+func (c *memoryWorkflowClient) setActive(step SyncStep, id string, active bool) (*n8n.Workflow, error) {
+	if err := c.failures[step]; err != nil {
+		return nil, err
+	}
+	if _, err := c.get(id); err != nil {
+		return nil, err
+	}
+	c.workflows[id].Active = active
+	return c.get(id)
+}
+
+func (c *memoryWorkflowClient) ActivateWorkflow(_ context.Context, id string) (*n8n.Workflow, error) {
+	return c.setActive(SyncStepActivate, id, true)
+}
+
+func (c *memoryWorkflowClient) DeactivateWorkflow(_ context.Context, id string) (*n8n.Workflow, error) {
+	return c.setActive(SyncStepDeactivate, id, false)
+}
+
+// patchingWorkflowClient is a memoryWorkflowClient that records the patches it
+// applies, or rejects them like an n8n without PATCH support
+type patchingWorkflowClient struct {
+	*memoryWorkflowClient
+	patches     []*n8n.WorkflowPatch
+	updates     int
+	unsupported bool
+}
+
+func (c *patchingWorkflowClient) UpdateWorkflow(ctx context.Context, id string, workflow *n8n.Workflow) (*n8n.Workflow, error) {
+	c.updates++
+	return c.memoryWorkflowClient.UpdateWorkflow(ctx, id, workflow)
+}
+
+func (c *patchingWorkflowClient) PatchWorkflow(_ context.Context, id string, patch *n8n.WorkflowPatch) (*n8n.Workflow, error) {
+	if c.unsupported {
+		return nil, n8n.ErrPatchUnsupported
+	}
+	c.patches = append(c.patches, patch)
+	wf, ok := c.workflows[id]
+	if !ok {
+		return c.get(id)
+	}
+	if patch.Name != "" {
+		wf.Name = patch.Name
+	}
+	if patch.Nodes != nil {
+		wf.Nodes = patch.Nodes
+	}
+	if patch.Connections != nil {
+		wf.Connections = patch.Connections
+	}
+	if patch.Settings != nil {
+		wf.Settings = patch.Settings
+	}
+	return c.get(id)
+}
+
+// staleWriteClient is a memoryWorkflowClient whose updates deactivate the
+// workflow while the response still reports the state from before the write,
+// and whose reads after a write can fail
+type staleWriteClient struct {
+	*memoryWorkflowClient
+	writes      int
+	readBackErr error
+}
+
+func (c *staleWriteClient) UpdateWorkflow(ctx context.Context, id string, workflow *n8n.Workflow) (*n8n.Workflow, error) {
+	updated, err := c.memoryWorkflowClient.UpdateWorkflow(ctx, id, workflow)
+	if err != nil {
+		return nil, err
+	}
+	c.writes++
+	c.workflows[id].Active = false
+	return updated, nil
+}
+
+func (c *staleWriteClient) GetWorkflow(ctx context.Context, id string) (*n8n.Workflow, error) {
+	if c.writes > 0 && c.readBackErr != nil {
+		return nil, c.readBackErr
+	}
+	return c.memoryWorkflowClient.GetWorkflow(ctx, id)
+}
+
+var _ = Describe("SyncWorkflow", func() {
+	ctx := context.Background()
+	desired := func() *n8n.Workflow {
+		return &n8n.Workflow{Name: "Orders", Nodes: []map[string]any{{"name": "Start"}}}
+	}
+
+	type syncCase struct {
+		existing []n8n.Workflow
+		opts     SyncOptions
+
+		actions    []SyncStep
+		workflowID string
+		adopted    bool
+		active     bool
+	}
+
+	DescribeTable("should choose the steps for each combination",
+		func(tc syncCase) {
+			client := newMemoryWorkflowClient(tc.existing...)
+			result, err := SyncWorkflow(ctx, client, desired(), tc.opts)
+			Expect(err).NotTo(HaveOccurred())
+			if len(tc.actions) == 0 {
+				Expect(result.Actions).To(BeEmpty())
+			} else {
+				Expect(result.Actions).To(Equal(tc.actions))
+			}
+			Expect(result.Workflow.ID).To(Equal(tc.workflowID))
+			Expect(result.Adopted).To(Equal(tc.adopted))
+			Expect(result.Workflow.Active).To(Equal(tc.active))
+		},
+		Entry("creates and activates when nothing exists", syncCase{
+			opts:       SyncOptions{Active: true},
+			actions:    []SyncStep{SyncStepCreate, SyncStepActivate},
+			workflowID: "new-1",
+			active:     true,
+		}),
+		Entry("creates inactive when inactive is desired", syncCase{
+			actions:    []SyncStep{SyncStepCreate},
+			workflowID: "new-1",
+		}),
+		Entry("updates the tracked workflow", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders", Active: true}},
+			opts:       SyncOptions{TrackedID: "wf-1", Update: true, Active: true},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-1",
+			active:     true,
+		}),
+		Entry("backs up the tracked workflow before updating it", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders", Active: true}},
+			opts:       SyncOptions{TrackedID: "wf-1", Update: true, BackupBeforeUpdate: true, Active: true},
+			actions:    []SyncStep{SyncStepBackup, SyncStepUpdate},
+			workflowID: "wf-1",
+			active:     true,
+		}),
+		Entry("does not back up a workflow it creates", syncCase{
+			opts:       SyncOptions{Update: true, BackupBeforeUpdate: true},
+			actions:    []SyncStep{SyncStepCreate},
+			workflowID: "new-1",
+		}),
+		Entry("only tracks the workflow when updates are off", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders", Active: true}},
+			opts:       SyncOptions{TrackedID: "wf-1", Active: true},
+			workflowID: "wf-1",
+			active:     true,
+		}),
+		Entry("still activates a tracked workflow when updates are off", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders"}},
+			opts:       SyncOptions{TrackedID: "wf-1", Active: true},
+			actions:    []SyncStep{SyncStepActivate},
+			workflowID: "wf-1",
+			active:     true,
+		}),
+		Entry("deactivates an active workflow before updating it", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders", Active: true}},
+			opts:       SyncOptions{TrackedID: "wf-1", Update: true},
+			actions:    []SyncStep{SyncStepDeactivate, SyncStepUpdate},
+			workflowID: "wf-1",
+		}),
+		Entry("deactivates a tracked workflow when updates are off", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders", Active: true}},
+			opts:       SyncOptions{TrackedID: "wf-1"},
+			actions:    []SyncStep{SyncStepDeactivate},
+			workflowID: "wf-1",
+		}),
+		Entry("adopts a same-named workflow by default", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-2", Name: "Orders"}},
+			opts:       SyncOptions{TrackedID: "gone", Update: true},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-2",
+			adopted:    true,
+		}),
+		Entry("adopts the marked workflow in ByMarkerOnly mode", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-3", Name: "Legacy Orders"}},
+			opts:       SyncOptions{AdoptID: "wf-3", AdoptionMode: n8nv1alpha1.AdoptionModeByMarkerOnly, Update: true},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-3",
+			adopted:    true,
+		}),
+		Entry("ignores same-named workflows in ByMarkerOnly mode", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-2", Name: "Orders"}},
+			opts:       SyncOptions{AdoptionMode: n8nv1alpha1.AdoptionModeByMarkerOnly},
+			actions:    []SyncStep{SyncStepCreate},
+			workflowID: "new-1",
+		}),
+		Entry("adopts a same-named workflow carrying the configured marker in ByMarkerOnly mode", syncCase{
+			existing: []n8n.Workflow{{ID: "wf-4", Name: "Orders", Meta: map[string]any{"owner": "team-a"}}},
+			opts: SyncOptions{
+				AdoptionMode: n8nv1alpha1.AdoptionModeByMarkerOnly,
+				ManagedBy:    ManagedByMarker{Key: "owner", Value: "team-a"},
+				Update:       true,
+			},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-4",
+			adopted:    true,
+		}),
+		Entry("ignores the default marker when another is configured", syncCase{
+			existing: []n8n.Workflow{{ID: "wf-4", Name: "Orders",
+				Meta: map[string]any{DefaultManagedByKey: DefaultManagedByValue}}},
+			opts: SyncOptions{
+				AdoptionMode: n8nv1alpha1.AdoptionModeByMarkerOnly,
+				ManagedBy:    ManagedByMarker{Key: "owner", Value: "team-a"},
+			},
+			actions:    []SyncStep{SyncStepCreate},
+			workflowID: "new-1",
+		}),
+		Entry("does not adopt by name a workflow marked by another operator", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-5", Name: "Orders", Meta: map[string]any{"owner": "team-b"}}},
+			opts:       SyncOptions{ManagedBy: ManagedByMarker{Key: "owner", Value: "team-a"}},
+			actions:    []SyncStep{SyncStepCreate},
+			workflowID: "new-1",
+		}),
+		Entry("overwrites a tracked workflow edited in n8n", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders", Nodes: []map[string]any{{"name": "Edited"}}}},
+			opts:       SyncOptions{TrackedID: "wf-1", OverwriteDrift: true},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-1",
+		}),
+		Entry("leaves a tracked workflow matching the spec alone", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Orders", Nodes: []map[string]any{{"name": "Start"}}}},
+			opts:       SyncOptions{TrackedID: "wf-1", OverwriteDrift: true},
+			workflowID: "wf-1",
+		}),
+		Entry("prefers the workflow carrying the owner UID over other marked ones", syncCase{
+			existing: []n8n.Workflow{
+				{ID: "wf-1", Name: "Orders", Meta: map[string]any{DefaultManagedByKey: DefaultManagedByValue}},
+				{ID: "wf-2", Name: "Orders", Meta: map[string]any{DefaultManagedByKey: DefaultManagedByValue, OwnerUIDMetaKey: "uid-1"}},
+			},
+			opts:       SyncOptions{OwnerUID: "uid-1", Update: true},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-2",
+			adopted:    true,
+		}),
+		Entry("takes over the owner's workflow in Never mode instead of creating another", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-2", Name: "Orders", Meta: map[string]any{OwnerUIDMetaKey: "uid-1"}}},
+			opts:       SyncOptions{OwnerUID: "uid-1", AdoptionMode: n8nv1alpha1.AdoptionModeNever},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-2",
+			adopted:    true,
+		}),
+		Entry("finds the owner's workflow after it was renamed in the spec", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-1", Name: "Old Orders", Meta: map[string]any{OwnerUIDMetaKey: "uid-1"}}},
+			opts:       SyncOptions{OwnerUID: "uid-1", Update: true},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-1",
+			adopted:    true,
+		}),
+		Entry("does not adopt a same-named workflow owned by another resource", syncCase{
+			existing: []n8n.Workflow{{ID: "wf-1", Name: "Orders",
+				Meta: map[string]any{OwnerUIDMetaKey: "uid-2", OwnerMetaKey: "team-b/orders"}}},
+			opts:       SyncOptions{OwnerUID: "uid-1", OwnerName: "team-a/orders", Update: true},
+			actions:    []SyncStep{SyncStepCreate},
+			workflowID: "new-1",
+		}),
+		Entry("adopts the workflow of a resource that was deleted and created again", syncCase{
+			existing: []n8n.Workflow{
+				{ID: "wf-1", Name: "Orders"},
+				{ID: "wf-2", Name: "Orders", Meta: map[string]any{OwnerUIDMetaKey: "uid-old", OwnerMetaKey: "team-a/orders"}},
+			},
+			opts:       SyncOptions{OwnerUID: "uid-1", OwnerName: "team-a/orders", Update: true},
+			actions:    []SyncStep{SyncStepUpdate},
+			workflowID: "wf-2",
+			adopted:    true,
+		}),
+		Entry("ignores the adoption marker in Never mode", syncCase{
+			existing:   []n8n.Workflow{{ID: "wf-3", Name: "Orders"}},
+			opts:       SyncOptions{AdoptID: "wf-3", AdoptionMode: n8nv1alpha1.AdoptionModeNever},
+			actions:    []SyncStep{SyncStepCreate},
+			workflowID: "new-1",
+		}),
+	)
+
+	It("should report the drift it overwrote", func() {
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-1", Name: "Orders", Active: true,
+			Nodes: []map[string]any{{"name": "Start"}, {"name": "Added In UI"}}})
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{TrackedID: "wf-1", OverwriteDrift: true, Active: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Drift).To(Equal([]string{`node "Added In UI" is not in the spec`}))
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepUpdate}))
+		Expect(client.workflows["wf-1"].Nodes).To(HaveLen(1))
+	})
+
+	DescribeTable("should report the failed step with the progress made",
+		func(failing SyncStep, opts SyncOptions, actions []SyncStep, hasWorkflow bool) {
+			client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-1", Name: "Orders"})
+			client.failures[failing] = fmt.Errorf("boom")
+
+			result, err := SyncWorkflow(ctx, client, desired(), opts)
+			Expect(err).To(MatchError(ContainSubstring("boom")))
+			syncErr, ok := err.(*SyncError)
+			Expect(ok).To(BeTrue())
+			Expect(syncErr.Step).To(Equal(failing))
+			if len(actions) == 0 {
+				Expect(result.Actions).To(BeEmpty())
+			} else {
+				Expect(result.Actions).To(Equal(actions))
+			}
+			Expect(result.Workflow != nil).To(Equal(hasWorkflow))
+		},
+		Entry("lookup without a name fallback", SyncStepLookup,
+			SyncOptions{TrackedID: "wf-1", AdoptionMode: n8nv1alpha1.AdoptionModeByMarkerOnly}, nil, false),
+		Entry("create", SyncStepCreate,
+			SyncOptions{AdoptionMode: n8nv1alpha1.AdoptionModeNever}, nil, false),
+		Entry("update", SyncStepUpdate,
+			SyncOptions{TrackedID: "wf-1", Update: true}, nil, true),
+		Entry("backup before updating", SyncStepBackup,
+			SyncOptions{TrackedID: "wf-1", Update: true, BackupBeforeUpdate: true}, nil, true),
+		Entry("activate after creating", SyncStepActivate,
+			SyncOptions{AdoptionMode: n8nv1alpha1.AdoptionModeNever, Active: true}, []SyncStep{SyncStepCreate}, true),
+	)
+
+	It("should copy the workflow before overwriting drift", func() {
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-1", Name: "Orders", Active: true,
+			Nodes: []map[string]any{{"name": "Start"}, {"name": "Added In UI"}}})
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{
+			TrackedID: "wf-1", OverwriteDrift: true, BackupBeforeUpdate: true, Active: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepBackup, SyncStepUpdate}))
+		Expect(result.Backup).NotTo(BeNil())
+		Expect(result.Backup.ID).To(Equal("new-1"))
+		Expect(result.Backup.Name).To(MatchRegexp(`^Orders-backup-\d{8}T\d{6}Z$`))
+		Expect(client.workflows["new-1"].Nodes).To(HaveLen(2))
+		Expect(client.workflows["new-1"].Active).To(BeFalse())
+		Expect(client.workflows["wf-1"].Nodes).To(HaveLen(1))
+	})
+
+	It("should leave the workflow alone when the backup fails", func() {
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-1", Name: "Orders", Active: true})
+		client.failures[SyncStepBackup] = fmt.Errorf("boom")
+
+		_, err := SyncWorkflow(ctx, client, desired(), SyncOptions{
+			TrackedID: "wf-1", Update: true, BackupBeforeUpdate: true, Active: true})
+		Expect(err).To(MatchError(ContainSubstring("boom")))
+		Expect(client.workflows).To(HaveLen(1))
+		Expect(client.workflows["wf-1"].Nodes).To(BeEmpty())
+	})
+
+	It("should not update when deactivating first fails", func() {
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-1", Name: "Orders", Active: true})
+		client.failures[SyncStepDeactivate] = fmt.Errorf("boom")
+
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{TrackedID: "wf-1", Update: true})
+		Expect(err).To(MatchError(ContainSubstring("boom")))
+		Expect(err.(*SyncError).Step).To(Equal(SyncStepDeactivate))
+		Expect(result.Actions).To(BeEmpty())
+		Expect(client.workflows["wf-1"].Nodes).To(BeEmpty())
+		Expect(client.workflows["wf-1"].Active).To(BeTrue())
+	})
+
+	It("should reactivate a workflow n8n deactivated during the update", func() {
+		client := &staleWriteClient{memoryWorkflowClient: newMemoryWorkflowClient(
+			n8n.Workflow{ID: "wf-1", Name: "Orders", Active: true})}
+
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{TrackedID: "wf-1", Update: true, Active: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepUpdate, SyncStepActivate}))
+		Expect(result.Workflow.Active).To(BeTrue())
+		Expect(client.workflows["wf-1"].Active).To(BeTrue())
+	})
+
+	It("should report a failed read back after the write", func() {
+		client := &staleWriteClient{memoryWorkflowClient: newMemoryWorkflowClient(
+			n8n.Workflow{ID: "wf-1", Name: "Orders", Active: true})}
+		client.readBackErr = fmt.Errorf("boom")
+
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{TrackedID: "wf-1", Update: true, Active: true})
+		Expect(err).To(MatchError(ContainSubstring("boom")))
+		Expect(err.(*SyncError).Step).To(Equal(SyncStepReadBack))
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepUpdate}))
+		Expect(client.workflows["wf-1"].Active).To(BeFalse())
+	})
+
+	DescribeTable("should tell activation drift apart from a change of spec.active",
+		func(trackedID string, lastActive, desired bool, result SyncResult, drifted bool) {
+			Expect(activationDrifted(trackedID, lastActive, desired, result)).To(Equal(drifted))
+		},
+		Entry("deactivated in n8n", "wf-1", true, true,
+			SyncResult{Workflow: &n8n.Workflow{ID: "wf-1"}, Actions: []SyncStep{SyncStepActivate}}, true),
+		Entry("activated in n8n", "wf-1", false, false,
+			SyncResult{Workflow: &n8n.Workflow{ID: "wf-1"}, Actions: []SyncStep{SyncStepDeactivate}}, true),
+		Entry("spec.active changed", "wf-1", false, true,
+			SyncResult{Workflow: &n8n.Workflow{ID: "wf-1"}, Actions: []SyncStep{SyncStepActivate}}, false),
+		Entry("newly created", "", false, true,
+			SyncResult{Workflow: &n8n.Workflow{ID: "wf-1"}, Actions: []SyncStep{SyncStepCreate, SyncStepActivate}}, false),
+		Entry("adopted", "gone", true, true,
+			SyncResult{Workflow: &n8n.Workflow{ID: "wf-2"}, Adopted: true, Actions: []SyncStep{SyncStepActivate}}, false),
+		Entry("already in the desired state", "wf-1", true, true,
+			SyncResult{Workflow: &n8n.Workflow{ID: "wf-1"}, Actions: []SyncStep{SyncStepUpdate}}, false),
+	)
+
+	It("should fail activation with DenyActivation without calling n8n", func() {
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-1", Name: "Orders"})
+		denied := fmt.Errorf("read-only key")
+
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{
+			TrackedID:      "wf-1",
+			Active:         true,
+			DenyActivation: denied,
+		})
+		Expect(err).To(MatchError(denied))
+		Expect(err.(*SyncError).Step).To(Equal(SyncStepActivate))
+		Expect(result.Actions).To(BeEmpty())
+		Expect(client.workflows["wf-1"].Active).To(BeFalse())
+
+		By("ignoring DenyActivation when activation already matches")
+		client.workflows["wf-1"].Active = true
+		_, err = SyncWorkflow(ctx, client, desired(), SyncOptions{TrackedID: "wf-1", Active: true, DenyActivation: denied})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should skip listing workflows when the name lookup is cached", func() {
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-2", Name: "Orders"})
+		cache := n8n.NewWorkflowCache(time.Minute).For("http://n8n:5678")
+		opts := SyncOptions{TrackedID: "gone", Cache: cache}
+
+		result, err := SyncWorkflow(ctx, client, desired(), opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Workflow.ID).To(Equal("wf-2"))
+		Expect(client.lists).To(Equal(1))
+
+		By("finding the same workflow again without a list call")
+		result, err = SyncWorkflow(ctx, client, desired(), opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Workflow.ID).To(Equal("wf-2"))
+		Expect(client.lists).To(Equal(1))
+
+		By("listing again, and once more before creating, once the cached workflow is gone")
+		delete(client.workflows, "wf-2")
+		result, err = SyncWorkflow(ctx, client, desired(), opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepCreate}))
+		Expect(client.lists).To(Equal(3))
+		id, ok := cache.Lookup("Orders")
+		Expect(ok).To(BeTrue())
+		Expect(id).To(Equal(result.Workflow.ID))
+	})
+
+	It("should update instead of create when the workflow appears right before creating it", func() {
+		client := newMemoryWorkflowClient()
+		// Another reconcile creates the workflow between the lookups and the create
+		client.onList = func(lists int) {
+			if lists == 3 {
+				client.workflows["wf-9"] = &n8n.Workflow{ID: "wf-9", Name: "Orders",
+					Meta: map[string]any{OwnerUIDMetaKey: "uid-1"}}
+			}
+		}
+
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{OwnerUID: "uid-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepUpdate}))
+		Expect(result.Workflow.ID).To(Equal("wf-9"))
+		Expect(result.Adopted).To(BeTrue())
+		Expect(client.workflows).To(HaveLen(1))
+		Expect(client.workflows["wf-9"].Nodes).To(HaveLen(1))
+	})
+
+	It("should record the owner in the meta of created workflows", func() {
+		client := newMemoryWorkflowClient()
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{OwnerUID: "uid-1", OwnerName: "team-a/orders"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepCreate}))
+		Expect(client.workflows[result.Workflow.ID].Meta).To(HaveKeyWithValue(OwnerUIDMetaKey, "uid-1"))
+		Expect(client.workflows[result.Workflow.ID].Meta).To(HaveKeyWithValue(OwnerMetaKey, "team-a/orders"))
+	})
+
+	DescribeTable("should compare the owner marker with the syncing resource",
+		func(meta map[string]any, opts SyncOptions, expected ownership) {
+			Expect(ownershipOf(&n8n.Workflow{Meta: meta}, opts)).To(Equal(expected))
+		},
+		Entry("no marker", nil, SyncOptions{OwnerUID: "uid-1"}, unowned),
+		Entry("no owner to compare with", map[string]any{OwnerUIDMetaKey: "uid-1"}, SyncOptions{}, unowned),
+		Entry("same UID", map[string]any{OwnerUIDMetaKey: "uid-1", OwnerMetaKey: "team-a/orders"},
+			SyncOptions{OwnerUID: "uid-1", OwnerName: "team-a/renamed"}, ownedBySelf),
+		Entry("same name, new UID", map[string]any{OwnerUIDMetaKey: "uid-0", OwnerMetaKey: "team-a/orders"},
+			SyncOptions{OwnerUID: "uid-1", OwnerName: "team-a/orders"}, ownedBySameName),
+		Entry("another resource", map[string]any{OwnerUIDMetaKey: "uid-2", OwnerMetaKey: "team-b/orders"},
+			SyncOptions{OwnerUID: "uid-1", OwnerName: "team-a/orders"}, ownedByOther),
+	)
+
+	It("should send only the name when nothing else changed", func() {
+		client := &patchingWorkflowClient{memoryWorkflowClient: newMemoryWorkflowClient(n8n.Workflow{
+			ID: "wf-1", Name: "Old Orders", Nodes: []map[string]any{{"name": "Start"}}})}
+
+		result, err := SyncWorkflow(ctx, client, desired(), SyncOptions{TrackedID: "wf-1", Update: true, PartialUpdate: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Actions).To(Equal([]SyncStep{SyncStepUpdate}))
+		Expect(client.updates).To(BeZero())
+		Expect(client.patches).To(Equal([]*n8n.WorkflowPatch{{Name: "Orders"}}))
+		Expect(client.workflows["wf-1"].Name).To(Equal("Orders"))
+		Expect(client.workflows["wf-1"].Nodes).To(HaveLen(1))
+	})
+
+	It("should patch whole sections that changed", func() {
+		client := &patchingWorkflowClient{memoryWorkflowClient: newMemoryWorkflowClient(n8n.Workflow{
+			ID: "wf-1", Name: "Orders", Nodes: []map[string]any{{"name": "Start"}, {"name": "Added In UI"}}})}
+
+		_, err := SyncWorkflow(ctx, client, desired(), SyncOptions{TrackedID: "wf-1", Update: true, PartialUpdate: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.patches).To(HaveLen(1))
+		Expect(client.patches[0].Name).To(BeEmpty())
+		Expect(client.patches[0].Nodes).To(Equal(desired().Nodes))
+	})
+
+	DescribeTable("should fall back to a full update",
+		func(existing n8n.Workflow, opts SyncOptions, unsupported bool) {
+			client := &patchingWorkflowClient{memoryWorkflowClient: newMemoryWorkflowClient(existing), unsupported: unsupported}
+			opts.TrackedID, opts.Update = existing.ID, true
+
+			result, err := SyncWorkflow(ctx, client, desired(), opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Actions).To(Equal([]SyncStep{SyncStepUpdate}))
+			Expect(client.patches).To(BeEmpty())
+			Expect(client.updates).To(Equal(1))
+			Expect(client.workflows["wf-1"].Name).To(Equal("Orders"))
+		},
+		Entry("when partial updates are off",
+			n8n.Workflow{ID: "wf-1", Name: "Old Orders"}, SyncOptions{}, false),
+		Entry("when n8n rejects the patch",
+			n8n.Workflow{ID: "wf-1", Name: "Old Orders"}, SyncOptions{PartialUpdate: true}, true),
+		Entry("when nothing differs",
+			n8n.Workflow{ID: "wf-1", Name: "Orders", Nodes: []map[string]any{{"name": "Start"}}}, SyncOptions{PartialUpdate: true}, false),
+		Entry("when a field must be cleared",
+			n8n.Workflow{ID: "wf-1", Name: "Old Orders", Nodes: []map[string]any{{"name": "Start"}},
+				Connections: map[string]any{"Start": map[string]any{}}}, SyncOptions{PartialUpdate: true}, false),
+	)
+
+	It("should decorate the context of mutating requests only", func() {
+		client := newMemoryWorkflowClient()
+		var steps []SyncStep
+		_, err := SyncWorkflow(ctx, client, desired(), SyncOptions{
+			Active: true,
+			RequestContext: func(ctx context.Context, step SyncStep) context.Context {
+				steps = append(steps, step)
+				return ctx
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(steps).To(Equal([]SyncStep{SyncStepCreate, SyncStepActivate}))
+	})
+})
@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the time zone database so spec.syncWindow.timeZone resolves in
+	// images without one
+	_ "time/tzdata"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// maxScheduleSearch bounds how far ahead the next match of a cron schedule is
+// searched for, covering the longest gap a valid schedule can have (Feb 29)
+const maxScheduleSearch = 8 * 366 * 24 * time.Hour
+
+// monthNames and weekdayNames are the names accepted in the month and
+// day-of-week fields of a cron schedule
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	weekdayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// cronSchedule is a parsed five-field cron expression. Each field is a bit set
+// of the values it matches.
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek record a "*" day field. Like cron, when
+	// both day fields are restricted a time matches if either does.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// parseCronSchedule parses "minute hour day-of-month month day-of-week". Each
+// field is "*" or a comma-separated list of values, ranges ("1-5") and steps
+// ("*/15", "9-17/2"). Months and weekdays may be given by their three-letter
+// English names, and 7 is Sunday like 0.
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule %q must have 5 fields (minute hour day-of-month month day-of-week), got %d",
+			expr, len(fields))
+	}
+	schedule := &cronSchedule{
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}
+	var err error
+	for _, f := range []struct {
+		bits     *uint64
+		value    string
+		name     string
+		min, max int
+		names    map[string]int
+	}{
+		{&schedule.minute, fields[0], "minute", 0, 59, nil},
+		{&schedule.hour, fields[1], "hour", 0, 23, nil},
+		{&schedule.dayOfMonth, fields[2], "day-of-month", 1, 31, nil},
+		{&schedule.month, fields[3], "month", 1, 12, monthNames},
+		{&schedule.dayOfWeek, fields[4], "day-of-week", 0, 7, weekdayNames},
+	} {
+		if *f.bits, err = parseCronField(f.value, f.min, f.max, f.names); err != nil {
+			return nil, fmt.Errorf("schedule %q: %s field: %w", expr, f.name, err)
+		}
+	}
+	// Sunday may be written as 7
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField returns the bit set of the values field matches between min
+// and max
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		low, high := min, max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = cronValue(lowPart, min, max, names); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = cronValue(highPart, min, max, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "5/15" means from 5 to the end in steps of 15
+				high = max
+			}
+			if low > high {
+				return 0, fmt.Errorf("range %q runs backwards", rangePart)
+			}
+		}
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// cronValue parses a number or name of a cron field and checks its bounds
+func cronValue(s string, min, max int, names map[string]int) (int, error) {
+	if value, ok := names[strings.ToLower(s)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if value < min || value > max {
+		return 0, fmt.Errorf("value %d is outside %d-%d", value, min, max)
+	}
+	return value, nil
+}
+
+// matchesDay reports whether the day of t matches the day-of-month and
+// day-of-week fields
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// next returns the first minute strictly after t the schedule matches, in t's
+// location, or the zero time if there is none within maxScheduleSearch
+func (s *cronSchedule) next(t time.Time) time.Time {
+	limit := t.Add(maxScheduleSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// syncWindow is a parsed spec.syncWindow
+type syncWindow struct {
+	schedule *cronSchedule
+	duration time.Duration
+	location *time.Location
+}
+
+// parseSyncWindow parses the schedule and time zone of window
+func parseSyncWindow(window *n8nv1alpha1.SyncWindow) (*syncWindow, error) {
+	schedule, err := parseCronSchedule(window.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if window.Duration.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive, got %s", window.Duration.Duration)
+	}
+	location := time.UTC
+	if window.TimeZone != "" {
+		if location, err = time.LoadLocation(window.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid timeZone %q: %w", window.TimeZone, err)
+		}
+	}
+	if schedule.next(time.Now().In(location)).IsZero() {
+		return nil, fmt.Errorf("schedule %q never matches", window.Schedule)
+	}
+	return &syncWindow{schedule: schedule, duration: window.Duration.Duration, location: location}, nil
+}
+
+// state reports whether the window is open at now, and when it closes if it
+// is or next opens if it isn't. A window opening at s is open from s up to but
+// excluding s plus the duration.
+func (w *syncWindow) state(now time.Time) (open bool, change time.Time) {
+	start := w.schedule.next(now.In(w.location).Add(-w.duration))
+	if start.IsZero() {
+		return false, time.Time{}
+	}
+	if !start.After(now) {
+		return true, start.Add(w.duration)
+	}
+	return false, start
+}
+
+// checkSyncWindow reports whether the workflow's sync window is closed now and
+// when it next opens. Workflows without a window are never closed.
+func (r *N8nWorkflowReconciler) checkSyncWindow(workflow *n8nv1alpha1.N8nWorkflow) (bool, time.Time, error) {
+	if workflow.Spec.SyncWindow == nil {
+		return false, time.Time{}, nil
+	}
+	window, err := parseSyncWindow(workflow.Spec.SyncWindow)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	open, change := window.state(time.Now())
+	if open {
+		return false, time.Time{}, nil
+	}
+	return true, change, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Sync windows", func() {
+	at := func(value string) time.Time {
+		t, err := time.Parse(time.RFC3339, value)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+	window := func(schedule, duration, timeZone string) *syncWindow {
+		d, err := time.ParseDuration(duration)
+		Expect(err).NotTo(HaveOccurred())
+		parsed, err := parseSyncWindow(&n8nv1alpha1.SyncWindow{
+			Schedule: schedule, Duration: metav1.Duration{Duration: d}, TimeZone: timeZone,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return parsed
+	}
+
+	// 2026-10-16 is a Friday
+	DescribeTable("should open and close a weekday business-hours window at its boundaries",
+		func(now string, open bool, change string) {
+			isOpen, changeAt := window("0 9 * * 1-5", "8h", "").state(at(now))
+			Expect(isOpen).To(Equal(open))
+			Expect(changeAt).To(BeTemporally("==", at(change)))
+		},
+		Entry("closed just before it opens", "2026-10-16T08:59:59Z", false, "2026-10-16T09:00:00Z"),
+		Entry("open the moment it opens", "2026-10-16T09:00:00Z", true, "2026-10-16T17:00:00Z"),
+		Entry("open just before it closes", "2026-10-16T16:59:59Z", true, "2026-10-16T17:00:00Z"),
+		Entry("closed the moment it closes", "2026-10-16T17:00:00Z", false, "2026-10-19T09:00:00Z"),
+		Entry("closed over the weekend", "2026-10-17T12:00:00Z", false, "2026-10-19T09:00:00Z"),
+	)
+
+	It("should evaluate the schedule in its time zone", func() {
+		berlin := window("0 9 * * 1-5", "8h", "Europe/Berlin")
+		// 09:00 CEST is 07:00 UTC
+		open, closes := berlin.state(at("2026-10-16T07:00:00Z"))
+		Expect(open).To(BeTrue())
+		Expect(closes).To(BeTemporally("==", at("2026-10-16T15:00:00Z")))
+
+		// After the switch to CET, 09:00 is 08:00 UTC
+		open, opens := berlin.state(at("2026-10-26T07:30:00Z"))
+		Expect(open).To(BeFalse())
+		Expect(opens).To(BeTemporally("==", at("2026-10-26T08:00:00Z")))
+	})
+
+	It("should span midnight and overlap windows", func() {
+		open, closes := window("0 22 * * *", "4h", "").state(at("2026-10-17T01:30:00Z"))
+		Expect(open).To(BeTrue())
+		Expect(closes).To(BeTemporally("==", at("2026-10-17T02:00:00Z")))
+
+		open, _ = window("*/15 * * * *", "15m", "").state(at("2026-10-16T13:37:00Z"))
+		Expect(open).To(BeTrue())
+	})
+
+	DescribeTable("should find the next match of a schedule",
+		func(schedule, after, expected string) {
+			parsed, err := parseCronSchedule(schedule)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.next(at(after))).To(BeTemporally("==", at(expected)))
+		},
+		Entry("steps", "*/15 * * * *", "2026-10-16T10:07:00Z", "2026-10-16T10:15:00Z"),
+		Entry("steps from a start", "5/20 * * * *", "2026-10-16T10:26:00Z", "2026-10-16T10:45:00Z"),
+		Entry("only strictly after", "0 9 * * *", "2026-10-16T09:00:00Z", "2026-10-17T09:00:00Z"),
+		Entry("ranges with steps", "0 9-17/4 * * *", "2026-10-16T13:30:00Z", "2026-10-16T17:00:00Z"),
+		Entry("lists and names", "0 6 * jan,oct sat,sun", "2026-10-16T10:00:00Z", "2026-10-17T06:00:00Z"),
+		Entry("Sunday as 7", "0 6 * * 7", "2026-10-16T10:00:00Z", "2026-10-18T06:00:00Z"),
+		Entry("either restricted day field", "0 0 1 * mon", "2026-10-16T10:00:00Z", "2026-10-19T00:00:00Z"),
+		Entry("leap days", "0 0 29 2 *", "2026-10-16T10:00:00Z", "2028-02-29T00:00:00Z"),
+	)
+
+	DescribeTable("should reject invalid windows",
+		func(schedule, timeZone, message string) {
+			_, err := parseSyncWindow(&n8nv1alpha1.SyncWindow{
+				Schedule: schedule, Duration: metav1.Duration{Duration: time.Hour}, TimeZone: timeZone,
+			})
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("too few fields", "0 9 * *", "", "must have 5 fields"),
+		Entry("out of range", "0 24 * * *", "", "hour field: value 24 is outside 0-23"),
+		Entry("backwards range", "0 17-9 * * *", "", "runs backwards"),
+		Entry("bad step", "*/0 * * * *", "", "invalid step"),
+		Entry("unknown name", "0 9 * * funday", "", `invalid value "funday"`),
+		Entry("impossible date", "0 0 30 2 *", "", "never matches"),
+		Entry("unknown time zone", "0 9 * * *", "Mars/Olympus", "invalid timeZone"),
+	)
+})
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// folderTagPrefix starts the reserved tag spec.folder is attached as
+const folderTagPrefix = "folder:"
+
+// inlineTags resolves the desired tags of a workflow that isn't tracked yet, so
+// they can be attached by the request creating it, and sets them on desired.
+// Whether n8n accepts tags on create is decided from the version the instance
+// reported. It returns the resolved name to ID mapping for syncTags, or nil
+// when the instance's release treats tags as read-only on create, its version
+// is unknown or the tags can't be resolved, in which case syncTags associates
+// them after the workflow is written.
+func (r *N8nWorkflowReconciler) inlineTags(ctx context.Context, n8nClient *n8n.Client, workflow *n8nv1alpha1.N8nWorkflow, instance *n8nv1alpha1.N8nInstance, desired *n8n.Workflow) map[string]string {
+	tags := desiredTags(workflow)
+	if workflow.Status.WorkflowID != "" || len(tags) == 0 || !n8nClient.InlineTagsSupported(instance.Status.Version) {
+		return nil
+	}
+	byName, err := resolveTags(ctx, n8nClient, tags)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info("Could not resolve tags before creating the workflow", "error", err.Error())
+		return nil
+	}
+	for _, name := range tags {
+		desired.Tags = append(desired.Tags, map[string]any{"id": byName[name], "name": name})
+	}
+	return byName
+}
+
+// syncTags attaches the tags named in spec.workflow.tags and the folder tag to
+// the synced workflow, creating missing tags in n8n first, and records the name
+// to ID mapping in status. Tags already attached on create are left alone; on
+// update, and on create where n8n treats tags as read-only, they are associated
+// in a second call. resolved is the mapping used by inlineTags, if any, so the
+// tags aren't looked up twice. It reports whether the workflow's tags were changed.
+func (r *N8nWorkflowReconciler) syncTags(ctx context.Context, n8nClient *n8n.Client, workflow *n8nv1alpha1.N8nWorkflow, current *n8n.Workflow, resolved map[string]string) (bool, error) {
+	desired := desiredTags(workflow)
+	// Leave tags alone unless the spec sets them or they were set before
+	if len(desired) == 0 && len(workflow.Status.TagIDs) == 0 {
+		return false, nil
+	}
+
+	attached := workflowTagIDs(current)
+	if sameTagNames(attached, desired) {
+		workflow.Status.TagIDs = tagIDMap(attached, desired)
+		return false, nil
+	}
+
+	byName := resolved
+	if byName == nil {
+		var err error
+		if byName, err = resolveTags(ctx, n8nClient, desired); err != nil {
+			return false, err
+		}
+	}
+	ids := make([]string, 0, len(desired))
+	for _, name := range desired {
+		ids = append(ids, byName[name])
+	}
+
+	logf.FromContext(ctx).Info("Updating workflow tags", "id", current.ID, "tags", desired)
+	tags, err := n8nClient.UpdateWorkflowTags(ctx, current.ID, ids)
+	if err != nil {
+		return false, err
+	}
+	attached = make(map[string]string, len(tags))
+	for _, tag := range tags {
+		attached[tag.Name] = tag.ID
+	}
+	workflow.Status.TagIDs = tagIDMap(attached, desired)
+	return true, nil
+}
+
+// resolveTags maps each of the named tags to its ID in n8n, creating the tags
+// that don't exist yet
+func resolveTags(ctx context.Context, n8nClient *n8n.Client, names []string) (map[string]string, error) {
+	existing, err := n8nClient.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]string, len(existing))
+	for _, tag := range existing {
+		byName[tag.Name] = tag.ID
+	}
+
+	for _, name := range names {
+		if _, ok := byName[name]; ok {
+			continue
+		}
+		logf.FromContext(ctx).Info("Creating tag in n8n", "tag", name)
+		created, err := n8nClient.CreateTag(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = created.ID
+	}
+	return byName, nil
+}
+
+// desiredTags returns the tags the workflow should carry, sorted and without
+// duplicates: spec.workflow.tags, minus any folder tags, plus the tag of
+// spec.folder. Setting the whole list replaces a stale folder tag, so a
+// workflow is in at most one folder.
+func desiredTags(workflow *n8nv1alpha1.N8nWorkflow) []string {
+	tags := make([]string, 0, len(workflow.Spec.Workflow.Tags)+1)
+	for _, tag := range workflow.Spec.Workflow.Tags {
+		if !strings.HasPrefix(tag, folderTagPrefix) {
+			tags = append(tags, tag)
+		}
+	}
+	if workflow.Spec.Folder != "" {
+		tags = append(tags, folderTagPrefix+workflow.Spec.Folder)
+	}
+	return uniqueSorted(tags)
+}
+
+// splitFolderTag separates the folder tag from the other tags of an n8n
+// workflow. With several folder tags, the first in order wins.
+func splitFolderTag(tags []string) (string, []string) {
+	folder := ""
+	var rest []string
+	for _, tag := range uniqueSorted(tags) {
+		if name, ok := strings.CutPrefix(tag, folderTagPrefix); ok {
+			if folder == "" {
+				folder = name
+			}
+			continue
+		}
+		rest = append(rest, tag)
+	}
+	return folder, rest
+}
+
+// workflowTagIDs maps the names of the tags attached to an n8n workflow to their IDs
+func workflowTagIDs(wf *n8n.Workflow) map[string]string {
+	ids := make(map[string]string, len(wf.Tags))
+	for _, tag := range wf.Tags {
+		name, _ := tag["name"].(string)
+		id, _ := tag["id"].(string)
+		if name != "" {
+			ids[name] = id
+		}
+	}
+	return ids
+}
+
+// sameTagNames reports whether exactly the desired tags are attached
+func sameTagNames(attached map[string]string, desired []string) bool {
+	if len(attached) != len(desired) {
+		return false
+	}
+	for _, name := range desired {
+		if _, ok := attached[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// tagIDMap returns the IDs of the desired tags, or nil when there are none
+func tagIDMap(attached map[string]string, desired []string) map[string]string {
+	if len(desired) == 0 {
+		return nil
+	}
+	ids := make(map[string]string, len(desired))
+	for _, name := range desired {
+		ids[name] = attached[name]
+	}
+	return ids
+}
+
+// uniqueSorted returns the non-empty values without duplicates, sorted
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
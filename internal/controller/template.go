@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// templateParameterPattern matches ${params.NAME} placeholders and their
+// $${params.NAME} escapes
+var templateParameterPattern = regexp.MustCompile(`\$?\$\{params\.([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// TemplateParameterError reports why a workflow's parameters can't render its
+// template: required parameters it doesn't set, parameters the template doesn't
+// declare, and placeholders in the template for parameters it doesn't declare
+type TemplateParameterError struct {
+	Template   string
+	Missing    []string
+	Unknown    []string
+	Undeclared []string
+}
+
+func (e *TemplateParameterError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing required parameters "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "parameters not declared by the template "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Undeclared) > 0 {
+		placeholders := make([]string, len(e.Undeclared))
+		for i, name := range e.Undeclared {
+			placeholders[i] = "${params." + name + "}"
+		}
+		problems = append(problems, "placeholders for undeclared parameters "+strings.Join(placeholders, ", "))
+	}
+	return fmt.Sprintf("N8nWorkflowTemplate %q: %s", e.Template, strings.Join(problems, "; "))
+}
+
+// loadTemplate fetches the N8nWorkflowTemplate named by spec.templateRef
+func (r *N8nWorkflowReconciler) loadTemplate(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (*n8nv1alpha1.N8nWorkflowTemplate, error) {
+	name := workflow.Spec.TemplateRef.Name
+	template := &n8nv1alpha1.N8nWorkflowTemplate{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: name}, template); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("templateRef: N8nWorkflowTemplate %q not found", name)
+		}
+		return nil, fmt.Errorf("failed to get N8nWorkflowTemplate %q: %w", name, err)
+	}
+	return template, nil
+}
+
+// applyTemplate renders template with the workflow's parameters into its
+// nodes, connections and, unless the workflow sets its own, settings. Nothing
+// is changed when an error is returned.
+func applyTemplate(workflow *n8nv1alpha1.N8nWorkflow, template *n8nv1alpha1.N8nWorkflowTemplate) error {
+	rendered, err := renderTemplate(template, workflow.Spec.Parameters)
+	if err != nil {
+		return err
+	}
+
+	spec := &workflow.Spec.Workflow
+	spec.Nodes = rendered.Nodes
+	spec.Connections = rendered.Connections
+	if spec.Settings == nil || len(spec.Settings.Raw) == 0 {
+		spec.Settings = rendered.Settings
+	}
+	return nil
+}
+
+// renderTemplate returns the body of template with its ${params.NAME}
+// placeholders replaced by parameters, falling back to the declared defaults.
+// Every problem with the parameters is reported in one *TemplateParameterError.
+func renderTemplate(template *n8nv1alpha1.N8nWorkflowTemplate, parameters map[string]string) (*n8nv1alpha1.N8nWorkflowTemplateSpec, error) {
+	paramErr := &TemplateParameterError{Template: template.Name}
+	declared := make(map[string]bool, len(template.Spec.Parameters))
+	values := make(map[string]string, len(template.Spec.Parameters))
+	for _, param := range template.Spec.Parameters {
+		declared[param.Name] = true
+		if value, ok := parameters[param.Name]; ok {
+			values[param.Name] = value
+		} else if param.Default != nil {
+			values[param.Name] = *param.Default
+		} else {
+			paramErr.Missing = append(paramErr.Missing, param.Name)
+		}
+	}
+	for name := range parameters {
+		if !declared[name] {
+			paramErr.Unknown = append(paramErr.Unknown, name)
+		}
+	}
+	sort.Strings(paramErr.Unknown)
+
+	undeclared := map[string]bool{}
+	expand := func(s string) string {
+		return templateParameterPattern.ReplaceAllStringFunc(s, func(match string) string {
+			// $${params.NAME} escapes a literal ${params.NAME}
+			if strings.HasPrefix(match, "$$") {
+				return match[1:]
+			}
+			name := match[len("${params.") : len(match)-1]
+			if !declared[name] {
+				undeclared[name] = true
+			}
+			if value, ok := values[name]; ok {
+				return value
+			}
+			return match
+		})
+	}
+
+	rendered := &n8nv1alpha1.N8nWorkflowTemplateSpec{}
+	for i, node := range template.Spec.Nodes {
+		raw, err := renderRaw(&node, expand)
+		if err != nil {
+			return nil, fmt.Errorf("N8nWorkflowTemplate %q: node %d: %w", template.Name, i, err)
+		}
+		rendered.Nodes = append(rendered.Nodes, *raw)
+	}
+	var err error
+	if rendered.Connections, err = renderRaw(template.Spec.Connections, expand); err != nil {
+		return nil, fmt.Errorf("N8nWorkflowTemplate %q: connections: %w", template.Name, err)
+	}
+	if rendered.Settings, err = renderRaw(template.Spec.Settings, expand); err != nil {
+		return nil, fmt.Errorf("N8nWorkflowTemplate %q: settings: %w", template.Name, err)
+	}
+
+	if len(undeclared) > 0 {
+		paramErr.Undeclared = sortedKeys(undeclared)
+	}
+	if len(paramErr.Missing) > 0 || len(paramErr.Unknown) > 0 || len(paramErr.Undeclared) > 0 {
+		return nil, paramErr
+	}
+	return rendered, nil
+}
+
+// renderRaw returns a copy of raw with expand applied to every string in it,
+// object keys included, since connections are keyed by node name
+func renderRaw(raw *runtime.RawExtension, expand func(string) string) (*runtime.RawExtension, error) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, nil
+	}
+	var decoded any
+	if err := json.Unmarshal(raw.Raw, &decoded); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(renderValue(decoded, expand))
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: data}, nil
+}
+
+// renderValue walks decoded JSON, applying expand to keys and strings
+func renderValue(value any, expand func(string) string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		rendered := make(map[string]any, len(v))
+		for key, child := range v {
+			rendered[expand(key)] = renderValue(child, expand)
+		}
+		return rendered
+	case []any:
+		for i, child := range v {
+			v[i] = renderValue(child, expand)
+		}
+	case string:
+		return expand(v)
+	}
+	return value
+}
+
+// workflowsForTemplate maps an N8nWorkflowTemplate to the workflows in its
+// namespace rendered from it
+func (r *N8nWorkflowReconciler) workflowsForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	workflows := &n8nv1alpha1.N8nWorkflowList{}
+	if err := r.List(ctx, workflows, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list workflows for template", "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, workflow := range workflows.Items {
+		if ref := workflow.Spec.TemplateRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&workflow)})
+		}
+	}
+	return requests
+}
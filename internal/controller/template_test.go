@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Workflow templates", func() {
+	newTemplate := func(node string, params ...n8nv1alpha1.WorkflowTemplateParameter) *n8nv1alpha1.N8nWorkflowTemplate {
+		return &n8nv1alpha1.N8nWorkflowTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "alert-to-slack", Namespace: "default"},
+			Spec: n8nv1alpha1.N8nWorkflowTemplateSpec{
+				Parameters: params,
+				Nodes:      []runtime.RawExtension{{Raw: []byte(node)}},
+			},
+		}
+	}
+	required := func(name string) n8nv1alpha1.WorkflowTemplateParameter {
+		return n8nv1alpha1.WorkflowTemplateParameter{Name: name}
+	}
+	withDefault := func(name, value string) n8nv1alpha1.WorkflowTemplateParameter {
+		return n8nv1alpha1.WorkflowTemplateParameter{Name: name, Default: &value}
+	}
+	decode := func(raw runtime.RawExtension) map[string]any {
+		var node map[string]any
+		Expect(json.Unmarshal(raw.Raw, &node)).To(Succeed())
+		return node
+	}
+
+	It("should substitute parameters into nested strings", func() {
+		template := newTemplate(
+			`{"name":"Post","parameters":{"channel":"${params.channel}","options":{"tags":["team-${params.team}"]},"retries":3}}`,
+			required("channel"), required("team"))
+		rendered, err := renderTemplate(template, map[string]string{"channel": "#alerts", "team": "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decode(rendered.Nodes[0])).To(Equal(map[string]any{
+			"name": "Post",
+			"parameters": map[string]any{
+				"channel": "#alerts",
+				"options": map[string]any{"tags": []any{"team-a"}},
+				"retries": float64(3),
+			},
+		}))
+	})
+
+	It("should substitute parameters into node names and the connections keyed by them", func() {
+		template := newTemplate(`{"name":"Post to ${params.team}"}`, required("team"))
+		template.Spec.Connections = &runtime.RawExtension{
+			Raw: []byte(`{"Trigger":{"main":[[{"node":"Post to ${params.team}","type":"main","index":0}]]},` +
+				`"Post to ${params.team}":{"main":[[]]}}`),
+		}
+		rendered, err := renderTemplate(template, map[string]string{"team": "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decode(rendered.Nodes[0])["name"]).To(Equal("Post to a"))
+		Expect(rendered.Connections.Raw).To(MatchJSON(
+			`{"Trigger":{"main":[[{"node":"Post to a","type":"main","index":0}]]},"Post to a":{"main":[[]]}}`))
+	})
+
+	It("should fall back to defaults and keep escapes, variables and n8n expressions", func() {
+		template := newTemplate(
+			`{"name":"Post","parameters":{"text":"[${params.severity}] {{ $json.msg }} $${params.severity} ${TOKEN}"}}`,
+			withDefault("severity", "warning"))
+		rendered, err := renderTemplate(template, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decode(rendered.Nodes[0])["parameters"]).To(HaveKeyWithValue("text",
+			"[warning] {{ $json.msg }} ${params.severity} ${TOKEN}"))
+	})
+
+	It("should let a parameter override its default", func() {
+		template := newTemplate(`{"name":"${params.severity}"}`, withDefault("severity", "warning"))
+		rendered, err := renderTemplate(template, map[string]string{"severity": "critical"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decode(rendered.Nodes[0])["name"]).To(Equal("critical"))
+	})
+
+	It("should report every missing required parameter", func() {
+		template := newTemplate(`{"name":"Post","parameters":{"channel":"${params.channel}"}}`,
+			required("channel"), required("team"), withDefault("severity", "warning"))
+		_, err := renderTemplate(template, map[string]string{})
+		Expect(err).To(Equal(&TemplateParameterError{Template: "alert-to-slack", Missing: []string{"channel", "team"}}))
+		Expect(err).To(MatchError(`N8nWorkflowTemplate "alert-to-slack": missing required parameters channel, team`))
+	})
+
+	It("should report parameters and placeholders the template doesn't declare", func() {
+		template := newTemplate(`{"name":"Post","parameters":{"channel":"${params.chanel}"}}`, required("channel"))
+		_, err := renderTemplate(template, map[string]string{"channel": "#alerts", "colour": "red"})
+		Expect(err).To(MatchError(`N8nWorkflowTemplate "alert-to-slack": parameters not declared by the template colour; ` +
+			`placeholders for undeclared parameters ${params.chanel}`))
+	})
+
+	It("should use the template's settings only when the workflow sets none", func() {
+		template := newTemplate(`{"name":"Post"}`)
+		template.Spec.Settings = &runtime.RawExtension{Raw: []byte(`{"executionOrder":"v1"}`)}
+
+		workflow := &n8nv1alpha1.N8nWorkflow{}
+		Expect(applyTemplate(workflow, template)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Nodes).To(HaveLen(1))
+		Expect(workflow.Spec.Workflow.Settings.Raw).To(MatchJSON(`{"executionOrder":"v1"}`))
+
+		workflow.Spec.Workflow.Settings = &runtime.RawExtension{Raw: []byte(`{"timezone":"UTC"}`)}
+		Expect(applyTemplate(workflow, template)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Settings.Raw).To(MatchJSON(`{"timezone":"UTC"}`))
+	})
+
+	It("should leave the workflow alone when parameters are invalid", func() {
+		workflow := &n8nv1alpha1.N8nWorkflow{}
+		Expect(applyTemplate(workflow, newTemplate(`{"name":"${params.team}"}`, required("team")))).
+			To(BeAssignableToTypeOf(&TemplateParameterError{}))
+		Expect(workflow.Spec.Workflow.Nodes).To(BeEmpty())
+	})
+})
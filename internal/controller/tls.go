@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// instanceTLSConfig builds the TLS config described by the instance's
+// spec.tls, reading the CA bundle from the instance's namespace. It returns
+// nil when spec.tls is unset, so the default transport is used.
+func instanceTLSConfig(ctx context.Context, reader client.Reader, instance *n8nv1alpha1.N8nInstance) (*tls.Config, error) {
+	spec := instance.Spec.TLS
+	if spec == nil {
+		return nil, nil
+	}
+
+	var caPEM []byte
+	if spec.CA != nil {
+		var err error
+		if caPEM, err = loadCABundle(ctx, reader, instance.Namespace, spec.CA); err != nil {
+			return nil, err
+		}
+	}
+	return n8n.NewTLSConfig(caPEM, spec.InsecureSkipVerify)
+}
+
+// loadCABundle reads the PEM CA bundle selected by source. An optional source
+// that is missing yields no bundle.
+func loadCABundle(ctx context.Context, reader client.Reader, namespace string, source *n8nv1alpha1.CABundleSource) ([]byte, error) {
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		configMap := &corev1.ConfigMap{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, configMap); err != nil {
+			if errors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get CA ConfigMap %q: %w", ref.Name, err)
+		}
+		if value, ok := configMap.Data[ref.Key]; ok {
+			return []byte(value), nil
+		}
+		if value, ok := configMap.BinaryData[ref.Key]; ok {
+			return value, nil
+		}
+		if ref.Optional != nil && *ref.Optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("CA ConfigMap %q does not contain key %q", ref.Name, ref.Key)
+	case source.SecretKeyRef != nil:
+		ref := source.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			if errors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get CA Secret %q: %w", ref.Name, err)
+		}
+		if value, ok := secret.Data[ref.Key]; ok {
+			return value, nil
+		}
+		if ref.Optional != nil && *ref.Optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("CA Secret %q does not contain key %q", ref.Name, ref.Key)
+	}
+	return nil, fmt.Errorf("tls.ca must set configMapKeyRef or secretKeyRef")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// uniqueNameAnnotation opts a workflow into being renamed when its name is
+// taken in n8n by a workflow it doesn't own, e.g. for preview environments
+// stamped out from the same template
+const uniqueNameAnnotation = "n8n.slys.dev/unique-name"
+
+// uniqueNameCandidates returns the names tried for workflow in order: the name
+// from the spec, then the name suffixed with the namespace, then with the
+// namespace and a hash of the resource UID
+func uniqueNameCandidates(name string, workflow *n8nv1alpha1.N8nWorkflow) []string {
+	sum := sha256.Sum256([]byte(workflow.UID))
+	return []string{
+		name,
+		fmt.Sprintf("%s (%s)", name, workflow.Namespace),
+		fmt.Sprintf("%s (%s-%s)", name, workflow.Namespace, hex.EncodeToString(sum[:])[:6]),
+	}
+}
+
+// effectiveWorkflowName returns the name to sync workflow under. Without the
+// unique-name annotation that is name itself. With it, the first candidate not
+// taken by a workflow another resource or nobody owns is used, keeping the
+// name recorded in status once the workflow exists so it is never renamed back
+// and forth. The result is recorded in status.effectiveName.
+func effectiveWorkflowName(ctx context.Context, client WorkflowClient, workflow *n8nv1alpha1.N8nWorkflow,
+	name string) (string, error) {
+	if workflow.Annotations[uniqueNameAnnotation] != "true" {
+		workflow.Status.EffectiveName = ""
+		return name, nil
+	}
+
+	candidates := uniqueNameCandidates(name, workflow)
+	if workflow.Status.WorkflowID != "" && slices.Contains(candidates, workflow.Status.EffectiveName) {
+		return workflow.Status.EffectiveName, nil
+	}
+
+	owner := SyncOptions{OwnerUID: string(workflow.UID), OwnerName: workflow.Namespace + "/" + workflow.Name}
+	for _, candidate := range candidates {
+		workflows, err := client.ListWorkflows(ctx, &n8n.ListWorkflowsOptions{Name: candidate})
+		if err != nil {
+			return "", err
+		}
+		if !nameTaken(workflows, candidate, workflow.Status.WorkflowID, owner) {
+			workflow.Status.EffectiveName = candidate
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("workflow names %q are all taken in n8n by workflows %s/%s doesn't own",
+		candidates, workflow.Namespace, workflow.Name)
+}
+
+// nameTaken reports whether a workflow named name exists in workflows that is
+// neither the tracked one nor owned by the resource described by owner
+func nameTaken(workflows []n8n.Workflow, name, trackedID string, owner SyncOptions) bool {
+	for i := range workflows {
+		candidate := &workflows[i]
+		if candidate.Name != name || (trackedID != "" && candidate.ID == trackedID) {
+			continue
+		}
+		switch ownershipOf(candidate, owner) {
+		case ownedBySelf, ownedBySameName:
+			continue
+		}
+		return true
+	}
+	return false
+}
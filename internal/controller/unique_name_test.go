@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("Unique workflow names", func() {
+	ctx := context.Background()
+
+	newWorkflow := func(annotated bool) *n8nv1alpha1.N8nWorkflow {
+		workflow := &n8nv1alpha1.N8nWorkflow{ObjectMeta: metav1.ObjectMeta{
+			Name: "orders", Namespace: "preview-42", UID: "uid-orders",
+		}}
+		if annotated {
+			workflow.Annotations = map[string]string{uniqueNameAnnotation: "true"}
+		}
+		return workflow
+	}
+	ownedBy := func(uid, owner string) map[string]any {
+		return map[string]any{OwnerUIDMetaKey: uid, OwnerMetaKey: owner}
+	}
+	hashedName := uniqueNameCandidates("Orders", newWorkflow(true))[2]
+
+	DescribeTable("should pick the first name no other workflow holds",
+		func(existing []n8n.Workflow, trackedID, expected string) {
+			workflow := newWorkflow(true)
+			workflow.Status.WorkflowID = trackedID
+			name, err := effectiveWorkflowName(ctx, newMemoryWorkflowClient(existing...), workflow, "Orders")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal(expected))
+			Expect(workflow.Status.EffectiveName).To(Equal(expected))
+		},
+		Entry("the spec name when it is free", nil, "", "Orders"),
+		Entry("the spec name when this resource owns the workflow",
+			[]n8n.Workflow{{ID: "wf-1", Name: "Orders", Meta: ownedBy("uid-orders", "preview-42/orders")}}, "", "Orders"),
+		Entry("the spec name when it is the tracked workflow",
+			[]n8n.Workflow{{ID: "wf-1", Name: "Orders"}}, "wf-1", "Orders"),
+		Entry("the namespace suffix when an unowned workflow has the name",
+			[]n8n.Workflow{{ID: "wf-1", Name: "Orders"}}, "", "Orders (preview-42)"),
+		Entry("the namespace suffix when another resource owns the name",
+			[]n8n.Workflow{{ID: "wf-1", Name: "Orders", Meta: ownedBy("uid-other", "preview-7/orders")}}, "", "Orders (preview-42)"),
+		Entry("the hash suffix when the namespace suffix is taken too",
+			[]n8n.Workflow{{ID: "wf-1", Name: "Orders"}, {ID: "wf-2", Name: "Orders (preview-42)"}}, "", hashedName),
+	)
+
+	It("should keep the name recorded for an existing workflow", func() {
+		workflow := newWorkflow(true)
+		workflow.Status.WorkflowID = "wf-2"
+		workflow.Status.EffectiveName = "Orders (preview-42)"
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-2", Name: "Orders (preview-42)"})
+
+		name, err := effectiveWorkflowName(ctx, client, workflow, "Orders")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("Orders (preview-42)"))
+		Expect(client.lists).To(BeZero())
+	})
+
+	It("should fail when every candidate is taken", func() {
+		client := newMemoryWorkflowClient(
+			n8n.Workflow{ID: "wf-1", Name: "Orders"},
+			n8n.Workflow{ID: "wf-2", Name: "Orders (preview-42)"},
+			n8n.Workflow{ID: "wf-3", Name: hashedName},
+		)
+		_, err := effectiveWorkflowName(ctx, client, newWorkflow(true), "Orders")
+		Expect(err).To(MatchError(ContainSubstring("are all taken")))
+	})
+
+	It("should leave the name alone without the annotation", func() {
+		workflow := newWorkflow(false)
+		workflow.Status.EffectiveName = "Orders (preview-42)"
+		client := newMemoryWorkflowClient(n8n.Workflow{ID: "wf-1", Name: "Orders"})
+
+		name, err := effectiveWorkflowName(ctx, client, workflow, "Orders")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("Orders"))
+		Expect(workflow.Status.EffectiveName).To(BeEmpty())
+		Expect(client.lists).To(BeZero())
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+// startURLMigration records a validated change of the instance URL. Dependent
+// workflows hold off syncing until advanceURLMigration releases them.
+func (r *N8nInstanceReconciler) startURLMigration(ctx context.Context, instance *n8nv1alpha1.N8nInstance, fromURL, toURL string) {
+	logf.FromContext(ctx).Info("Instance URL changed, migrating dependent workflows", "from", fromURL, "to", toURL)
+	instance.Status.URLMigration = &n8nv1alpha1.URLMigrationStatus{
+		FromURL:   fromURL,
+		ToURL:     toURL,
+		Phase:     n8nv1alpha1.URLMigrationInProgress,
+		StartedAt: metav1.Now(),
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, "URLChanged",
+		fmt.Sprintf("URL changed from %s to %s; re-syncing dependent workflows in batches", fromURL, toURL))
+}
+
+// advanceURLMigration releases the next batch of dependent workflows once the
+// batch interval has elapsed, recording it in the instance status. It returns
+// how long to wait before the next batch, or zero when the migration is complete.
+func (r *N8nInstanceReconciler) advanceURLMigration(ctx context.Context, instance *n8nv1alpha1.N8nInstance) (time.Duration, error) {
+	migration := instance.Status.URLMigration
+	interval := instance.GetMigrationBatchInterval()
+	if migration.LastBatchTime != nil {
+		if wait := interval - time.Since(migration.LastBatchTime.Time); wait > 0 {
+			return wait, nil
+		}
+	}
+
+	workflows := &n8nv1alpha1.N8nWorkflowList{}
+	if err := r.List(ctx, workflows); err != nil {
+		return 0, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	// The workflows themselves belong to their users, so the release is
+	// recorded on the instance by moving a cursor over their sorted keys
+	var total int32
+	var pending []string
+	for i := range workflows.Items {
+		workflow := &workflows.Items[i]
+		if !workflowUsesInstance(workflow, instance) {
+			continue
+		}
+		total++
+		if key := client.ObjectKeyFromObject(workflow).String(); !urlMigrationReleased(migration, key) {
+			pending = append(pending, key)
+		}
+	}
+	sort.Strings(pending)
+
+	batch := pending[:min(len(pending), instance.GetMigrationBatchSize())]
+	if len(batch) > 0 {
+		migration.ReleasedThrough = batch[len(batch)-1]
+	}
+
+	now := metav1.Now()
+	migration.LastBatchTime = &now
+	migration.Total = total
+	migration.Migrated = total - int32(len(pending)-len(batch))
+	logf.FromContext(ctx).Info("Released workflows to the new URL", "batch", len(batch),
+		"migrated", migration.Migrated, "total", migration.Total)
+
+	if len(batch) < len(pending) {
+		return interval, nil
+	}
+	migration.Phase = n8nv1alpha1.URLMigrationCompleted
+	migration.CompletedAt = &now
+	r.Recorder.Event(instance, corev1.EventTypeNormal, "URLMigrationCompleted",
+		fmt.Sprintf("All %d dependent workflows moved to %s", total, migration.ToURL))
+	return 0, nil
+}
+
+// urlMigrationReleased reports whether the migration released the workflow
+// with the given namespace/name key
+func urlMigrationReleased(migration *n8nv1alpha1.URLMigrationStatus, key string) bool {
+	return migration.ReleasedThrough != "" && key <= migration.ReleasedThrough
+}
+
+// urlMigrationPending reports whether the workflow must wait for an in-progress
+// URL migration to release it. Workflows waiting requeue after the batch
+// interval, so they notice the release without a watch on the instance status.
+func urlMigrationPending(instance *n8nv1alpha1.N8nInstance, workflow *n8nv1alpha1.N8nWorkflow) bool {
+	migration := instance.Status.URLMigration
+	return migration != nil && migration.Phase == n8nv1alpha1.URLMigrationInProgress &&
+		!urlMigrationReleased(migration, client.ObjectKeyFromObject(workflow).String())
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// ValidationSeverity classifies a validation issue
+type ValidationSeverity string
+
+const (
+	// ValidationError means n8n would reject the workflow or it cannot be converted
+	ValidationError ValidationSeverity = "Error"
+
+	// ValidationWarning flags a likely mistake that n8n would still accept
+	ValidationWarning ValidationSeverity = "Warning"
+)
+
+// ValidationIssue is a single finding from ValidateWorkflow
+type ValidationIssue struct {
+	Severity ValidationSeverity `json:"severity"`
+	// Field is the path of the offending field, e.g. spec.workflow.nodes[2]
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationResult holds every issue found for one N8nWorkflow
+type ValidationResult struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Issues    []ValidationIssue `json:"issues"`
+}
+
+// Valid reports whether the result contains no errors. Warnings are allowed.
+func (v *ValidationResult) Valid() bool {
+	for _, issue := range v.Issues {
+		if issue.Severity == ValidationError {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ValidationResult) add(severity ValidationSeverity, field, format string, args ...any) {
+	v.Issues = append(v.Issues, ValidationIssue{Severity: severity, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateWorkflow checks an N8nWorkflow entirely offline: it runs the same
+// conversion the controller uses, then validates nodes and connections and lints
+// for common mistakes. No n8n API or Kubernetes calls are made, so it is suitable
+// for CI gating of manifests. Sub-workflow references are only checked against
+// the node list, since resolving them needs the cluster.
+func ValidateWorkflow(workflow *n8nv1alpha1.N8nWorkflow) *ValidationResult {
+	result := &ValidationResult{Name: workflow.Name, Namespace: workflow.Namespace, Issues: []ValidationIssue{}}
+
+	if workflow.Spec.Workflow.Name == "" {
+		result.add(ValidationError, "spec.workflow.name", "workflow name is required")
+	}
+	if interval := workflow.Spec.ReconcileInterval; interval != nil && interval.Duration < n8nv1alpha1.MinReconcileInterval {
+		result.add(ValidationError, "spec.reconcileInterval", "reconcileInterval must be at least %s, got %s",
+			n8nv1alpha1.MinReconcileInterval, interval.Duration)
+	}
+	if window := workflow.Spec.SyncWindow; window != nil {
+		if _, err := parseSyncWindow(window); err != nil {
+			result.add(ValidationError, "spec.syncWindow", "%v", err)
+		}
+	}
+	for i, tag := range workflow.Spec.Workflow.Tags {
+		if strings.HasPrefix(tag, folderTagPrefix) {
+			result.add(ValidationWarning, fmt.Sprintf("spec.workflow.tags[%d]", i),
+				"tag %q is ignored; tags starting with %q are reserved for spec.folder", tag, folderTagPrefix)
+		}
+	}
+	if workflow.Spec.TemplateRef != nil {
+		if len(workflow.Spec.Workflow.Nodes) > 0 {
+			result.add(ValidationError, "spec.workflow.nodes", "nodes must be empty when templateRef is set")
+		}
+		if connections := workflow.Spec.Workflow.Connections; connections != nil && len(connections.Raw) > 0 {
+			result.add(ValidationError, "spec.workflow.connections", "connections must be empty when templateRef is set")
+		}
+		if workflow.Spec.Workflow.BaseFrom != nil {
+			result.add(ValidationError, "spec.workflow.baseFrom", "baseFrom can't be combined with templateRef")
+		}
+		result.add(ValidationWarning, "spec.templateRef",
+			"templates can't be resolved offline; the rendered workflow was not validated")
+		return result
+	}
+	if len(workflow.Spec.Parameters) > 0 {
+		result.add(ValidationError, "spec.parameters", "parameters are only used with templateRef")
+	}
+	if workflow.Spec.Workflow.BaseFrom != nil {
+		if len(workflow.Spec.Workflow.Nodes) > 0 {
+			result.add(ValidationError, "spec.workflow.nodes", "nodes must be empty when baseFrom is set")
+		}
+		if connections := workflow.Spec.Workflow.Connections; connections != nil && len(connections.Raw) > 0 {
+			result.add(ValidationError, "spec.workflow.connections", "connections must be empty when baseFrom is set")
+		}
+		result.add(ValidationWarning, "spec.workflow.baseFrom",
+			"base workflows can't be loaded offline; the patched workflow was not validated")
+		return result
+	}
+
+	// Unmarshal nodes one at a time so the error names the offending index
+	malformed := false
+	for i, node := range workflow.Spec.Workflow.Nodes {
+		var nodeMap map[string]any
+		if err := json.Unmarshal(node.Raw, &nodeMap); err != nil {
+			result.add(ValidationError, fmt.Sprintf("spec.workflow.nodes[%d]", i), "node is not a JSON object: %v", err)
+			malformed = true
+		}
+	}
+	if malformed {
+		return result
+	}
+
+	converted, err := (&N8nWorkflowReconciler{}).convertToN8nWorkflow(workflow)
+	if err != nil {
+		result.add(ValidationError, "spec.workflow", "conversion failed: %v", err)
+		return result
+	}
+
+	if len(workflow.Spec.Workflow.VariablesFrom) > 0 {
+		result.add(ValidationWarning, "spec.workflow.variablesFrom",
+			"variables from ConfigMaps and Secrets can't be resolved offline; placeholders were not substituted")
+	}
+
+	validateNodes(converted, result)
+	validateConnections(converted, result)
+	validateSubWorkflowRefs(converted, workflow.Spec.Workflow.SubWorkflowRefs, result)
+	lintWorkflow(converted, workflow.Spec.Active, result)
+	return result
+}
+
+// validateNodes requires every node to have a unique name and a type, and
+// webhook nodes to have a path
+func validateNodes(wf *n8n.Workflow, result *ValidationResult) {
+	seen := make(map[string]int, len(wf.Nodes))
+	for i, node := range wf.Nodes {
+		field := fmt.Sprintf("spec.workflow.nodes[%d]", i)
+		name, _ := node["name"].(string)
+		if name == "" {
+			result.add(ValidationError, field+".name", "node name is required")
+		} else if first, dup := seen[name]; dup {
+			result.add(ValidationError, field+".name", "duplicate node name %q (also nodes[%d])", name, first)
+		} else {
+			seen[name] = i
+		}
+		nodeType, _ := node["type"].(string)
+		if nodeType == "" {
+			result.add(ValidationError, field+".type", "node %q has no type", name)
+		}
+		if nodeType == "n8n-nodes-base.webhook" {
+			params, _ := node["parameters"].(map[string]any)
+			if path, _ := params["path"].(string); strings.TrimSpace(path) == "" {
+				result.add(ValidationError, field+".parameters.path", "webhook node %q has no path", name)
+			}
+		}
+	}
+}
+
+// validateConnections requires every connection source and target to be a known
+// node, and every cycle to have a way out
+func validateConnections(wf *n8n.Workflow, result *ValidationResult) {
+	names := nodeNames(wf)
+	for source, outputs := range wf.Connections {
+		field := fmt.Sprintf("spec.workflow.connections[%q]", source)
+		if !names[source] {
+			result.add(ValidationError, field, "connection source %q is not a node", source)
+		}
+		for _, target := range connectionTargets(outputs) {
+			if !names[target] {
+				result.add(ValidationError, field, "connection target %q is not a node", target)
+			}
+		}
+	}
+	for _, cycle := range buildWorkflowGraph(wf).closedCycles() {
+		result.add(ValidationError, "spec.workflow.connections", "%s", describeCycle(cycle))
+	}
+}
+
+// validateSubWorkflowRefs requires each reference to name an existing node
+func validateSubWorkflowRefs(wf *n8n.Workflow, refs []n8nv1alpha1.SubWorkflowRef, result *ValidationResult) {
+	names := nodeNames(wf)
+	for i, ref := range refs {
+		if !names[ref.Node] {
+			result.add(ValidationError, fmt.Sprintf("spec.workflow.subWorkflowRefs[%d].node", i),
+				"node %q not found in workflow", ref.Node)
+		}
+	}
+}
+
+// lintWorkflow warns about workflows n8n accepts but that are probably wrong
+func lintWorkflow(wf *n8n.Workflow, active bool, result *ValidationResult) {
+	if len(wf.Nodes) == 0 {
+		result.add(ValidationWarning, "spec.workflow.nodes", "workflow has no nodes")
+		return
+	}
+
+	hasTrigger := false
+	for _, node := range wf.Nodes {
+		if isTriggerNode(node) {
+			hasTrigger = true
+			break
+		}
+	}
+	if active && !hasTrigger {
+		result.add(ValidationWarning, "spec.active",
+			"workflow is active but has no trigger node, so n8n will refuse to activate it")
+	}
+
+	// Non-trigger nodes nothing connects to will never run
+	connected := map[string]bool{}
+	for _, outputs := range wf.Connections {
+		for _, target := range connectionTargets(outputs) {
+			connected[target] = true
+		}
+	}
+	for i, node := range wf.Nodes {
+		name, _ := node["name"].(string)
+		if name == "" || isTriggerNode(node) || connected[name] || isStickyNote(node) {
+			continue
+		}
+		result.add(ValidationWarning, fmt.Sprintf("spec.workflow.nodes[%d]", i),
+			"node %q has no incoming connections and will never run", name)
+	}
+
+	for _, finding := range detectSecrets(wf) {
+		result.add(ValidationWarning, "spec.workflow.nodes",
+			"%s; use n8n credentials instead", finding)
+	}
+}
+
+// nodeNames returns the set of node names in the workflow
+func nodeNames(wf *n8n.Workflow) map[string]bool {
+	names := make(map[string]bool, len(wf.Nodes))
+	for _, node := range wf.Nodes {
+		if name, ok := node["name"].(string); ok {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// connectionTargets flattens n8n's {type: [[{node: ...}]]} connection structure
+// into the list of target node names
+func connectionTargets(outputs any) []string {
+	var targets []string
+	byType, _ := outputs.(map[string]any)
+	for _, groups := range byType {
+		groupList, _ := groups.([]any)
+		for _, group := range groupList {
+			links, _ := group.([]any)
+			for _, link := range links {
+				linkMap, _ := link.(map[string]any)
+				if target, ok := linkMap["node"].(string); ok {
+					targets = append(targets, target)
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// isTriggerNode reports whether the node starts workflow executions
+func isTriggerNode(node map[string]any) bool {
+	nodeType, _ := node["type"].(string)
+	lower := strings.ToLower(nodeType)
+	return strings.HasSuffix(lower, "trigger") || strings.HasSuffix(lower, ".webhook") ||
+		strings.HasSuffix(lower, ".formtrigger") || strings.HasSuffix(lower, ".chattrigger")
+}
+
+// isStickyNote reports whether the node is a canvas annotation rather than a step
+func isStickyNote(node map[string]any) bool {
+	nodeType, _ := node["type"].(string)
+	return nodeType == "n8n-nodes-base.stickyNote"
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("Offline workflow validation", func() {
+	raw := func(value string) runtime.RawExtension {
+		return runtime.RawExtension{Raw: []byte(value)}
+	}
+
+	// newWorkflow builds a valid two-node workflow that individual cases break
+	newWorkflow := func() *n8nv1alpha1.N8nWorkflow {
+		return &n8nv1alpha1.N8nWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "validate", Namespace: "default"},
+			Spec: n8nv1alpha1.N8nWorkflowSpec{
+				InstanceRef: "n8n",
+				Active:      true,
+				Workflow: n8nv1alpha1.WorkflowSpec{
+					Name: "Validate Me",
+					Nodes: []runtime.RawExtension{
+						raw(`{"name":"Hook","type":"n8n-nodes-base.webhook","parameters":{"path":"hook"}}`),
+						raw(`{"name":"Set","type":"n8n-nodes-base.set"}`),
+					},
+					Connections: &runtime.RawExtension{
+						Raw: []byte(`{"Hook":{"main":[[{"node":"Set","type":"main","index":0}]]}}`),
+					},
+				},
+			},
+		}
+	}
+
+	// fields returns the field of every issue with the given severity
+	fields := func(result *ValidationResult, severity ValidationSeverity) []string {
+		var out []string
+		for _, issue := range result.Issues {
+			if issue.Severity == severity {
+				out = append(out, issue.Field)
+			}
+		}
+		return out
+	}
+
+	It("should accept a valid workflow without issues", func() {
+		result := ValidateWorkflow(newWorkflow())
+		Expect(result.Valid()).To(BeTrue())
+		Expect(result.Issues).To(BeEmpty())
+		Expect(result.Name).To(Equal("validate"))
+	})
+
+	It("should accept a workflow without instanceRef, which uses a default instance", func() {
+		workflow := newWorkflow()
+		workflow.Spec.InstanceRef = ""
+		Expect(ValidateWorkflow(workflow).Issues).To(BeEmpty())
+	})
+
+	DescribeTable("should report errors for invalid specs",
+		func(mutate func(*n8nv1alpha1.N8nWorkflow), field string) {
+			workflow := newWorkflow()
+			mutate(workflow)
+
+			result := ValidateWorkflow(workflow)
+			Expect(result.Valid()).To(BeFalse())
+			Expect(fields(result, ValidationError)).To(ContainElement(field))
+		},
+		Entry("reconcile interval below the minimum", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.ReconcileInterval = &metav1.Duration{Duration: time.Second}
+		}, "spec.reconcileInterval"),
+		Entry("sync window with an invalid schedule", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.SyncWindow = &n8nv1alpha1.SyncWindow{Schedule: "0 9 * *", Duration: metav1.Duration{Duration: time.Hour}}
+		}, "spec.syncWindow"),
+		Entry("malformed node JSON", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[1] = raw(`["Set"]`)
+		}, "spec.workflow.nodes[1]"),
+		Entry("duplicate node names", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[1] = raw(`{"name":"Hook","type":"n8n-nodes-base.set"}`)
+		}, "spec.workflow.nodes[1].name"),
+		Entry("node without a type", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[1] = raw(`{"name":"Set"}`)
+		}, "spec.workflow.nodes[1].type"),
+		Entry("webhook node without a path", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[0] = raw(`{"name":"Hook","type":"n8n-nodes-base.webhook","parameters":{}}`)
+		}, "spec.workflow.nodes[0].parameters.path"),
+		Entry("connection to an unknown node", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Connections = &runtime.RawExtension{
+				Raw: []byte(`{"Hook":{"main":[[{"node":"Missing","type":"main","index":0}]]}}`),
+			}
+		}, `spec.workflow.connections["Hook"]`),
+		Entry("connection from an unknown node", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Connections = &runtime.RawExtension{
+				Raw: []byte(`{"Ghost":{"main":[[{"node":"Set","type":"main","index":0}]]}}`),
+			}
+		}, `spec.workflow.connections["Ghost"]`),
+		Entry("cycle no node can leave", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Connections = &runtime.RawExtension{
+				Raw: []byte(`{"Hook":{"main":[[{"node":"Set","type":"main","index":0}]]},` +
+					`"Set":{"main":[[{"node":"Set","type":"main","index":0}]]}}`),
+			}
+		}, "spec.workflow.connections"),
+		Entry("sub-workflow reference to an unknown node", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.SubWorkflowRefs = []n8nv1alpha1.SubWorkflowRef{{Node: "Call", WorkflowRef: "child"}}
+		}, "spec.workflow.subWorkflowRefs[0].node"),
+		Entry("both pinData and pinDataFrom", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.PinData = &runtime.RawExtension{Raw: []byte(`{"Hook":[{"json":{}}]}`)}
+			w.Spec.Workflow.PinDataFrom = &n8nv1alpha1.PinDataSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "fixtures"}, Key: "pinData.json"}}
+		}, "spec.workflow"),
+		Entry("pinData for a node that doesn't exist", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.PinData = &runtime.RawExtension{Raw: []byte(`{"Webhook":[{"json":{}}]}`)}
+		}, "spec.workflow"),
+		Entry("pinData that isn't an array of items", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.PinData = &runtime.RawExtension{Raw: []byte(`{"Hook":{"json":{}}}`)}
+		}, "spec.workflow"),
+		Entry("pinDataFrom without a reference", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.PinDataFrom = &n8nv1alpha1.PinDataSource{}
+		}, "spec.workflow"),
+		Entry("baseFrom with inline nodes", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.BaseFrom = &n8nv1alpha1.BaseWorkflowSource{}
+		}, "spec.workflow.nodes"),
+		Entry("baseFrom with templateRef", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes, w.Spec.Workflow.Connections = nil, nil
+			w.Spec.Workflow.BaseFrom = &n8nv1alpha1.BaseWorkflowSource{}
+			w.Spec.TemplateRef = &n8nv1alpha1.WorkflowTemplateReference{Name: "base"}
+		}, "spec.workflow.baseFrom"),
+	)
+
+	DescribeTable("should lint with warnings that keep the workflow valid",
+		func(mutate func(*n8nv1alpha1.N8nWorkflow), field string) {
+			workflow := newWorkflow()
+			mutate(workflow)
+
+			result := ValidateWorkflow(workflow)
+			Expect(result.Valid()).To(BeTrue())
+			Expect(fields(result, ValidationWarning)).To(ContainElement(field))
+		},
+		Entry("active workflow without a trigger", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[0] = raw(`{"name":"Hook","type":"n8n-nodes-base.noOp"}`)
+		}, "spec.active"),
+		Entry("node nothing connects to", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Connections = nil
+		}, "spec.workflow.nodes[1]"),
+		Entry("literal token in a node parameter", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[1] = raw(`{"name":"Set","type":"n8n-nodes-base.set","parameters":{"apiToken":"abc123"}}`)
+		}, "spec.workflow.nodes"),
+		Entry("folder tag in the tags", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Tags = []string{"billing", "folder:payments"}
+		}, "spec.workflow.tags[1]"),
+		Entry("baseFrom that can't be loaded offline", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes, w.Spec.Workflow.Connections = nil, nil
+			w.Spec.Workflow.BaseFrom = &n8nv1alpha1.BaseWorkflowSource{}
+		}, "spec.workflow.baseFrom"),
+	)
+
+	It("should ignore sticky notes when looking for disconnected nodes", func() {
+		workflow := newWorkflow()
+		workflow.Spec.Workflow.Nodes = append(workflow.Spec.Workflow.Nodes,
+			raw(`{"name":"Note","type":"n8n-nodes-base.stickyNote"}`))
+		Expect(ValidateWorkflow(workflow).Issues).To(BeEmpty())
+	})
+
+	DescribeTable("should detect secret-looking node parameters",
+		func(parameters string, expected int) {
+			nodes := []runtime.RawExtension{raw(`{"name":"Call","type":"n8n-nodes-base.httpRequest","parameters":` + parameters + `}`)}
+			n8nWorkflow, err := (&N8nWorkflowReconciler{}).convertToN8nWorkflow(&n8nv1alpha1.N8nWorkflow{
+				Spec: n8nv1alpha1.N8nWorkflowSpec{Workflow: n8nv1alpha1.WorkflowSpec{Name: "Secrets", Nodes: nodes}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(detectSecrets(n8nWorkflow)).To(HaveLen(expected))
+		},
+		Entry("literal token", `{"token":"hunter2"}`, 1),
+		Entry("literal password nested in options", `{"options":{"password":"s3cret"}}`, 1),
+		Entry("authorization header pair",
+			`{"headerParameters":{"parameters":[{"name":"Authorization","value":"Bearer abc"}]}}`, 1),
+		Entry("high-entropy string under an innocent key", `{"body":"sk9Fq2LmZx7Rt4VbN8wPjK3hYc6DgE1s"}`, 1),
+		Entry("expression under a sensitive key", `{"token":"={{ $credentials.token }}"}`, 0),
+		Entry("URL", `{"url":"https://example.com/api/v1/items?page=2&limit=50"}`, 0),
+		Entry("UUID", `{"id":"3f2b8c1e-9a4d-4e7f-b6c2-1d5e8f0a7b93"}`, 0),
+		Entry("ordinary text", `{"text":"Hello from the nightly report job"}`, 0),
+	)
+})
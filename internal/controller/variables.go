@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// variablePattern matches ${NAME} placeholders and their $${NAME} escapes
+var variablePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// workflowVariables are the variables resolved for a workflow. secretValues
+// holds the values sourced from Secrets, which must never be surfaced.
+type workflowVariables struct {
+	values       map[string]string
+	secretValues []string
+}
+
+// loadVariables resolves spec.workflow.variablesFrom and spec.workflow.variables,
+// in that order of precedence from lowest to highest. It returns nil when
+// neither is set, so placeholders are left alone.
+func (r *N8nWorkflowReconciler) loadVariables(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (*workflowVariables, error) {
+	spec := workflow.Spec.Workflow
+	if spec.Variables == nil && len(spec.VariablesFrom) == 0 {
+		return nil, nil
+	}
+
+	vars := &workflowVariables{values: map[string]string{}}
+	for i, source := range spec.VariablesFrom {
+		switch {
+		case source.ConfigMapRef != nil && source.SecretRef == nil:
+			ref := source.ConfigMapRef
+			configMap := &corev1.ConfigMap{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: ref.Name}, configMap)
+			if err != nil {
+				if errors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+					continue
+				}
+				if errors.IsNotFound(err) {
+					return nil, fmt.Errorf("variablesFrom: ConfigMap %q not found", ref.Name)
+				}
+				return nil, fmt.Errorf("failed to get ConfigMap %q: %w", ref.Name, err)
+			}
+			for key, value := range configMap.Data {
+				vars.values[key] = value
+			}
+			for key, value := range configMap.BinaryData {
+				vars.values[key] = string(value)
+			}
+		case source.SecretRef != nil && source.ConfigMapRef == nil:
+			ref := source.SecretRef
+			secret := &corev1.Secret{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: workflow.Namespace, Name: ref.Name}, secret)
+			if err != nil {
+				if errors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+					continue
+				}
+				if errors.IsNotFound(err) {
+					return nil, fmt.Errorf("variablesFrom: Secret %q not found", ref.Name)
+				}
+				return nil, fmt.Errorf("failed to get Secret %q: %w", ref.Name, err)
+			}
+			for key, value := range secret.Data {
+				vars.values[key] = string(value)
+				if len(value) > 0 {
+					vars.secretValues = append(vars.secretValues, string(value))
+				}
+			}
+		default:
+			return nil, fmt.Errorf("variablesFrom[%d] must set exactly one of configMapRef and secretRef", i)
+		}
+	}
+	for key, value := range spec.Variables {
+		vars.values[key] = value
+	}
+	return vars, nil
+}
+
+// substituteVariables replaces ${NAME} placeholders in every string value of the
+// node JSON. Undefined placeholders are reported together, naming each one and
+// the node using it but never a value.
+func substituteVariables(n8nWorkflow *n8n.Workflow, values map[string]string) error {
+	var undefined []string
+	seen := map[string]bool{}
+	for _, node := range n8nWorkflow.Nodes {
+		name, _ := node["name"].(string)
+		missing := map[string]bool{}
+		expandValue(node, values, missing)
+		for _, placeholder := range sortedKeys(missing) {
+			entry := fmt.Sprintf("${%s} in node %q", placeholder, name)
+			if !seen[entry] {
+				seen[entry] = true
+				undefined = append(undefined, entry)
+			}
+		}
+	}
+	if len(undefined) > 0 {
+		return fmt.Errorf("undefined variable %s", strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+// expandValue walks decoded JSON, expanding placeholders in strings and
+// recording undefined names in missing
+func expandValue(value any, values map[string]string, missing map[string]bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			v[key] = expandValue(child, values, missing)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = expandValue(child, values, missing)
+		}
+	case string:
+		return variablePattern.ReplaceAllStringFunc(v, func(match string) string {
+			// $${NAME} escapes a literal ${NAME}
+			if strings.HasPrefix(match, "$$") {
+				return match[1:]
+			}
+			name := match[2 : len(match)-1]
+			replacement, ok := values[name]
+			if !ok {
+				missing[name] = true
+				return match
+			}
+			return replacement
+		})
+	}
+	return value
+}
+
+// sortedKeys returns the keys of set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// variablesHash folds the resolved variables into the spec hash, so editing a
+// variablesFrom ConfigMap or Secret triggers an update
+func variablesHash(specHash string, vars *workflowVariables) string {
+	if vars == nil || len(vars.values) == 0 {
+		return specHash
+	}
+
+	keys := make([]string, 0, len(vars.values))
+	for key := range vars.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	h.Write([]byte(specHash))
+	for _, key := range keys {
+		h.Write([]byte{0})
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(vars.values[key]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// webhookNodeType is the n8n node type that receives webhook calls
+const webhookNodeType = "n8n-nodes-base.webhook"
+
+// webhookCredentialTypes maps each webhook auth method to the n8n credential
+// type the node expects under its credentials key
+var webhookCredentialTypes = map[n8nv1alpha1.WebhookAuthType]string{
+	n8nv1alpha1.WebhookAuthBasic:  "httpBasicAuth",
+	n8nv1alpha1.WebhookAuthHeader: "httpHeaderAuth",
+	n8nv1alpha1.WebhookAuthJWT:    "jwtAuth",
+}
+
+// applyWebhookAuth sets the authentication parameter and credential of the
+// webhook nodes selected by spec.webhookAuth
+func applyWebhookAuth(n8nWorkflow *n8n.Workflow, auth *n8nv1alpha1.WebhookAuth) error {
+	if auth == nil {
+		return nil
+	}
+
+	credentialType, needsCredential := webhookCredentialTypes[auth.Type]
+	switch {
+	case auth.Type == n8nv1alpha1.WebhookAuthNone:
+		if auth.Credential != nil {
+			return fmt.Errorf("webhookAuth credential is not used with type %q", auth.Type)
+		}
+	case !needsCredential:
+		return fmt.Errorf("unsupported webhookAuth type %q", auth.Type)
+	case auth.Credential == nil || (auth.Credential.ID == "" && auth.Credential.CredentialRef == ""):
+		return fmt.Errorf("webhookAuth type %q requires a credential", auth.Type)
+	}
+
+	selected := make(map[string]bool, len(auth.Nodes))
+	for _, name := range auth.Nodes {
+		selected[name] = true
+	}
+	found := make(map[string]bool, len(auth.Nodes))
+
+	for _, node := range n8nWorkflow.Nodes {
+		name, _ := node["name"].(string)
+		if len(selected) > 0 {
+			if !selected[name] {
+				continue
+			}
+			found[name] = true
+		}
+		if nodeType, _ := node["type"].(string); nodeType != webhookNodeType {
+			if len(selected) > 0 {
+				return fmt.Errorf("webhookAuth node %q is not a webhook node", name)
+			}
+			continue
+		}
+
+		params, _ := node["parameters"].(map[string]any)
+		if params == nil {
+			params = map[string]any{}
+			node["parameters"] = params
+		}
+		params["authentication"] = string(auth.Type)
+
+		// Drop credentials for the other auth methods so the node only carries one
+		credentials, _ := node["credentials"].(map[string]any)
+		for _, otherType := range webhookCredentialTypes {
+			delete(credentials, otherType)
+		}
+		if needsCredential {
+			if credentials == nil {
+				credentials = map[string]any{}
+			}
+			// A credentialRef is replaced by the ID it resolves to before syncing
+			credential := map[string]any{}
+			if auth.Credential.ID != "" {
+				credential["id"] = auth.Credential.ID
+			}
+			if auth.Credential.Name != "" {
+				credential["name"] = auth.Credential.Name
+			}
+			credentials[credentialType] = credential
+		}
+		if len(credentials) > 0 {
+			node["credentials"] = credentials
+		} else {
+			delete(node, "credentials")
+		}
+	}
+
+	for _, name := range auth.Nodes {
+		if !found[name] {
+			return fmt.Errorf("webhookAuth node %q not found in workflow", name)
+		}
+	}
+	return nil
+}
+
+// resolveWebhookCredential replaces a webhookAuth credentialRef in the
+// in-memory spec with the ID and name of the N8nCredential it names, which must
+// manage a credential on the workflow's instance. The returned message is
+// non-empty while the N8nCredential doesn't exist or isn't Ready, in which
+// case the sync should wait.
+func (r *N8nWorkflowReconciler) resolveWebhookCredential(ctx context.Context, workflow *n8nv1alpha1.N8nWorkflow) (string, error) {
+	auth := workflow.Spec.Workflow.WebhookAuth
+	if auth == nil || auth.Credential == nil || auth.Credential.CredentialRef == "" {
+		return "", nil
+	}
+
+	ref := auth.Credential.CredentialRef
+	credential := &n8nv1alpha1.N8nCredential{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref, Namespace: workflow.Namespace}, credential); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("N8nCredential %q referenced by webhookAuth not found", ref), nil
+		}
+		return "", fmt.Errorf("failed to get N8nCredential %q: %w", ref, err)
+	}
+	if instance := workflowInstance(workflow); credential.Spec.InstanceRef != instance {
+		return fmt.Sprintf("N8nCredential %q referenced by webhookAuth belongs to N8nInstance %q, not %q",
+			ref, credential.Spec.InstanceRef, instance), nil
+	}
+	if credential.Status.CredentialID == "" ||
+		!meta.IsStatusConditionTrue(credential.Status.Conditions, n8nv1alpha1.ConditionTypeReady) {
+		return fmt.Sprintf("N8nCredential %q referenced by webhookAuth is not ready", ref), nil
+	}
+
+	resolved := *auth
+	resolved.Credential = &n8nv1alpha1.CredentialReference{ID: credential.Status.CredentialID, Name: credential.Spec.Name}
+	workflow.Spec.Workflow.WebhookAuth = &resolved
+	return "", nil
+}
+
+// workflowsForCredential maps an N8nCredential to the workflows in its
+// namespace whose webhookAuth references it, so they sync once it is Ready
+// and again when it is recreated under a new ID
+func (r *N8nWorkflowReconciler) workflowsForCredential(ctx context.Context, obj client.Object) []reconcile.Request {
+	workflows := &n8nv1alpha1.N8nWorkflowList{}
+	if err := r.List(ctx, workflows, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list workflows for credential", "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, workflow := range workflows.Items {
+		if auth := workflow.Spec.Workflow.WebhookAuth; auth != nil && auth.Credential != nil &&
+			auth.Credential.CredentialRef == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&workflow)})
+		}
+	}
+	return requests
+}
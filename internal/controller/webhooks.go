@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+// formTriggerNodeType is the n8n node type that serves a form and starts the
+// workflow when it is submitted
+const formTriggerNodeType = "n8n-nodes-base.formTrigger"
+
+// webhookEndpoint holds the URL prefixes n8n serves a trigger node type under
+type webhookEndpoint struct {
+	production string
+	test       string
+	// methods are used when the node doesn't choose its HTTP methods
+	methods []string
+}
+
+// webhookEndpoints maps each trigger node type that listens on an HTTP path
+// to where n8n serves it
+var webhookEndpoints = map[string]webhookEndpoint{
+	webhookNodeType:     {production: "webhook", test: "webhook-test", methods: []string{"GET"}},
+	formTriggerNodeType: {production: "form", test: "form-test", methods: []string{"GET", "POST"}},
+}
+
+// workflowWebhooks lists the endpoints of the enabled webhook and form trigger
+// nodes of the workflow in n8n, as absolute URLs under baseURL
+func workflowWebhooks(wf *n8n.Workflow, baseURL string) []n8nv1alpha1.WorkflowWebhook {
+	if wf == nil {
+		return nil
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	var webhooks []n8nv1alpha1.WorkflowWebhook
+	for _, node := range wf.Nodes {
+		nodeType, enabled := nodeTypeOf(node)
+		endpoint, ok := webhookEndpoints[nodeType]
+		if !ok || !enabled {
+			continue
+		}
+		params, _ := node["parameters"].(map[string]any)
+		path := webhookPath(node, params)
+		if path == "" {
+			continue
+		}
+		name, _ := node["name"].(string)
+		responseMode, _ := params["responseMode"].(string)
+		if responseMode == "" {
+			responseMode = "onReceived"
+		}
+		webhooks = append(webhooks, n8nv1alpha1.WorkflowWebhook{
+			Node:          name,
+			Type:          nodeType,
+			Methods:       webhookMethods(params, endpoint.methods),
+			ResponseMode:  responseMode,
+			ProductionURL: baseURL + "/" + endpoint.production + "/" + path,
+			TestURL:       baseURL + "/" + endpoint.test + "/" + path,
+		})
+	}
+	return webhooks
+}
+
+// webhookPath returns the path a trigger node listens on below its prefix.
+// Like n8n, it falls back to the node's webhookId when no path is set and
+// prefixes paths with route parameters, e.g. "user/:id", with the webhookId.
+func webhookPath(node map[string]any, params map[string]any) string {
+	webhookID, _ := node["webhookId"].(string)
+	path, _ := params["path"].(string)
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	switch {
+	case path == "":
+		return webhookID
+	case strings.Contains(path, ":") && webhookID != "":
+		return webhookID + "/" + path
+	default:
+		return path
+	}
+}
+
+// webhookMethods returns the HTTP methods the node accepts: httpMethod, which
+// holds a list when multipleMethods is set, or defaults when it is unset
+func webhookMethods(params map[string]any, defaults []string) []string {
+	switch method := params["httpMethod"].(type) {
+	case string:
+		if method != "" {
+			return []string{method}
+		}
+	case []any:
+		var methods []string
+		for _, value := range method {
+			if s, ok := value.(string); ok && s != "" {
+				methods = append(methods, s)
+			}
+		}
+		if len(methods) > 0 {
+			return methods
+		}
+	}
+	if multiple, _ := params["multipleMethods"].(bool); multiple {
+		return []string{"GET", "POST"}
+	}
+	return append([]string(nil), defaults...)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/n8n"
+)
+
+var _ = Describe("Workflow webhooks", func() {
+	It("should list every enabled webhook and form trigger as absolute URLs", func() {
+		wf := &n8n.Workflow{Nodes: []map[string]any{
+			{"name": "Orders", "type": "n8n-nodes-base.webhook",
+				"parameters": map[string]any{"path": "/orders", "httpMethod": "POST", "responseMode": "responseNode"}},
+			{"name": "Users", "type": "n8n-nodes-base.webhook", "webhookId": "abc",
+				"parameters": map[string]any{"path": "user/:id", "multipleMethods": true,
+					"httpMethod": []any{"GET", "DELETE"}}},
+			{"name": "Unnamed", "type": "n8n-nodes-base.webhook", "webhookId": "def",
+				"parameters": map[string]any{}},
+			{"name": "Signup", "type": "n8n-nodes-base.formTrigger",
+				"parameters": map[string]any{"path": "signup"}},
+			{"name": "Paused", "type": "n8n-nodes-base.webhook", "disabled": true,
+				"parameters": map[string]any{"path": "paused"}},
+			{"name": "Set", "type": "n8n-nodes-base.set"},
+		}}
+
+		Expect(workflowWebhooks(wf, "https://n8n.example.com/")).To(Equal([]n8nv1alpha1.WorkflowWebhook{
+			{Node: "Orders", Type: "n8n-nodes-base.webhook", Methods: []string{"POST"}, ResponseMode: "responseNode",
+				ProductionURL: "https://n8n.example.com/webhook/orders",
+				TestURL:       "https://n8n.example.com/webhook-test/orders"},
+			{Node: "Users", Type: "n8n-nodes-base.webhook", Methods: []string{"GET", "DELETE"}, ResponseMode: "onReceived",
+				ProductionURL: "https://n8n.example.com/webhook/abc/user/:id",
+				TestURL:       "https://n8n.example.com/webhook-test/abc/user/:id"},
+			{Node: "Unnamed", Type: "n8n-nodes-base.webhook", Methods: []string{"GET"}, ResponseMode: "onReceived",
+				ProductionURL: "https://n8n.example.com/webhook/def",
+				TestURL:       "https://n8n.example.com/webhook-test/def"},
+			{Node: "Signup", Type: "n8n-nodes-base.formTrigger", Methods: []string{"GET", "POST"}, ResponseMode: "onReceived",
+				ProductionURL: "https://n8n.example.com/form/signup",
+				TestURL:       "https://n8n.example.com/form-test/signup"},
+		}))
+	})
+
+	It("should return nothing for workflows without webhook triggers", func() {
+		Expect(workflowWebhooks(&n8n.Workflow{Nodes: []map[string]any{{"name": "Set", "type": "n8n-nodes-base.set"}}},
+			"http://n8n:5678")).To(BeEmpty())
+		Expect(workflowWebhooks(nil, "http://n8n:5678")).To(BeNil())
+	})
+})
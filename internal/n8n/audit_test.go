@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGenerateAudit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/audit" {
+			t.Errorf("expected POST /api/v1/audit, got %s %s", r.Method, r.URL.Path)
+		}
+		var req auditRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AdditionalOptions == nil ||
+			req.AdditionalOptions.DaysAbandonedWorkflow != 30 || len(req.AdditionalOptions.Categories) != 2 {
+			t.Errorf("expected the audit options to be sent, got %+v (%v)", req.AdditionalOptions, err)
+		}
+		w.Write([]byte(`{
+			"Credentials Risk Report": {"risk": "credentials", "sections": [
+				{"title": "Credentials not used in any workflow", "location": [
+					{"kind": "credential", "id": "1", "name": "Old"},
+					{"kind": "credential", "id": "2", "name": "Older"}
+				]}
+			]},
+			"Instance Risk Report": {"risk": "instance", "sections": [
+				{"title": "Outdated instance", "description": "A newer version is available"}
+			]}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	report, err := client.GenerateAudit(context.Background(),
+		&AuditOptions{Categories: []string{"credentials", "instance"}, DaysAbandonedWorkflow: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	credentials := report.Reports["Credentials Risk Report"]
+	if credentials.Risk != "credentials" || credentials.Findings() != 2 {
+		t.Errorf("expected 2 credential findings, got %+v", credentials)
+	}
+	instance := report.Reports["Instance Risk Report"]
+	if instance.Findings() != 1 {
+		t.Errorf("expected a section without locations to count once, got %d", instance.Findings())
+	}
+	if len(report.Raw) == 0 {
+		t.Error("expected the raw report to be kept")
+	}
+}
+
+func TestGenerateAuditNoFindings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	report, err := client.GenerateAudit(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Reports) != 0 {
+		t.Errorf("expected no reports, got %+v", report.Reports)
+	}
+}
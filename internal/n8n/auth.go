@@ -0,0 +1,41 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"encoding/base64"
+)
+
+// WithBasicAuth sends HTTP Basic credentials in the Authorization header of
+// every request, alongside the API key, for n8n behind an auth proxy. The
+// password is redacted from logs like the API key.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+		c.authSecret = password
+	}
+}
+
+// WithBearerToken sends token as a bearer token in the Authorization header of
+// every request, alongside the API key, for n8n behind an auth proxy. The
+// token is redacted from logs like the API key.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.authorization = "Bearer " + token
+		c.authSecret = token
+	}
+}
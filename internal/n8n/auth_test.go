@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthorizationHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "api key only"},
+		{name: "basic", opts: []Option{WithBasicAuth("operator", "proxy-pass")}, want: "Basic b3BlcmF0b3I6cHJveHktcGFzcw=="},
+		{name: "bearer", opts: []Option{WithBearerToken("proxy-token")}, want: "Bearer proxy-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("X-N8N-API-KEY"); got != testAPIKey {
+					t.Errorf("expected the API key to be sent, got %q", got)
+				}
+				if got := r.Header.Get("Authorization"); got != tt.want {
+					t.Errorf("expected Authorization %q, got %q", tt.want, got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"data":[]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, testAPIKey, tt.opts...)
+			if _, err := client.ListWorkflows(context.Background(), nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizationRedacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"bad token proxy-token"}`))
+	}))
+	defer server.Close()
+
+	entries, logOpt := captureLogger()
+	client := NewClient(server.URL, testAPIKey, WithBearerToken("proxy-token"), logOpt)
+	_, err := client.GetWorkflow(context.Background(), "1")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "proxy-token") {
+		t.Errorf("error leaks the token: %v", err)
+	}
+	for _, entry := range *entries {
+		if strings.Contains(entry, "proxy-token") {
+			t.Errorf("log entry leaks the token: %s", entry)
+		}
+	}
+}
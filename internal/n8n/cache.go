@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// WorkflowCache remembers which workflow ID each name resolved to on every n8n
+// instance, so a lookup by name can fetch that workflow directly instead of
+// listing workflows again. Entries expire after the TTL and are only hints:
+// callers still fetch the workflow and check it before trusting an entry.
+type WorkflowCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu sync.Mutex
+	// ids maps instance and workflow name to the workflow ID
+	ids map[workflowCacheKey]string
+	// lastSeen maps instance and workflow ID to when the workflow was last seen
+	lastSeen map[workflowCacheKey]time.Time
+}
+
+// workflowCacheKey scopes a workflow name or ID to an instance
+type workflowCacheKey struct {
+	instance string
+	key      string
+}
+
+// NewWorkflowCache creates a cache whose entries expire after ttl. A ttl of
+// zero or less disables caching.
+func NewWorkflowCache(ttl time.Duration) *WorkflowCache {
+	return &WorkflowCache{
+		ttl:      ttl,
+		now:      time.Now,
+		ids:      map[workflowCacheKey]string{},
+		lastSeen: map[workflowCacheKey]time.Time{},
+	}
+}
+
+// For returns the view of the cache for the instance at baseURL, or nil when
+// the cache is nil or disabled. All methods of a nil view are no-ops.
+func (c *WorkflowCache) For(baseURL string) *InstanceWorkflowCache {
+	if c == nil || c.ttl <= 0 {
+		return nil
+	}
+	return &InstanceWorkflowCache{cache: c, instance: strings.ToLower(strings.TrimRight(baseURL, "/"))}
+}
+
+// InstanceWorkflowCache is the part of a WorkflowCache for one n8n instance
+type InstanceWorkflowCache struct {
+	cache    *WorkflowCache
+	instance string
+}
+
+// Lookup returns the ID the workflow name last resolved to, if that workflow
+// was seen within the TTL
+func (c *InstanceWorkflowCache) Lookup(name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	nameKey := workflowCacheKey{instance: c.instance, key: name}
+	id, ok := c.cache.ids[nameKey]
+	if !ok {
+		return "", false
+	}
+	idKey := workflowCacheKey{instance: c.instance, key: id}
+	if c.cache.now().Sub(c.cache.lastSeen[idKey]) >= c.cache.ttl {
+		delete(c.cache.ids, nameKey)
+		delete(c.cache.lastSeen, idKey)
+		return "", false
+	}
+	return id, true
+}
+
+// Store records that the workflow named name has the given ID, replacing any
+// earlier name the ID was stored under
+func (c *InstanceWorkflowCache) Store(name, id string) {
+	if c == nil || name == "" || id == "" {
+		return
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	c.forget(id)
+	c.cache.ids[workflowCacheKey{instance: c.instance, key: name}] = id
+	c.cache.lastSeen[workflowCacheKey{instance: c.instance, key: id}] = c.cache.now()
+}
+
+// Forget drops every entry for the workflow ID, e.g. once it is deleted
+func (c *InstanceWorkflowCache) Forget(id string) {
+	if c == nil || id == "" {
+		return
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	c.forget(id)
+}
+
+// forget drops the entries for id; the caller holds the lock
+func (c *InstanceWorkflowCache) forget(id string) {
+	delete(c.cache.lastSeen, workflowCacheKey{instance: c.instance, key: id})
+	for key, cached := range c.cache.ids {
+		if key.instance == c.instance && cached == id {
+			delete(c.cache.ids, key)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkflowCache(t *testing.T) {
+	now := time.Now()
+	cache := NewWorkflowCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	instance := cache.For("http://n8n:5678")
+	instance.Store("Orders", "wf-1")
+
+	if id, ok := cache.For("HTTP://n8n:5678/").Lookup("Orders"); !ok || id != "wf-1" {
+		t.Errorf("expected wf-1 regardless of case and trailing slash, got %q, %v", id, ok)
+	}
+	if _, ok := cache.For("http://other:5678").Lookup("Orders"); ok {
+		t.Error("expected instances not to share entries")
+	}
+
+	// Renaming moves the ID to the new name
+	instance.Store("Orders v2", "wf-1")
+	if _, ok := instance.Lookup("Orders"); ok {
+		t.Error("expected the old name to be dropped")
+	}
+	if id, _ := instance.Lookup("Orders v2"); id != "wf-1" {
+		t.Errorf("expected wf-1 under the new name, got %q", id)
+	}
+
+	instance.Forget("wf-1")
+	if _, ok := instance.Lookup("Orders v2"); ok {
+		t.Error("expected no entry after Forget")
+	}
+
+	instance.Store("Orders", "wf-2")
+	now = now.Add(time.Minute)
+	if _, ok := instance.Lookup("Orders"); ok {
+		t.Error("expected the entry to expire after the TTL")
+	}
+}
+
+func TestWorkflowCacheDisabled(t *testing.T) {
+	if NewWorkflowCache(0).For("http://n8n:5678") != nil {
+		t.Error("expected no instance cache when the TTL is zero")
+	}
+	var nilCache *WorkflowCache
+	instance := nilCache.For("http://n8n:5678")
+	if instance != nil {
+		t.Fatal("expected no instance cache from a nil cache")
+	}
+
+	// A nil instance cache is usable and never hits
+	instance.Store("Orders", "wf-1")
+	instance.Forget("wf-1")
+	if _, ok := instance.Lookup("Orders"); ok {
+		t.Error("expected a nil instance cache never to hit")
+	}
+}
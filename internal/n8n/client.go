@@ -19,11 +19,23 @@ package n8n
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"mime"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
 )
 
 // Client is a client for the n8n REST API
@@ -31,17 +43,178 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+
+	// tlsConfig, pool and transport decide the transport requests are sent
+	// through, see roundTripper
+	tlsConfig *tls.Config
+	pool      ConnectionPool
+	transport *http.Transport
+
+	// authorization is the Authorization header for an auth proxy in front of
+	// n8n, and authSecret the password or token in it, see WithBasicAuth
+	authorization string
+	authSecret    string
+
+	// userAgent is sent with every request
+	userAgent string
+
+	// auditLog receives one entry per mutating (non-GET) request
+	auditLog logr.Logger
+
+	// log receives request diagnostics, see WithLogger
+	log logr.Logger
+
+	// limiter throttles requests. It is shared with every other client of the
+	// same instance when obtained from a RateLimiterRegistry.
+	limiter *rate.Limiter
+
+	// maxAttempts and retryBaseDelay control how idempotent requests are
+	// retried after a 5xx response or network error
+	maxAttempts    int
+	retryBaseDelay time.Duration
+
+	// dateMu guards the Date header of the most recent response and the local
+	// time it was received, used to estimate clock skew
+	dateMu     sync.Mutex
+	serverDate time.Time
+	localDate  time.Time
+
+	// rateLimitMu guards the rate limit headers of the most recent response
+	// that carried them
+	rateLimitMu        sync.Mutex
+	rateLimitRemaining int64
+	rateLimitLimit     int64
+	rateLimitSeen      bool
+
+	// state holds what was learned about the instance. It is shared with every
+	// other client of the same instance when obtained from an InstanceStateRegistry.
+	state *InstanceState
+}
+
+// idempotencyKeyHeader lets the server deduplicate retried mutating requests
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyContextKey is the context key for a request's idempotency key
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context whose mutating requests carry key in the
+// Idempotency-Key header, so n8n can deduplicate retries of the same operation.
+// Instances that reject the header are retried without it. Once an instance
+// has accepted the header, keyed creates are retried like idempotent requests.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// payloadCaptureContextKey is the context key for a request's payload capture hook
+type payloadCaptureContextKey struct{}
+
+// PayloadCaptureFunc receives the exact JSON body of a request before it is sent
+type PayloadCaptureFunc func(method, path string, body []byte)
+
+// RedactedValue replaces secret values in captured payloads
+const RedactedValue = "[REDACTED]"
+
+// WithPayloadCapture returns a context whose requests with a body are passed to
+// capture just before being sent. Any occurrence of the client's API key in the
+// body is replaced with RedactedValue first.
+func WithPayloadCapture(ctx context.Context, capture PayloadCaptureFunc) context.Context {
+	return context.WithValue(ctx, payloadCaptureContextKey{}, capture)
+}
+
+// Option configures optional Client behavior
+type Option func(*Client)
+
+// WithAuditLogger sets the logger that receives an audit entry for every
+// mutating request. Entries never include headers or request bodies.
+func WithAuditLogger(logger logr.Logger) Option {
+	return func(c *Client) {
+		c.auditLog = logger
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before every request. A nil
+// limiter disables throttling.
+func WithRateLimiter(limiter *rate.Limiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request. Values below 1 keep
+// DefaultTimeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout > 0 {
+			c.httpClient.Timeout = timeout
+		}
+	}
 }
 
+// WithTLSConfig makes the client verify the server with config, e.g. to trust
+// a custom CA. A nil config keeps the default transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		if config != nil {
+			c.tlsConfig = config
+		}
+	}
+}
+
+// WithRetry sets how many attempts idempotent requests get and the delay
+// before the first retry, which doubles on each further retry. Values below 1
+// keep the defaults.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		if maxAttempts > 0 {
+			c.maxAttempts = maxAttempts
+		}
+		if baseDelay > 0 {
+			c.retryBaseDelay = baseDelay
+		}
+	}
+}
+
+const (
+	// DefaultTimeout bounds each HTTP request when no timeout is configured
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultMaxAttempts is the number of attempts idempotent requests get
+	DefaultMaxAttempts = 3
+
+	// DefaultRetryBaseDelay is the delay before the first retry
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+
+	// MaxRetryAfter is the longest Retry-After the client waits out. A request
+	// asked to wait longer fails with the throttling error instead, leaving the
+	// retry to the caller's requeue.
+	MaxRetryAfter = 30 * time.Second
+)
+
 // NewClient creates a new n8n API client
-func NewClient(baseURL, apiKey string) *Client {
-	return &Client{
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
 		baseURL: baseURL,
 		apiKey:  apiKey,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		userAgent:      DefaultUserAgent(),
+		auditLog:       logr.Discard(),
+		log:            logr.Discard(),
+		maxAttempts:    DefaultMaxAttempts,
+		retryBaseDelay: DefaultRetryBaseDelay,
+		state:          &InstanceState{},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.httpClient.Transport = c.roundTripper()
+	return c
+}
+
+// BaseURL returns the URL of the n8n instance the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
 }
 
 // Workflow represents an n8n workflow
@@ -58,6 +231,79 @@ type Workflow struct {
 	UpdatedAt   string           `json:"updatedAt,omitempty"`
 	Tags        []map[string]any `json:"tags,omitempty"`
 	Meta        map[string]any   `json:"meta,omitempty"`
+	Shared      []map[string]any `json:"shared,omitempty"`
+}
+
+// TagNames returns the names of the tags attached to the workflow
+func (w *Workflow) TagNames() []string {
+	names := make([]string, 0, len(w.Tags))
+	for _, tag := range w.Tags {
+		if name, ok := tag["name"].(string); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// CredentialRef is a credential a workflow node uses
+type CredentialRef struct {
+	// ID is the n8n credential ID, empty in workflows exported before n8n stored IDs
+	ID string
+	// Name is the credential name at the time the node was saved
+	Name string
+	// Type is the credential type, e.g. httpBasicAuth
+	Type string
+}
+
+// Credentials returns the credentials referenced by the workflow's nodes,
+// deduplicated and sorted by type, ID and name. n8n returns each reference in
+// the node's credentials block, keyed by type, as {"id": ..., "name": ...} or,
+// in older exports, as just the name.
+func (w *Workflow) Credentials() []CredentialRef {
+	seen := map[CredentialRef]bool{}
+	var refs []CredentialRef
+	for _, node := range w.Nodes {
+		credentials, ok := node["credentials"].(map[string]any)
+		if !ok {
+			continue
+		}
+		for credentialType, value := range credentials {
+			ref := CredentialRef{Type: credentialType}
+			switch v := value.(type) {
+			case string:
+				ref.Name = v
+			case map[string]any:
+				ref.ID, _ = v["id"].(string)
+				ref.Name, _ = v["name"].(string)
+			default:
+				continue
+			}
+			if ref.ID == "" && ref.Name == "" {
+				continue
+			}
+			// The name is only a label when the ID is known
+			key := ref
+			if key.ID != "" {
+				key.Name = ""
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			refs = append(refs, ref)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Name < b.Name
+	})
+	return refs
 }
 
 // WorkflowCreateRequest is used when creating a workflow (active is read-only in n8n API)
@@ -68,6 +314,9 @@ type WorkflowCreateRequest struct {
 	Settings    map[string]any   `json:"settings,omitempty"`
 	StaticData  map[string]any   `json:"staticData,omitempty"`
 	PinData     map[string]any   `json:"pinData,omitempty"`
+	Meta        map[string]any   `json:"meta,omitempty"`
+	// Tags are only sent on create, and only to instances accepting them there
+	Tags []tagRef `json:"tags,omitempty"`
 }
 
 // WorkflowListResponse represents the response from listing workflows
@@ -80,6 +329,13 @@ type WorkflowListResponse struct {
 type ErrorResponse struct {
 	Message string `json:"message"`
 	Code    string `json:"code,omitempty"`
+
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"-"`
+
+	// RetryAfter is how long the server asked to wait before retrying, from the
+	// Retry-After header of a 429 or 503 response
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *ErrorResponse) Error() string {
@@ -89,14 +345,184 @@ func (e *ErrorResponse) Error() string {
 	return e.Message
 }
 
+// IsNotFound returns true if err is an n8n API error with a 404 status
+func IsNotFound(err error) bool {
+	var apiErr *ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// readOnlyModeMessages are the messages n8n answers writes with, along with a
+// 503, while the instance is in maintenance or read-only mode
+var readOnlyModeMessages = []string{"n8n is in maintenance mode", "instance is in read-only mode"}
+
+// IsServerReadOnly returns true if err indicates the n8n server is refusing
+// writes because it is in maintenance or read-only mode. This is a transient
+// state: the same request is expected to succeed once writes are accepted again.
+// Only a 503 carrying one of readOnlyModeMessages counts. Any other 503, e.g.
+// from a proxy in front of an n8n that is down, is an ordinary server error,
+// and 400s such as "request/body/tags is read-only" or a read-only API key's
+// 403 are permanent.
+func IsServerReadOnly(err error) bool {
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	message := strings.TrimSuffix(strings.TrimSpace(apiErr.Message), ".")
+	for _, known := range readOnlyModeMessages {
+		if strings.EqualFold(message, known) {
+			return true
+		}
+	}
+	return false
+}
+
 // doRequest performs an HTTP request to the n8n API
 func (c *Client) doRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
-	var reqBody io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	if capture, ok := ctx.Value(payloadCaptureContextKey{}).(PayloadCaptureFunc); ok && jsonBody != nil {
+		capture(method, path, []byte(c.redact(string(jsonBody))))
+	}
+
+	idempotencyKey, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	if method == http.MethodGet || c.state.idempotencyUnsupported.Load() {
+		idempotencyKey = ""
+	}
+
+	maxAttempts := c.attempts(method, path, idempotencyKey)
+	for attempt := 1; ; attempt++ {
+		respBody, err := c.send(ctx, method, path, jsonBody, idempotencyKey, true)
+		if idempotencyKey != "" && isIdempotencyKeyRejected(err) {
+			// The request was refused before being processed, so resending is safe
+			c.state.idempotencyUnsupported.Store(true)
+			c.state.idempotencySupported.Store(false)
+			idempotencyKey = ""
+			maxAttempts = c.attempts(method, path, "")
+			respBody, err = c.send(ctx, method, path, jsonBody, "", true)
+		}
+		if idempotencyKey != "" && err == nil {
+			c.state.idempotencySupported.Store(true)
+		}
+		// A throttled request was refused before being processed, so even a
+		// create can safely be resent
+		limit := maxAttempts
+		if isTooManyRequests(err) {
+			limit = c.maxAttempts
+		}
+		if attempt >= limit || !isRetryable(ctx, err) {
+			return respBody, err
+		}
+		delay := c.retryDelay(attempt, err)
+		if delay > MaxRetryAfter {
+			return respBody, err
+		}
+		if waitErr := wait(ctx, delay); waitErr != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempts returns how many times the request may be sent: once, unless it is
+// idempotent or carries an idempotency key the instance is known to honour, in
+// which case a resend can't apply it twice
+func (c *Client) attempts(method, path, idempotencyKey string) int {
+	if isIdempotentRequest(method, path) || (idempotencyKey != "" && c.state.idempotencySupported.Load()) {
+		return c.maxAttempts
+	}
+	return 1
+}
+
+// isIdempotentRequest reports whether repeating the request has the same effect
+// as sending it once: reads, replacements, deletions and (de)activations
+func isIdempotentRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		path, _, _ = strings.Cut(path, "?")
+		return strings.HasSuffix(path, "/activate") || strings.HasSuffix(path, "/deactivate")
+	}
+	return false
+}
+
+// isRetryable reports whether err is a 5xx or 429 response or network error
+// that may succeed on another attempt. Other 4xx responses will not.
+func isRetryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var apiErr *ErrorResponse
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// isTooManyRequests reports whether err is a 429 response
+func isTooManyRequests(err error) bool {
+	var apiErr *ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// retryDelay returns how long to wait before the retry following attempt: the
+// server's Retry-After when it sent one, otherwise the base delay doubled each
+// attempt with jitter so clients don't retry in lockstep
+func (c *Client) retryDelay(attempt int, err error) time.Duration {
+	var apiErr *ErrorResponse
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	delay := c.retryBaseDelay << (attempt - 1)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date, returning zero when it is absent, malformed or already past
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+// wait sleeps for delay or until ctx is done
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// send issues a single HTTP request and decodes API errors. When expectJSON is
+// set, a successful response with a non-JSON content type is reported as an error.
+func (c *Client) send(ctx context.Context, method, path string, jsonBody []byte, idempotencyKey string, expectJSON bool) ([]byte, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limit wait: %w", err)
+		}
+	}
+
+	var reqBody io.Reader
+	if jsonBody != nil {
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
@@ -105,55 +531,298 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Every attempt gets its own ID, so retries can be told apart in n8n's logs
+	requestID := newRequestID()
 	req.Header.Set("X-N8N-API-KEY", c.apiKey)
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set(requestIDHeader, requestID)
+	if idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		c.redactURLError(urlErr)
+	}
+	if method != http.MethodGet {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		c.audit(method, path, requestID, statusCode, err)
+	}
 	if err != nil {
+		c.logRequest(method, path, requestID, 0, time.Since(start), err, nil)
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	c.recordServerDate(resp.Header.Get("Date"))
+	c.recordRateLimit(resp.Header)
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
+		c.logRequest(method, path, requestID, 0, time.Since(start), err, nil)
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	contentType := resp.Header.Get("Content-Type")
+	contentTypeErr := checkContentType(contentType, respBody)
+
 	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err != nil {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		errResp := ErrorResponse{StatusCode: resp.StatusCode}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			errResp.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
 		}
+		// An HTML error page says nothing useful, but a plain text body might
+		if contentTypeErr != nil && isHTML(contentType) {
+			errResp.Message = fmt.Sprintf("API error (status %d): %v", resp.StatusCode, contentTypeErr)
+		} else if err := json.Unmarshal(respBody, &errResp); err != nil {
+			errResp.Message = fmt.Sprintf("API error (status %d): %s", resp.StatusCode, string(respBody))
+		}
+		errResp.Message = c.redact(errResp.Message)
+		c.logRequest(method, path, requestID, resp.StatusCode, time.Since(start), nil, respBody)
 		return nil, &errResp
 	}
+	c.logRequest(method, path, requestID, resp.StatusCode, time.Since(start), nil, nil)
+
+	if expectJSON && contentTypeErr != nil {
+		return nil, contentTypeErr
+	}
 
 	return respBody, nil
 }
 
-// ListWorkflows retrieves all workflows from n8n
-func (c *Client) ListWorkflows(ctx context.Context) ([]Workflow, error) {
+// isHTML reports whether the content type is an HTML page
+func isHTML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml")
+}
+
+// checkContentType reports a response that isn't JSON, which usually means the
+// URL points at a login page or proxy rather than the n8n API. Empty bodies,
+// responses without a Content-Type and JSON bodies mislabelled by a proxy
+// (e.g. as text/plain) are accepted.
+func checkContentType(contentType string, body []byte) error {
+	trimmed := bytes.TrimSpace(body)
+	if contentType == "" || len(trimmed) == 0 {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return nil
+	}
+	if (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return nil
+	}
+	return fmt.Errorf("expected application/json, got %s (is the URL correct / are you authenticated?)", contentType)
+}
+
+// recordServerDate remembers the server's Date header alongside the local clock
+func (c *Client) recordServerDate(header string) {
+	if header == "" {
+		return
+	}
+	serverDate, err := http.ParseTime(header)
+	if err != nil {
+		return
+	}
+	c.dateMu.Lock()
+	defer c.dateMu.Unlock()
+	c.serverDate = serverDate
+	c.localDate = time.Now()
+}
+
+// ClockSkew returns how far the n8n server clock is ahead of the local clock
+// (negative if behind), based on the Date header of the most recent response.
+// It returns false if no response carried a usable Date header. The header has
+// one-second resolution, so skews under a second are not meaningful.
+func (c *Client) ClockSkew() (time.Duration, bool) {
+	c.dateMu.Lock()
+	defer c.dateMu.Unlock()
+	if c.serverDate.IsZero() {
+		return 0, false
+	}
+	return c.serverDate.Sub(c.localDate.Truncate(time.Second)), true
+}
+
+// rateLimitHeaders lists the remaining/limit header pairs in order of
+// preference: the common X-RateLimit-* headers, then the IETF RateLimit-* ones
+var rateLimitHeaders = [][2]string{
+	{"X-RateLimit-Remaining", "X-RateLimit-Limit"},
+	{"RateLimit-Remaining", "RateLimit-Limit"},
+}
+
+// recordRateLimit remembers the remaining request budget reported by the
+// response headers, if any
+func (c *Client) recordRateLimit(header http.Header) {
+	for _, names := range rateLimitHeaders {
+		remaining, ok := parseRateLimitHeader(header.Get(names[0]))
+		if !ok {
+			continue
+		}
+		limit, _ := parseRateLimitHeader(header.Get(names[1]))
+		c.rateLimitMu.Lock()
+		defer c.rateLimitMu.Unlock()
+		c.rateLimitRemaining = remaining
+		c.rateLimitLimit = limit
+		c.rateLimitSeen = true
+		return
+	}
+}
+
+// parseRateLimitHeader reads the leading count of a rate limit header. Values
+// such as "100, 100;w=60" carry a policy after the count, which is ignored.
+func parseRateLimitHeader(value string) (int64, bool) {
+	value, _, _ = strings.Cut(value, ",")
+	value, _, _ = strings.Cut(value, ";")
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// RateLimit returns the remaining request budget and its limit from the most
+// recent response carrying rate limit headers. The limit is 0 if the server
+// only reported the remaining budget. It returns false if no response carried
+// the headers.
+func (c *Client) RateLimit() (remaining, limit int64, ok bool) {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimitRemaining, c.rateLimitLimit, c.rateLimitSeen
+}
+
+// isIdempotencyKeyRejected reports whether n8n refused a request because of the
+// Idempotency-Key header, as instances that don't support it may do
+func isIdempotencyKeyRejected(err error) bool {
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusUnprocessableEntity {
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Message), "idempotency")
+}
+
+// audit writes a sanitized entry for a mutating request to the audit logger.
+// Only the method, path, affected resource, request ID and outcome are
+// recorded; the query string, headers and body are left out since they may
+// carry secrets.
+func (c *Client) audit(method, path, requestID string, statusCode int, err error) {
+	path, _, _ = strings.Cut(path, "?")
+
+	// Path is /api/v1/<resource>[/<id>[/<action>]]
+	resource, id, _ := strings.Cut(strings.TrimPrefix(path, "/api/v1/"), "/")
+	id, _, _ = strings.Cut(id, "/")
+
+	host := ""
+	if u, parseErr := url.Parse(c.baseURL); parseErr == nil {
+		host = u.Host
+	}
+
+	keysAndValues := []any{
+		"method", method,
+		"path", path,
+		"resource", resource,
+		"host", host,
+		"status", statusCode,
+		"requestID", requestID,
+	}
+	if id != "" {
+		keysAndValues = append(keysAndValues, "id", id)
+	}
+	if err != nil {
+		keysAndValues = append(keysAndValues, "error", err.Error())
+	}
+	c.auditLog.Info("n8n API mutation", keysAndValues...)
+}
+
+// ListWorkflowsOptions filters and bounds ListWorkflows. The zero value, like
+// a nil pointer, lists every workflow.
+type ListWorkflowsOptions struct {
+	// Name only returns workflows with this name
+	Name string
+
+	// Active, when set, only returns workflows with this activation state
+	Active *bool
+
+	// Tags only returns workflows carrying these tag names
+	Tags []string
+
+	// Limit caps the number of workflows returned, and the size of each page.
+	// Zero fetches every page.
+	Limit int
+}
+
+// query returns the query string for a page starting at cursor
+func (o *ListWorkflowsOptions) query(cursor string) string {
+	query := url.Values{}
+	if o.Name != "" {
+		query.Set("name", o.Name)
+	}
+	if o.Active != nil {
+		query.Set("active", strconv.FormatBool(*o.Active))
+	}
+	if len(o.Tags) > 0 {
+		query.Set("tags", strings.Join(o.Tags, ","))
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	return query.Encode()
+}
+
+// listWorkflowsPage fetches the page of workflows matching opts that starts at cursor
+func (c *Client) listWorkflowsPage(ctx context.Context, opts *ListWorkflowsOptions, cursor string) (*WorkflowListResponse, error) {
+	path := "/api/v1/workflows"
+	if query := opts.query(cursor); query != "" {
+		path += "?" + query
+	}
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	var listResp WorkflowListResponse
+	if err := json.Unmarshal(respBody, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflows: %w", err)
+	}
+	return &listResp, nil
+}
+
+// ListWorkflows retrieves the workflows matching opts from n8n, following the
+// cursor across pages until opts.Limit workflows are collected. A nil opts
+// lists every workflow.
+func (c *Client) ListWorkflows(ctx context.Context, opts *ListWorkflowsOptions) ([]Workflow, error) {
+	if opts == nil {
+		opts = &ListWorkflowsOptions{}
+	}
 	var allWorkflows []Workflow
 	cursor := ""
 
 	for {
-		path := "/api/v1/workflows"
-		if cursor != "" {
-			path += "?cursor=" + cursor
-		}
-
-		respBody, err := c.doRequest(ctx, http.MethodGet, path, nil)
+		listResp, err := c.listWorkflowsPage(ctx, opts, cursor)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list workflows: %w", err)
-		}
-
-		var listResp WorkflowListResponse
-		if err := json.Unmarshal(respBody, &listResp); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal workflows: %w", err)
+			return nil, err
 		}
 
 		allWorkflows = append(allWorkflows, listResp.Data...)
 
+		if opts.Limit > 0 && len(allWorkflows) >= opts.Limit {
+			return allWorkflows[:opts.Limit], nil
+		}
 		if listResp.NextCursor == "" {
 			break
 		}
@@ -178,23 +847,37 @@ func (c *Client) GetWorkflow(ctx context.Context, id string) (*Workflow, error)
 	return &workflow, nil
 }
 
-// GetWorkflowByName finds a workflow by name
+// GetWorkflowByName finds a workflow by name using n8n's name filter. Pages
+// are only followed until a match turns up, and names are compared again in
+// case the server ignores the filter.
 func (c *Client) GetWorkflowByName(ctx context.Context, name string) (*Workflow, error) {
-	workflows, err := c.ListWorkflows(ctx)
-	if err != nil {
-		return nil, err
-	}
+	opts := &ListWorkflowsOptions{Name: name}
+	cursor := ""
+	for {
+		listResp, err := c.listWorkflowsPage(ctx, opts, cursor)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, w := range workflows {
-		if w.Name == name {
-			return &w, nil
+		for _, w := range listResp.Data {
+			if w.Name == name {
+				return &w, nil
+			}
 		}
-	}
 
-	return nil, nil // Not found
+		if listResp.NextCursor == "" {
+			return nil, nil // Not found
+		}
+		cursor = listResp.NextCursor
+	}
 }
 
-// CreateWorkflow creates a new workflow in n8n
+// CreateWorkflow creates a new workflow in n8n. The IDs of workflow.Tags are
+// attached in the same request; callers only set them where
+// InlineTagsSupported. Should the instance still reject them as read-only, it
+// is remembered as such in the client's InstanceState and the workflow is
+// created again without tags, to be associated in a second call with
+// UpdateWorkflowTags.
 func (c *Client) CreateWorkflow(ctx context.Context, workflow *Workflow) (*Workflow, error) {
 	// Use WorkflowCreateRequest to exclude the 'active' field (read-only in n8n API)
 	createReq := &WorkflowCreateRequest{
@@ -204,9 +887,23 @@ func (c *Client) CreateWorkflow(ctx context.Context, workflow *Workflow) (*Workf
 		Settings:    workflow.Settings,
 		StaticData:  workflow.StaticData,
 		PinData:     workflow.PinData,
+		Meta:        workflow.Meta,
+	}
+	if !c.state.inlineTagsUnsupported.Load() {
+		createReq.Tags = workflowTagRefs(workflow)
 	}
 
 	respBody, err := c.doRequest(ctx, http.MethodPost, "/api/v1/workflows", createReq)
+	if err != nil && len(createReq.Tags) > 0 && isTagsReadOnly(err) {
+		c.log.V(1).Info("n8n does not accept tags on create, associating them separately")
+		c.state.inlineTagsUnsupported.Store(true)
+		createReq.Tags = nil
+		// The request changed, so it must not be deduplicated against the rejected one
+		if key, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+			ctx = WithIdempotencyKey(ctx, key+"/untagged")
+		}
+		respBody, err = c.doRequest(ctx, http.MethodPost, "/api/v1/workflows", createReq)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workflow: %w", err)
 	}
@@ -229,6 +926,7 @@ func (c *Client) UpdateWorkflow(ctx context.Context, id string, workflow *Workfl
 		Settings:    workflow.Settings,
 		StaticData:  workflow.StaticData,
 		PinData:     workflow.PinData,
+		Meta:        workflow.Meta,
 	}
 
 	respBody, err := c.doRequest(ctx, http.MethodPut, "/api/v1/workflows/"+id, updateReq)
@@ -244,6 +942,29 @@ func (c *Client) UpdateWorkflow(ctx context.Context, id string, workflow *Workfl
 	return &updated, nil
 }
 
+// CopyWorkflow fetches the workflow with the given ID and creates a copy of it
+// named name, e.g. to keep a recoverable snapshot before overwriting it. The
+// copy is created inactive and without the original's meta and tags, so it is
+// never mistaken for the original by lookups that match on meta.
+func (c *Client) CopyWorkflow(ctx context.Context, id, name string) (*Workflow, error) {
+	original, err := c.GetWorkflow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	copied, err := c.CreateWorkflow(ctx, &Workflow{
+		Name:        name,
+		Nodes:       original.Nodes,
+		Connections: original.Connections,
+		Settings:    original.Settings,
+		StaticData:  original.StaticData,
+		PinData:     original.PinData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy workflow %s: %w", id, err)
+	}
+	return copied, nil
+}
+
 // DeleteWorkflow deletes a workflow by ID
 func (c *Client) DeleteWorkflow(ctx context.Context, id string) error {
 	_, err := c.doRequest(ctx, http.MethodDelete, "/api/v1/workflows/"+id, nil)
@@ -288,3 +1009,116 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	_, err := c.doRequest(ctx, http.MethodGet, "/api/v1/workflows?limit=1", nil)
 	return err
 }
+
+// GetMetric reads a Prometheus text exposition endpoint (such as n8n's /metrics)
+// and returns the value of the named metric, summed across all label sets.
+// An error is returned if the metric is not exposed.
+func (c *Client) GetMetric(ctx context.Context, path, name string) (float64, error) {
+	respBody, err := c.send(ctx, http.MethodGet, path, nil, "", false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get metrics: %w", err)
+	}
+
+	var total float64
+	found := false
+	for _, line := range strings.Split(string(respBody), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		series := fields[0]
+		if i := strings.IndexByte(series, '{'); i >= 0 {
+			series = series[:i]
+		}
+		if series != name {
+			continue
+		}
+		// Label values may contain spaces, so take the value after the closing brace
+		valueField := fields[1]
+		if i := strings.LastIndexByte(line, '}'); i >= 0 {
+			rest := strings.Fields(line[i+1:])
+			if len(rest) == 0 {
+				continue
+			}
+			valueField = rest[0]
+		}
+		value, err := strconv.ParseFloat(valueField, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value for metric %s: %w", name, err)
+		}
+		total += value
+		found = true
+	}
+
+	if !found {
+		return 0, fmt.Errorf("metric %s not found at %s", name, path)
+	}
+	return total, nil
+}
+
+// scopeProbeWorkflowName names the throwaway workflow created by ProbeWriteAccess
+const scopeProbeWorkflowName = "n8n-operator-scope-probe"
+
+// ProbeWriteAccess reports whether the API key may write workflows by creating an
+// empty, inactive workflow and deleting it straight away. A 401 or 403 on create
+// means the key is read-only; any other failure is returned as an error. A
+// conclusive result is kept in the client's InstanceState and reused for
+// WriteProbeTTL, so clients sharing it don't create a workflow on every call.
+// Probe workflows left behind by an earlier probe whose delete failed are
+// removed too, since they carry no owner for the orphan sweep to find.
+func (c *Client) ProbeWriteAccess(ctx context.Context) (bool, error) {
+	if canWrite, ok := c.state.cachedWriteAccess(c.apiKey); ok {
+		return canWrite, nil
+	}
+
+	// n8n requires nodes, connections and settings to be present on create
+	probe := map[string]any{
+		"name":        scopeProbeWorkflowName,
+		"nodes":       []any{},
+		"connections": map[string]any{},
+		"settings":    map[string]any{},
+	}
+	respBody, err := c.doRequest(ctx, http.MethodPost, "/api/v1/workflows", probe)
+	if err != nil {
+		var apiErr *ErrorResponse
+		if errors.As(err, &apiErr) &&
+			(apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+			c.state.storeWriteAccess(c.apiKey, false)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create scope probe workflow: %w", err)
+	}
+	c.state.storeWriteAccess(c.apiKey, true)
+
+	var created Workflow
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return true, fmt.Errorf("failed to unmarshal scope probe workflow: %w", err)
+	}
+	if err := c.DeleteWorkflow(ctx, created.ID); err != nil {
+		return true, fmt.Errorf("failed to delete scope probe workflow %s: %w", created.ID, err)
+	}
+	c.deleteLeftoverProbes(ctx)
+	return true, nil
+}
+
+// deleteLeftoverProbes deletes the empty, inactive workflows named
+// scopeProbeWorkflowName. Failures are only logged; the next probe tries again.
+func (c *Client) deleteLeftoverProbes(ctx context.Context) {
+	workflows, err := c.ListWorkflows(ctx, &ListWorkflowsOptions{Name: scopeProbeWorkflowName})
+	if err != nil {
+		c.log.V(1).Info("Could not list leftover scope probe workflows", "error", err.Error())
+		return
+	}
+	for _, wf := range workflows {
+		if wf.Name != scopeProbeWorkflowName || len(wf.Nodes) > 0 || wf.Active {
+			continue
+		}
+		if err := c.DeleteWorkflow(ctx, wf.ID); err != nil && !IsNotFound(err) {
+			c.log.V(1).Info("Could not delete leftover scope probe workflow", "id", wf.ID, "error", err.Error())
+		}
+	}
+}
@@ -19,9 +19,17 @@ package n8n
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
 )
 
 func TestNewClient(t *testing.T) {
@@ -61,7 +69,7 @@ func TestListWorkflows(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL, "test-key")
-	result, err := client.ListWorkflows(context.Background())
+	result, err := client.ListWorkflows(context.Background(), nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -103,6 +111,35 @@ func TestGetWorkflow(t *testing.T) {
 	}
 }
 
+func TestWorkflowCredentials(t *testing.T) {
+	workflow := Workflow{Nodes: []map[string]any{
+		{"name": "Start"},
+		{"name": "Fetch", "credentials": map[string]any{
+			"httpBasicAuth": map[string]any{"id": "7", "name": "Partner API"},
+		}},
+		{"name": "Fetch Again", "credentials": map[string]any{
+			"httpBasicAuth": map[string]any{"id": "7", "name": "Partner API (renamed)"},
+		}},
+		{"name": "Notify", "credentials": map[string]any{
+			"slackApi": map[string]any{"id": "3", "name": "Slack"},
+			"smtp":     "Legacy Mail",
+		}},
+		{"name": "Broken", "credentials": map[string]any{"oAuth2Api": map[string]any{}}},
+	}}
+
+	want := []CredentialRef{
+		{ID: "7", Name: "Partner API", Type: "httpBasicAuth"},
+		{ID: "3", Name: "Slack", Type: "slackApi"},
+		{Name: "Legacy Mail", Type: "smtp"},
+	}
+	if got := workflow.Credentials(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := (&Workflow{}).Credentials(); got != nil {
+		t.Errorf("expected no credentials, got %v", got)
+	}
+}
+
 func TestGetWorkflowByName(t *testing.T) {
 	workflows := []Workflow{
 		{ID: "1", Name: "Other Workflow", Active: false},
@@ -151,6 +188,93 @@ func TestGetWorkflowByNameNotFound(t *testing.T) {
 	}
 }
 
+func TestListWorkflowsOptionsQuery(t *testing.T) {
+	active := false
+	tests := []struct {
+		name   string
+		opts   ListWorkflowsOptions
+		cursor string
+		want   string
+	}{
+		{name: "empty", want: ""},
+		{name: "cursor only", cursor: "abc", want: "cursor=abc"},
+		{name: "name is escaped", opts: ListWorkflowsOptions{Name: "My Workflow&x"}, want: "name=My+Workflow%26x"},
+		{
+			name:   "all filters",
+			opts:   ListWorkflowsOptions{Name: "wf", Active: &active, Tags: []string{"prod", "team-a"}, Limit: 50},
+			cursor: "next",
+			want:   "active=false&cursor=next&limit=50&name=wf&tags=prod%2Cteam-a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.query(tt.cursor); got != tt.want {
+				t.Errorf("expected query %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestListWorkflowsPagination(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		resp := WorkflowListResponse{Data: []Workflow{{ID: "1"}, {ID: "2"}}, NextCursor: "page2"}
+		if r.URL.Query().Get("cursor") == "page2" {
+			resp = WorkflowListResponse{Data: []Workflow{{ID: "3"}}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	all, err := client.ListWorkflows(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 3 || len(queries) != 2 {
+		t.Fatalf("expected 3 workflows over 2 pages, got %d over %d", len(all), len(queries))
+	}
+	if queries[0] != "" || queries[1] != "cursor=page2" {
+		t.Errorf("unexpected queries %q", queries)
+	}
+
+	queries = nil
+	limited, err := client.ListWorkflows(context.Background(), &ListWorkflowsOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(limited) != 2 || len(queries) != 1 {
+		t.Errorf("expected limit to stop after 2 workflows on 1 page, got %d over %d", len(limited), len(queries))
+	}
+}
+
+func TestGetWorkflowByNameFilter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.URL.Query().Get("name"); got != "Target Workflow" {
+			t.Errorf("expected name filter Target Workflow, got %q", got)
+		}
+		// More pages remain, but the match on the first one ends the lookup
+		resp := WorkflowListResponse{Data: []Workflow{{ID: "2", Name: "Target Workflow"}}, NextCursor: "more"}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	result, err := client.GetWorkflowByName(context.Background(), "Target Workflow")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || result.ID != "2" {
+		t.Fatalf("expected workflow 2, got %v", result)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
 func TestCreateWorkflow(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -215,6 +339,65 @@ func TestUpdateWorkflow(t *testing.T) {
 	}
 }
 
+func TestCopyWorkflow(t *testing.T) {
+	var created map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/workflows/123":
+			json.NewEncoder(w).Encode(Workflow{
+				ID: "123", Name: "Orders", Active: true,
+				Nodes:       []map[string]any{{"name": "Start", "type": "n8n-nodes-base.manualTrigger"}},
+				Connections: map[string]any{"Start": map[string]any{}},
+				Settings:    map[string]any{"executionOrder": "v1"},
+				Meta:        map[string]any{"ownerUid": "uid-1"},
+				Tags:        []map[string]any{{"id": "t1", "name": "prod"}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/workflows":
+			json.NewDecoder(r.Body).Decode(&created)
+			created["id"] = "copy-456"
+			json.NewEncoder(w).Encode(created)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	result, err := client.CopyWorkflow(context.Background(), "123", "Orders-backup-20261016T100000Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != "copy-456" || result.Name != "Orders-backup-20261016T100000Z" {
+		t.Errorf("expected the copy to be returned, got %s %q", result.ID, result.Name)
+	}
+	if len(result.Nodes) != 1 || result.Settings["executionOrder"] != "v1" {
+		t.Errorf("expected nodes and settings to be copied, got %v %v", result.Nodes, result.Settings)
+	}
+	for _, key := range []string{"meta", "tags", "active"} {
+		if _, ok := created[key]; ok {
+			t.Errorf("expected the copy to be created without %s, got %v", key, created[key])
+		}
+	}
+}
+
+func TestCopyWorkflowNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected only the lookup, got %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Not Found"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if _, err := client.CopyWorkflow(context.Background(), "missing", "copy"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
 func TestDeleteWorkflow(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodDelete {
@@ -304,6 +487,88 @@ func TestAPIError(t *testing.T) {
 	if err.Error() == "" {
 		t.Error("expected error message to be non-empty")
 	}
+	if !IsNotFound(err) {
+		t.Errorf("expected IsNotFound to be true for a 404, got %v", err)
+	}
+}
+
+func TestIsNotFoundNonJSONError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	err := client.DeleteWorkflow(context.Background(), "123")
+	if err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+	if IsNotFound(err) {
+		t.Error("expected IsNotFound to be false for a 500")
+	}
+	if !strings.Contains(err.Error(), "API error (status 500): boom") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestHTMLResponse(t *testing.T) {
+	loginPage := `<!DOCTYPE html><html><head><title>Sign in</title></head><body>Please log in</body></html>`
+
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int
+	}{
+		{name: "login page served with 200", status: http.StatusOK},
+		{name: "proxy error page", status: http.StatusBadGateway, wantStatus: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(loginPage))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key")
+			_, err := client.GetWorkflow(context.Background(), "123")
+			if err == nil {
+				t.Fatal("expected error for HTML response")
+			}
+			expected := "expected application/json, got text/html; charset=utf-8 (is the URL correct / are you authenticated?)"
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("expected error to contain %q, got %q", expected, err.Error())
+			}
+			if strings.Contains(err.Error(), "<html>") {
+				t.Errorf("expected HTML body to be left out of the error, got %q", err.Error())
+			}
+
+			var apiErr *ErrorResponse
+			if tt.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.wantStatus) {
+				t.Errorf("expected ErrorResponse with status %d, got %v", tt.wantStatus, err)
+			}
+		})
+	}
+}
+
+func TestJSONWithoutJSONContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(`{"id":"123","name":"Test"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	workflow, err := client.GetWorkflow(context.Background(), "123")
+	if err != nil {
+		t.Fatalf("expected mislabelled JSON to be accepted, got %v", err)
+	}
+	if workflow.ID != "123" {
+		t.Errorf("expected ID 123, got %s", workflow.ID)
+	}
 }
 
 func TestHealthCheck(t *testing.T) {
@@ -322,3 +587,578 @@ func TestHealthCheck(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestGetMetric(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			t.Errorf("expected path /metrics, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(`# HELP n8n_scaling_mode_queue_jobs_waiting Jobs waiting
+# TYPE n8n_scaling_mode_queue_jobs_waiting gauge
+n8n_scaling_mode_queue_jobs_waiting{queue="default"} 12
+n8n_scaling_mode_queue_jobs_waiting{queue="name with spaces"} 3
+n8n_scaling_mode_queue_jobs_active 4
+`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	value, err := client.GetMetric(context.Background(), "/metrics", "n8n_scaling_mode_queue_jobs_waiting")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != 15 {
+		t.Errorf("expected summed value 15, got %v", value)
+	}
+
+	if _, err := client.GetMetric(context.Background(), "/metrics", "missing_metric"); err == nil {
+		t.Error("expected error for missing metric")
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Method+" "+r.Header.Get("Idempotency-Key"))
+		json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Test"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+	if _, err := client.CreateWorkflow(ctx, &Workflow{Name: "Test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.CreateWorkflow(ctx, &Workflow{Name: "Test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetWorkflow(ctx, "123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.CreateWorkflow(context.Background(), &Workflow{Name: "Test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"POST key-1", "POST key-1", "GET ", "POST "}
+	if strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected headers %v, got %v", expected, keys)
+	}
+}
+
+func TestIdempotencyKeyUnsupported(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		keys = append(keys, key)
+		if key != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Message: "Unsupported header: Idempotency-Key"})
+			return
+		}
+		json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Test"})
+	}))
+	defer server.Close()
+
+	// Clients are built per reconcile, so the rejection must outlive the client
+	registry := NewInstanceStateRegistry()
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+	for i := 0; i < 2; i++ {
+		client := NewClient(server.URL, "test-key", WithInstanceState(registry.For(server.URL)))
+		if _, err := client.ActivateWorkflow(ctx, "123"); err != nil {
+			t.Fatalf("expected silent fallback, got error: %v", err)
+		}
+	}
+
+	// The first call is retried without the header and later calls omit it
+	expected := []string{"key-1", "", ""}
+	if strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected headers %v, got %v", expected, keys)
+	}
+}
+
+func TestIdempotencyKeyRetry(t *testing.T) {
+	var keys []string
+	failures := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) > 1 && failures < 1 {
+			failures++
+			w.WriteHeader(http.StatusBadGateway)
+			json.NewEncoder(w).Encode(ErrorResponse{Message: "bad gateway"})
+			return
+		}
+		json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Test"})
+	}))
+	defer server.Close()
+
+	registry := NewInstanceStateRegistry()
+	newClient := func() *Client {
+		return NewClient(server.URL, "test-key", WithRetry(3, time.Millisecond), WithInstanceState(registry.For(server.URL)))
+	}
+	// The first keyed create succeeds, so the instance is known to accept the
+	// header and a later keyed create is retried after a 502
+	if _, err := newClient().CreateWorkflow(WithIdempotencyKey(context.Background(), "key-1"), &Workflow{Name: "Test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := newClient().CreateWorkflow(WithIdempotencyKey(context.Background(), "key-2"), &Workflow{Name: "Test"}); err != nil {
+		t.Fatalf("expected the keyed create to be retried, got error: %v", err)
+	}
+	expected := []string{"key-1", "key-2", "key-2"}
+	if strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected headers %v, got %v", expected, keys)
+	}
+
+	// A create without a key is still sent once
+	failures = 0
+	if _, err := newClient().CreateWorkflow(context.Background(), &Workflow{Name: "Test"}); err == nil {
+		t.Fatal("expected an unkeyed create not to be retried")
+	}
+}
+
+func TestProbeWriteAccess(t *testing.T) {
+	tests := []struct {
+		name         string
+		createStatus int
+		wantWrite    bool
+		wantErr      bool
+		wantDeletes  int
+	}{
+		{name: "read-write key", createStatus: http.StatusOK, wantWrite: true, wantDeletes: 2},
+		{name: "read-only key", createStatus: http.StatusForbidden, wantWrite: false},
+		{name: "server error", createStatus: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletes := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					// A probe left by an earlier failed delete, and a user's workflow of the same name
+					json.NewEncoder(w).Encode(WorkflowListResponse{Data: []Workflow{
+						{ID: "probe-0", Name: "n8n-operator-scope-probe"},
+						{ID: "user-1", Name: "n8n-operator-scope-probe", Nodes: []map[string]any{{"name": "Start"}}},
+					}})
+				case http.MethodPost:
+					w.WriteHeader(tt.createStatus)
+					if tt.createStatus == http.StatusOK {
+						json.NewEncoder(w).Encode(Workflow{ID: "probe-1", Name: "n8n-operator-scope-probe"})
+						return
+					}
+					json.NewEncoder(w).Encode(ErrorResponse{Message: "nope"})
+				case http.MethodDelete:
+					if r.URL.Path != "/api/v1/workflows/probe-1" && r.URL.Path != "/api/v1/workflows/probe-0" {
+						t.Errorf("unexpected delete path %s", r.URL.Path)
+					}
+					deletes++
+					json.NewEncoder(w).Encode(Workflow{ID: "probe-1"})
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key")
+			canWrite, err := client.ProbeWriteAccess(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if canWrite != tt.wantWrite {
+				t.Errorf("expected write access %v, got %v", tt.wantWrite, canWrite)
+			}
+			if deletes != tt.wantDeletes {
+				t.Errorf("expected %d deletes, got %d", tt.wantDeletes, deletes)
+			}
+		})
+	}
+}
+
+func TestProbeWriteAccessCached(t *testing.T) {
+	probes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			probes++
+		}
+		json.NewEncoder(w).Encode(Workflow{ID: "probe-1"})
+	}))
+	defer server.Close()
+
+	registry := NewInstanceStateRegistry()
+	probe := func(apiKey string) {
+		t.Helper()
+		client := NewClient(server.URL, apiKey, WithInstanceState(registry.For(server.URL)))
+		if canWrite, err := client.ProbeWriteAccess(context.Background()); err != nil || !canWrite {
+			t.Fatalf("expected write access, got %v, %v", canWrite, err)
+		}
+	}
+
+	// Clients sharing the instance state reuse the result
+	probe("test-key")
+	probe("test-key")
+	if probes != 1 {
+		t.Fatalf("expected 1 probe, got %d", probes)
+	}
+
+	// A rotated key is probed again
+	probe("other-key")
+	if probes != 2 {
+		t.Fatalf("expected 2 probes after the key changed, got %d", probes)
+	}
+
+	// So is an expired result
+	state := registry.For(server.URL)
+	state.writeProbe.at = time.Now().Add(-WriteProbeTTL)
+	probe("other-key")
+	if probes != 3 {
+		t.Errorf("expected 3 probes after the result expired, got %d", probes)
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	offset := 0 * time.Second
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(offset).UTC().Format(http.TimeFormat))
+		json.NewEncoder(w).Encode(WorkflowListResponse{Data: []Workflow{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if _, ok := client.ClockSkew(); ok {
+		t.Fatal("expected no skew before any request")
+	}
+
+	for _, offset = range []time.Duration{0, 90 * time.Second, -45 * time.Second} {
+		if err := client.HealthCheck(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		skew, ok := client.ClockSkew()
+		if !ok {
+			t.Fatal("expected skew to be available")
+		}
+		if diff := skew - offset; diff < -time.Second || diff > time.Second {
+			t.Errorf("expected skew near %v, got %v", offset, skew)
+		}
+	}
+}
+
+func TestAuditLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Existing"})
+			return
+		}
+		var workflow Workflow
+		json.NewDecoder(r.Body).Decode(&workflow)
+		workflow.ID = "new-123"
+		json.NewEncoder(w).Encode(workflow)
+	}))
+	defer server.Close()
+
+	var entries []string
+	auditLogger := funcr.New(func(prefix, args string) {
+		entries = append(entries, args)
+	}, funcr.Options{})
+
+	client := NewClient(server.URL, "super-secret-key", WithAuditLogger(auditLogger))
+
+	// Reads are not audited
+	if _, err := client.GetWorkflow(context.Background(), "123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no audit entries for GET, got %d", len(entries))
+	}
+
+	if _, err := client.CreateWorkflow(context.Background(), &Workflow{Name: "New Workflow"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d: %v", len(entries), entries)
+	}
+
+	entry := entries[0]
+	for _, want := range []string{`"method"="POST"`, `"path"="/api/v1/workflows"`, `"resource"="workflows"`, `"status"=200`} {
+		if !strings.Contains(entry, want) {
+			t.Errorf("expected audit entry to contain %s, got %s", want, entry)
+		}
+	}
+	if strings.Contains(entry, "super-secret-key") {
+		t.Errorf("audit entry must not contain the API key: %s", entry)
+	}
+	if strings.Contains(entry, "New Workflow") {
+		t.Errorf("audit entry must not contain the request body: %s", entry)
+	}
+}
+
+func TestIsServerReadOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		message    string
+		expected   bool
+	}{
+		{name: "service unavailable maintenance", statusCode: http.StatusServiceUnavailable, message: "n8n is in maintenance mode", expected: true},
+		{name: "service unavailable other", statusCode: http.StatusServiceUnavailable, message: "Service Unavailable", expected: false},
+		{name: "service unavailable read-only", statusCode: http.StatusServiceUnavailable, message: "Instance is in read-only mode.", expected: true},
+		{name: "service unavailable mentioning maintenance", statusCode: http.StatusServiceUnavailable, message: "Upstream down for maintenance window", expected: false},
+		{name: "forbidden read-only", statusCode: http.StatusForbidden, message: "Instance is in read-only mode", expected: false},
+		{name: "bad request read-only field", statusCode: http.StatusBadRequest, message: "request/body/active is read-only", expected: false},
+		{name: "forbidden other", statusCode: http.StatusForbidden, message: "Forbidden", expected: false},
+		{name: "not found", statusCode: http.StatusNotFound, message: "Not Found", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				json.NewEncoder(w).Encode(ErrorResponse{Message: tt.message})
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key")
+			_, err := client.CreateWorkflow(context.Background(), &Workflow{Name: "Test"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got := IsServerReadOnly(err); got != tt.expected {
+				t.Errorf("expected IsServerReadOnly to be %v, got %v (%v)", tt.expected, got, err)
+			}
+		})
+	}
+}
+
+func TestPayloadCapture(t *testing.T) {
+	var sent []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent, _ = io.ReadAll(r.Body)
+		json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Test"})
+	}))
+	defer server.Close()
+
+	var captured []byte
+	var capturedPath string
+	ctx := WithPayloadCapture(context.Background(), func(method, path string, body []byte) {
+		capturedPath = method + " " + path
+		captured = body
+	})
+
+	client := NewClient(server.URL, "secret-api-key")
+	wf := &Workflow{
+		Name:  "Test",
+		Nodes: []map[string]any{{"name": "HTTP", "parameters": map[string]any{"header": "secret-api-key"}}},
+	}
+	if _, err := client.UpdateWorkflow(ctx, "123", wf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if capturedPath != "PUT /api/v1/workflows/123" {
+		t.Errorf("expected capture of PUT /api/v1/workflows/123, got %q", capturedPath)
+	}
+	if strings.Contains(string(captured), "secret-api-key") {
+		t.Errorf("captured payload leaks the API key: %s", captured)
+	}
+	expected := strings.ReplaceAll(string(sent), "secret-api-key", RedactedValue)
+	if string(captured) != expected {
+		t.Errorf("captured payload %s does not match sent payload %s", captured, sent)
+	}
+
+	// Requests without a body are not captured
+	captured = nil
+	if _, err := client.GetWorkflow(ctx, "123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if captured != nil {
+		t.Errorf("expected no capture for GET, got %s", captured)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var headers map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range headers {
+			w.Header().Set(name, value)
+		}
+		json.NewEncoder(w).Encode(WorkflowListResponse{Data: []Workflow{}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if _, _, ok := client.RateLimit(); ok {
+		t.Fatal("expected no rate limit before any request")
+	}
+
+	tests := []struct {
+		name              string
+		headers           map[string]string
+		expectedRemaining int64
+		expectedLimit     int64
+	}{
+		{"x-ratelimit headers", map[string]string{"X-RateLimit-Remaining": "42", "X-RateLimit-Limit": "100"}, 42, 100},
+		{"ietf headers with policy", map[string]string{"RateLimit-Remaining": "7", "RateLimit-Limit": "60, 60;w=60"}, 7, 60},
+		{"remaining only", map[string]string{"X-RateLimit-Remaining": "3"}, 3, 0},
+		// Responses without usable headers keep the last observed budget
+		{"invalid remaining", map[string]string{"X-RateLimit-Remaining": "lots"}, 3, 0},
+		{"no headers", nil, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers = tt.headers
+			if err := client.HealthCheck(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			remaining, limit, ok := client.RateLimit()
+			if !ok {
+				t.Fatal("expected rate limit to be available")
+			}
+			if remaining != tt.expectedRemaining || limit != tt.expectedLimit {
+				t.Errorf("expected %d/%d, got %d/%d", tt.expectedRemaining, tt.expectedLimit, remaining, limit)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name             string
+		call             func(c *Client) error
+		statuses         []int
+		expectedAttempts int
+		expectErr        bool
+	}{
+		{
+			name:             "get recovers after two 503s",
+			call:             func(c *Client) error { _, err := c.GetWorkflow(context.Background(), "123"); return err },
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "activate recovers after a 502",
+			call:             func(c *Client) error { _, err := c.ActivateWorkflow(context.Background(), "123"); return err },
+			statuses:         []int{http.StatusBadGateway},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "gives up after max attempts",
+			call:             func(c *Client) error { return c.DeleteWorkflow(context.Background(), "123") },
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectedAttempts: 3,
+			expectErr:        true,
+		},
+		{
+			name:             "4xx fails fast",
+			call:             func(c *Client) error { _, err := c.GetWorkflow(context.Background(), "123"); return err },
+			statuses:         []int{http.StatusNotFound},
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+		{
+			name: "create is not retried",
+			call: func(c *Client) error {
+				_, err := c.CreateWorkflow(context.Background(), &Workflow{Name: "New"})
+				return err
+			},
+			statuses:         []int{http.StatusServiceUnavailable},
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts <= len(tt.statuses) {
+					w.WriteHeader(tt.statuses[attempts-1])
+					json.NewEncoder(w).Encode(ErrorResponse{Message: "unavailable"})
+					return
+				}
+				json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Existing"})
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key", WithRetry(3, time.Millisecond))
+			err := tt.call(client)
+			if tt.expectErr && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	baseURL := server.URL
+	server.Close()
+
+	start := time.Now()
+	client := NewClient(baseURL, "test-key", WithRetry(3, 20*time.Millisecond))
+	if err := client.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected error for unreachable server")
+	}
+	// Two backoffs of at least half of 20ms and 40ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected retries with backoff, returned after %v", elapsed)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		json.NewEncoder(w).Encode(WorkflowListResponse{Data: []Workflow{}})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	if timeout := NewClient(server.URL, "test-key").httpClient.Timeout; timeout != DefaultTimeout {
+		t.Errorf("expected default timeout %v, got %v", DefaultTimeout, timeout)
+	}
+
+	client := NewClient(server.URL, "test-key", WithTimeout(50*time.Millisecond), WithRetry(1, 0))
+	start := time.Now()
+	if err := client.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected request to time out quickly, took %v", elapsed)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		json.NewEncoder(w).Encode(WorkflowListResponse{Data: []Workflow{}})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// The deadline is far below the HTTP timeout, so only the context can abort the call
+	client := NewClient(server.URL, "test-key")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.ListWorkflows(ctx, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a context deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the in-flight request to be aborted at the deadline, took %v", elapsed)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected no retry after the deadline, got %d requests", got)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Credential represents an n8n credential. n8n never returns the data of a
+// credential, so it is only set when creating or updating one.
+type Credential struct {
+	ID        string         `json:"id,omitempty"`
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	Data      map[string]any `json:"data,omitempty"`
+	CreatedAt string         `json:"createdAt,omitempty"`
+	UpdatedAt string         `json:"updatedAt,omitempty"`
+}
+
+// CredentialSchema is the JSON schema n8n publishes for a credential type's data
+type CredentialSchema struct {
+	Type       string         `json:"type,omitempty"`
+	Properties map[string]any `json:"properties,omitempty"`
+	Required   []string       `json:"required,omitempty"`
+}
+
+// GetCredentialSchema returns the data schema of a credential type. Schemas
+// are cached per type in the client's InstanceState, so clients sharing it
+// fetch each schema once. An unknown type is reported as a not found error.
+func (c *Client) GetCredentialSchema(ctx context.Context, credentialType string) (*CredentialSchema, error) {
+	c.state.schemaMu.Lock()
+	cached, ok := c.state.schemas[credentialType]
+	c.state.schemaMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, "/api/v1/credentials/schema/"+url.PathEscape(credentialType), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema of credential type %s: %w", credentialType, err)
+	}
+
+	var schema CredentialSchema
+	if err := json.Unmarshal(respBody, &schema); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential schema: %w", err)
+	}
+
+	c.state.schemaMu.Lock()
+	defer c.state.schemaMu.Unlock()
+	if c.state.schemas == nil {
+		c.state.schemas = map[string]*CredentialSchema{}
+	}
+	c.state.schemas[credentialType] = &schema
+	return &schema, nil
+}
+
+// CreateCredential creates a new credential in n8n
+func (c *Client) CreateCredential(ctx context.Context, credential *Credential) (*Credential, error) {
+	createReq := &Credential{
+		Name: credential.Name,
+		Type: credential.Type,
+		Data: credential.Data,
+	}
+
+	respBody, err := c.doRequest(ctx, http.MethodPost, "/api/v1/credentials", createReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential: %w", err)
+	}
+
+	var created Credential
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal created credential: %w", err)
+	}
+
+	return &created, nil
+}
+
+// UpdateCredential replaces the name, type and data of an existing credential.
+// A credential that no longer exists is reported as a not found error.
+func (c *Client) UpdateCredential(ctx context.Context, id string, credential *Credential) error {
+	updateReq := &Credential{
+		Name: credential.Name,
+		Type: credential.Type,
+		Data: credential.Data,
+	}
+
+	if _, err := c.doRequest(ctx, http.MethodPatch, "/api/v1/credentials/"+id, updateReq); err != nil {
+		return fmt.Errorf("failed to update credential %s: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteCredential deletes a credential from n8n
+func (c *Client) DeleteCredential(ctx context.Context, id string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, "/api/v1/credentials/"+id, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential %s: %w", id, err)
+	}
+	return nil
+}
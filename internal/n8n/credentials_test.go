@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/credentials" {
+			t.Errorf("expected path /api/v1/credentials, got %s", r.URL.Path)
+		}
+
+		var credential Credential
+		json.NewDecoder(r.Body).Decode(&credential)
+		if credential.Type != "httpHeaderAuth" {
+			t.Errorf("expected type httpHeaderAuth, got %s", credential.Type)
+		}
+		if credential.Data["value"] != "s3cret" {
+			t.Errorf("expected data to be sent, got %v", credential.Data)
+		}
+
+		// n8n never echoes the data back
+		json.NewEncoder(w).Encode(Credential{ID: "cred-1", Name: credential.Name, Type: credential.Type})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	input := &Credential{
+		Name: "API Token",
+		Type: "httpHeaderAuth",
+		Data: map[string]any{"name": "Authorization", "value": "s3cret"},
+	}
+	result, err := client.CreateCredential(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID != "cred-1" {
+		t.Errorf("expected credential ID to be cred-1, got %s", result.ID)
+	}
+	if result.Data != nil {
+		t.Errorf("expected no data in the response, got %v", result.Data)
+	}
+}
+
+func TestCreateCredentialError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: "request.body.data is not valid"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	_, err := client.CreateCredential(context.Background(), &Credential{Name: "Bad", Type: "httpHeaderAuth"})
+	if err == nil {
+		t.Fatal("expected error for invalid credential data")
+	}
+}
+
+func TestUpdateCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/credentials/cred-1" {
+			t.Errorf("expected path /api/v1/credentials/cred-1, got %s", r.URL.Path)
+		}
+
+		var credential Credential
+		json.NewDecoder(r.Body).Decode(&credential)
+		if credential.Name != "Rotated" || credential.Data["value"] != "n3w" {
+			t.Errorf("expected name and data to be sent, got %+v", credential)
+		}
+		json.NewEncoder(w).Encode(Credential{ID: "cred-1", Name: credential.Name, Type: credential.Type})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	err := client.UpdateCredential(context.Background(), "cred-1", &Credential{
+		Name: "Rotated",
+		Type: "httpHeaderAuth",
+		Data: map[string]any{"name": "Authorization", "value": "n3w"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateCredentialNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: "Not Found"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	err := client.UpdateCredential(context.Background(), "missing", &Credential{Name: "Gone", Type: "httpHeaderAuth"})
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestDeleteCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/credentials/cred-1" {
+			t.Errorf("expected path /api/v1/credentials/cred-1, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if err := client.DeleteCredential(context.Background(), "cred-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteCredentialNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: "Not Found"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	err := client.DeleteCredential(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestGetCredentialSchema(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/credentials/schema/httpHeaderAuth" {
+			t.Errorf("expected path /api/v1/credentials/schema/httpHeaderAuth, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"additionalProperties":false,"type":"object",` +
+			`"properties":{"name":{"type":"string"},"value":{"type":"string"}},"required":["name","value"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	schema, err := client.GetCredentialSchema(context.Background(), "httpHeaderAuth")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schema.Required) != 2 || schema.Required[0] != "name" || schema.Required[1] != "value" {
+		t.Errorf("expected required fields [name value], got %v", schema.Required)
+	}
+	if _, ok := schema.Properties["value"]; !ok {
+		t.Errorf("expected property value, got %v", schema.Properties)
+	}
+
+	// The schema is cached per type
+	if _, err := client.GetCredentialSchema(context.Background(), "httpHeaderAuth"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected the schema to be fetched once, got %d requests", requests)
+	}
+}
+
+func TestGetCredentialSchemaUnknownType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: "not found"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	_, err := client.GetCredentialSchema(context.Background(), "noSuchType")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
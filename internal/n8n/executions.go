@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// defaultExecutionLimit caps how many executions ListExecutions fetches
+	// when no limit is given
+	defaultExecutionLimit = 100
+
+	// maxExecutionPageSize is the largest page n8n serves
+	maxExecutionPageSize = 250
+)
+
+// Execution status values reported by n8n
+const (
+	ExecutionStatusSuccess = "success"
+	ExecutionStatusError   = "error"
+	ExecutionStatusRunning = "running"
+	ExecutionStatusWaiting = "waiting"
+	ExecutionStatusCrashed = "crashed"
+)
+
+// Execution represents a single run of an n8n workflow. The execution data
+// itself is never requested.
+type Execution struct {
+	// ID is numeric in n8n but accepted either as a number or a string
+	ID         json.Number `json:"id"`
+	WorkflowID string      `json:"workflowId,omitempty"`
+	Finished   bool        `json:"finished"`
+	Mode       string      `json:"mode,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	StartedAt  string      `json:"startedAt,omitempty"`
+	StoppedAt  string      `json:"stoppedAt,omitempty"`
+}
+
+// ExecutionListResponse represents the response from listing executions
+type ExecutionListResponse struct {
+	Data       []Execution `json:"data"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+}
+
+// ExecutionListOptions filters and bounds ListExecutions
+type ExecutionListOptions struct {
+	// Status only returns executions with this status, e.g. ExecutionStatusError
+	Status string
+
+	// Limit caps the number of executions fetched across all pages. Zero means
+	// defaultExecutionLimit.
+	Limit int
+}
+
+// ListExecutions retrieves the most recent executions of a workflow, newest
+// first. Pages are fetched until opts.Limit executions are collected, so a
+// busy workflow's history is never loaded whole.
+func (c *Client) ListExecutions(ctx context.Context, workflowID string, opts *ExecutionListOptions) ([]Execution, error) {
+	if opts == nil {
+		opts = &ExecutionListOptions{}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultExecutionLimit
+	}
+
+	var executions []Execution
+	cursor := ""
+
+	for len(executions) < limit {
+		query := url.Values{}
+		query.Set("workflowId", workflowID)
+		query.Set("limit", strconv.Itoa(min(limit-len(executions), maxExecutionPageSize)))
+		if opts.Status != "" {
+			query.Set("status", opts.Status)
+		}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		respBody, err := c.doRequest(ctx, http.MethodGet, "/api/v1/executions?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list executions of workflow %s: %w", workflowID, err)
+		}
+
+		var listResp ExecutionListResponse
+		if err := json.Unmarshal(respBody, &listResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal executions: %w", err)
+		}
+
+		executions = append(executions, listResp.Data...)
+
+		if listResp.NextCursor == "" || len(listResp.Data) == 0 {
+			break
+		}
+		cursor = listResp.NextCursor
+	}
+
+	if len(executions) > limit {
+		executions = executions[:limit]
+	}
+	return executions, nil
+}
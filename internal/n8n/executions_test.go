@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestListExecutionsCapsPagination(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/executions" {
+			t.Errorf("expected path /api/v1/executions, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("workflowId"); got != "wf-1" {
+			t.Errorf("expected workflowId wf-1, got %q", got)
+		}
+		if got := r.URL.Query().Get("status"); got != ExecutionStatusError {
+			t.Errorf("expected status filter error, got %q", got)
+		}
+
+		// An endless history: every page is full and points at another
+		pages++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":[`)
+		for i := range limit {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id":%d,"status":"error","startedAt":"2025-01-01T00:00:00.000Z"}`, pages*1000+i)
+		}
+		fmt.Fprintf(w, `],"nextCursor":"page-%d"}`, pages)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	executions, err := client.ListExecutions(context.Background(), "wf-1",
+		&ExecutionListOptions{Status: ExecutionStatusError, Limit: 300})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(executions) != 300 {
+		t.Errorf("expected the limit of 300 executions, got %d", len(executions))
+	}
+	if pages != 2 {
+		t.Errorf("expected 2 pages to reach the limit, got %d", pages)
+	}
+	if executions[0].ID.String() != "1000" {
+		t.Errorf("expected numeric ID 1000, got %s", executions[0].ID)
+	}
+}
+
+func TestListExecutionsAcceptsStringIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("status"); got != "" {
+			t.Errorf("expected no status filter, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":[{"id":"42","finished":true,"status":"success"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	executions, err := client.ListExecutions(context.Background(), "wf-1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(executions) != 1 || executions[0].ID.String() != "42" || executions[0].Status != ExecutionStatusSuccess {
+		t.Errorf("expected execution 42 with status success, got %+v", executions)
+	}
+}
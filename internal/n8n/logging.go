@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// maxLoggedBodyBytes bounds how much of an error response body is logged
+const maxLoggedBodyBytes = 1024
+
+// sensitiveBodyKeys are JSON keys whose values are never logged: credential
+// data and anything that looks like a secret
+var sensitiveBodyKeys = []string{"data", "password", "secret", "token", "apikey", "api_key", "authorization"}
+
+// WithLogger sets the logger that receives request diagnostics: method, path,
+// status and duration of each request at V(1), and the truncated body of error
+// responses at the error level. The API key and credential data are redacted.
+func WithLogger(logger logr.Logger) Option {
+	return func(c *Client) {
+		c.log = logger
+	}
+}
+
+// logRequest logs the outcome of a single request. err is only logged for
+// transport failures (statusCode 0) and respBody only for error responses.
+func (c *Client) logRequest(method, path, requestID string, statusCode int, duration time.Duration, err error, respBody []byte) {
+	keysAndValues := []any{
+		"method", method,
+		"path", c.redact(path),
+		"host", c.redactedHost(),
+		"requestID", requestID,
+		"status", statusCode,
+		"durationMillis", duration.Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		c.log.Error(err, "n8n API request failed", keysAndValues...)
+	case statusCode >= 400:
+		// The error message repeats the body, so only the truncated body is logged
+		keysAndValues = append(keysAndValues, "body", c.redactBody(respBody))
+		c.log.Error(nil, "n8n API error response", keysAndValues...)
+	default:
+		c.log.V(1).Info("n8n API request", keysAndValues...)
+	}
+}
+
+// redact replaces every occurrence of the API key and the proxy password or
+// token in s
+func (c *Client) redact(s string) string {
+	for _, secret := range []string{c.apiKey, c.authSecret} {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, RedactedValue)
+		}
+	}
+	return s
+}
+
+// redactedHost returns the host of the base URL without any user info
+func (c *Client) redactedHost() string {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return ""
+	}
+	return c.redact(u.Host)
+}
+
+// redactURLError strips user info and the API key from the URL a transport
+// error reports, keeping the error type so callers can still inspect it
+func (c *Client) redactURLError(err *url.Error) {
+	if u, parseErr := url.Parse(err.URL); parseErr == nil && u.User != nil {
+		u.User = nil
+		err.URL = u.String()
+	}
+	err.URL = c.redact(err.URL)
+}
+
+// redactBody returns body truncated to maxLoggedBodyBytes with the API key and
+// the values of sensitive JSON keys replaced by RedactedValue
+func (c *Client) redactBody(body []byte) string {
+	body = bytes.TrimSpace(body)
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if redacted, err := json.Marshal(redactValue(decoded)); err == nil {
+			body = redacted
+		}
+	}
+	s := c.redact(string(body))
+	if len(s) > maxLoggedBodyBytes {
+		s = s[:maxLoggedBodyBytes] + "...(truncated)"
+	}
+	return s
+}
+
+// redactValue walks decoded JSON, replacing the values of sensitive keys
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if isSensitiveBodyKey(key) {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+	case []any:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
+
+// isSensitiveBodyKey reports whether the JSON key holds a value that must not be logged
+func isSensitiveBodyKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveBodyKeys {
+		if lower == sensitive || (sensitive != "data" && strings.Contains(lower, sensitive)) {
+			return true
+		}
+	}
+	return false
+}
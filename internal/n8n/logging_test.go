@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+)
+
+const testAPIKey = "super-secret-key"
+
+// captureLogger returns a logger at verbosity 1 and the entries it receives
+func captureLogger() (*[]string, Option) {
+	var entries []string
+	logger := funcr.New(func(prefix, args string) {
+		entries = append(entries, args)
+	}, funcr.Options{Verbosity: 1})
+	return &entries, WithLogger(logger)
+}
+
+func TestLoggerRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"123","name":"Existing","nodes":[],"connections":{}}`))
+	}))
+	defer server.Close()
+
+	entries, opt := captureLogger()
+	client := NewClient(server.URL, testAPIKey, opt)
+	if _, err := client.GetWorkflow(context.Background(), "123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d: %v", len(*entries), *entries)
+	}
+	entry := (*entries)[0]
+	for _, want := range []string{`"method"="GET"`, `"path"="/api/v1/workflows/123"`, `"status"=200`, `"durationMillis"=`} {
+		if !strings.Contains(entry, want) {
+			t.Errorf("expected log entry to contain %s, got %s", want, entry)
+		}
+	}
+	if strings.Contains(entry, testAPIKey) {
+		t.Errorf("log entry must not contain the API key: %s", entry)
+	}
+}
+
+func TestLoggerErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message":"invalid key ` + testAPIKey + `","request":{"data":{"password":"hunter2"},"accessToken":"abc"}}`))
+	}))
+	defer server.Close()
+
+	entries, opt := captureLogger()
+	client := NewClient(server.URL, testAPIKey, opt)
+	_, err := client.CreateCredential(context.Background(), &Credential{Name: "Slack", Type: "slackApi"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), testAPIKey) {
+		t.Errorf("error must not contain the API key: %v", err)
+	}
+
+	if len(*entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d: %v", len(*entries), *entries)
+	}
+	entry := (*entries)[0]
+	if !strings.Contains(entry, `"msg"="n8n API error response"`) || !strings.Contains(entry, `"status"=400`) {
+		t.Errorf("expected an error entry with the status, got %s", entry)
+	}
+	if !strings.Contains(entry, `"body"=`) || !strings.Contains(entry, RedactedValue) {
+		t.Errorf("expected the redacted body to be logged, got %s", entry)
+	}
+	for _, secret := range []string{testAPIKey, "hunter2", `abc`} {
+		if strings.Contains(entry, secret) {
+			t.Errorf("log entry must not contain %q: %s", secret, entry)
+		}
+	}
+}
+
+func TestLoggerTruncatesBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(strings.Repeat("x", 4*maxLoggedBodyBytes)))
+	}))
+	defer server.Close()
+
+	entries, opt := captureLogger()
+	client := NewClient(server.URL, testAPIKey, opt, WithRetry(1, 0))
+	if _, err := client.GetWorkflow(context.Background(), "123"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(*entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(*entries))
+	}
+	if !strings.Contains((*entries)[0], "...(truncated)") || len((*entries)[0]) > 2*maxLoggedBodyBytes {
+		t.Errorf("expected the body to be truncated, got %d bytes", len((*entries)[0]))
+	}
+}
+
+func TestTransportErrorRedactsURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL, _ := url.Parse(server.URL)
+	server.Close()
+
+	// User info in the base URL, with the API key as the password
+	serverURL.User = url.UserPassword("admin", testAPIKey)
+	entries, opt := captureLogger()
+	client := NewClient(serverURL.String(), testAPIKey, opt, WithRetry(1, 0))
+	_, err := client.GetWorkflow(context.Background(), "123")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("expected a *url.Error to remain inspectable, got %T", err)
+	}
+	if strings.Contains(err.Error(), testAPIKey) || strings.Contains(err.Error(), "admin") {
+		t.Errorf("error must not contain user info: %v", err)
+	}
+	for _, entry := range *entries {
+		if strings.Contains(entry, testAPIKey) {
+			t.Errorf("log entry must not contain the API key: %s", entry)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrPatchUnsupported is returned by PatchWorkflow when the instance doesn't
+// accept partial workflow updates; UpdateWorkflow must be used instead
+var ErrPatchUnsupported = errors.New("n8n does not support partial workflow updates")
+
+// WorkflowPatch holds the workflow fields to change. Unset fields are left as
+// they are in n8n, so a field can't be cleared with a patch.
+type WorkflowPatch struct {
+	Name        string           `json:"name,omitempty"`
+	Nodes       []map[string]any `json:"nodes,omitempty"`
+	Connections map[string]any   `json:"connections,omitempty"`
+	Settings    map[string]any   `json:"settings,omitempty"`
+	StaticData  map[string]any   `json:"staticData,omitempty"`
+	PinData     map[string]any   `json:"pinData,omitempty"`
+	Meta        map[string]any   `json:"meta,omitempty"`
+}
+
+// PatchWorkflow sends only the fields set in patch. Once the instance answers
+// 405 or 501 it is remembered in the client's InstanceState as not supporting
+// partial updates, and this and every later call of a client sharing that
+// state return ErrPatchUnsupported without a request.
+func (c *Client) PatchWorkflow(ctx context.Context, id string, patch *WorkflowPatch) (*Workflow, error) {
+	if c.state.patchUnsupported.Load() {
+		return nil, ErrPatchUnsupported
+	}
+
+	respBody, err := c.doRequest(ctx, http.MethodPatch, "/api/v1/workflows/"+id, patch)
+	if err != nil {
+		var apiErr *ErrorResponse
+		if errors.As(err, &apiErr) &&
+			(apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented) {
+			c.state.patchUnsupported.Store(true)
+			return nil, ErrPatchUnsupported
+		}
+		return nil, fmt.Errorf("failed to patch workflow %s: %w", id, err)
+	}
+
+	var updated Workflow
+	if err := json.Unmarshal(respBody, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal patched workflow: %w", err)
+	}
+
+	return &updated, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPatchWorkflowNameOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/workflows/123" {
+			t.Errorf("expected path /api/v1/workflows/123, got %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"Renamed"}` {
+			t.Errorf("expected a name-only body, got %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"123","name":"Renamed","nodes":[{"name":"Start"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	result, err := client.PatchWorkflow(context.Background(), "123", &WorkflowPatch{Name: "Renamed"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "Renamed" || len(result.Nodes) != 1 {
+		t.Errorf("expected the patched workflow, got %+v", result)
+	}
+}
+
+func TestPatchWorkflowUnsupported(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_, _ = w.Write([]byte(`{"message":"PATCH method not allowed"}`))
+	}))
+	defer server.Close()
+
+	// Clients are built per reconcile, so the rejection must outlive the client
+	registry := NewInstanceStateRegistry()
+	for range 2 {
+		client := NewClient(server.URL, "test-key", WithInstanceState(registry.For(server.URL)))
+		_, err := client.PatchWorkflow(context.Background(), "123", &WorkflowPatch{Name: "Renamed"})
+		if !errors.Is(err, ErrPatchUnsupported) {
+			t.Fatalf("expected ErrPatchUnsupported, got %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request once PATCH was rejected, got %d", got)
+	}
+}
+
+func TestPatchWorkflowError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	_, err := client.PatchWorkflow(context.Background(), "123", &WorkflowPatch{Name: "Renamed"})
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if errors.Is(err, ErrPatchUnsupported) {
+		t.Error("a missing workflow must not mark PATCH as unsupported")
+	}
+}
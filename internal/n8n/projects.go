@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// workflowOwnerRole is the sharing role of the project owning a workflow
+const workflowOwnerRole = "workflow:owner"
+
+// transferRequest is the body of a workflow transfer
+type transferRequest struct {
+	DestinationProjectID string `json:"destinationProjectId"`
+}
+
+// ProjectID returns the ID of the project owning the workflow, or "" if the
+// response didn't include sharing information, as on instances without projects
+func (w *Workflow) ProjectID() string {
+	for _, share := range w.Shared {
+		if role, _ := share["role"].(string); role != workflowOwnerRole {
+			continue
+		}
+		if id, ok := share["projectId"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// TransferWorkflow moves a workflow to another project. Projects are an
+// enterprise feature; see IsProjectsUnavailable for the error other
+// editions return.
+func (c *Client) TransferWorkflow(ctx context.Context, workflowID, projectID string) error {
+	_, err := c.doRequest(ctx, http.MethodPut, "/api/v1/workflows/"+workflowID+"/transfer",
+		&transferRequest{DestinationProjectID: projectID})
+	if err != nil {
+		return fmt.Errorf("failed to transfer workflow %s to project %s: %w", workflowID, projectID, err)
+	}
+	return nil
+}
+
+// IsProjectsUnavailable returns true if err is n8n refusing a project operation
+// with a 403, as instances without the projects feature licensed do
+func IsProjectsUnavailable(err error) bool {
+	var apiErr *ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden
+}
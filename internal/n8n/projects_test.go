@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransferWorkflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/workflows/123/transfer" {
+			t.Errorf("expected path /api/v1/workflows/123/transfer, got %s", r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if body["destinationProjectId"] != "proj-1" {
+			t.Errorf("expected destinationProjectId proj-1, got %q", body["destinationProjectId"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if err := client.TransferWorkflow(context.Background(), "123", "proj-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTransferWorkflowUnlicensed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: "Plan lacks license for this feature"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	err := client.TransferWorkflow(context.Background(), "123", "proj-1")
+	if !IsProjectsUnavailable(err) {
+		t.Errorf("expected a projects unavailable error, got %v", err)
+	}
+}
+
+func TestWorkflowProjectID(t *testing.T) {
+	wf := Workflow{Shared: []map[string]any{
+		{"role": "workflow:editor", "projectId": "other"},
+		{"role": "workflow:owner", "projectId": "owner-project"},
+	}}
+	if got := wf.ProjectID(); got != "owner-project" {
+		t.Errorf("expected owner-project, got %q", got)
+	}
+	if got := (&Workflow{}).ProjectID(); got != "" {
+		t.Errorf("expected no project without sharing information, got %q", got)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiterRegistry hands out one rate limiter per n8n instance URL, so every
+// client built for the same instance shares a single request budget no matter
+// how many workflows are being reconciled
+type RateLimiterRegistry struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewRateLimiterRegistry creates a registry allowing requestsPerSecond sustained
+// requests per instance with bursts of up to burst. A requestsPerSecond of zero
+// or less disables limiting.
+func NewRateLimiterRegistry(requestsPerSecond float64, burst int) *RateLimiterRegistry {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiterRegistry{
+		limit:    rate.Limit(requestsPerSecond),
+		burst:    burst,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// For returns the limiter shared by all clients of the instance at baseURL, or
+// nil when the registry is nil or limiting is disabled
+func (r *RateLimiterRegistry) For(baseURL string) *rate.Limiter {
+	if r == nil || r.limit <= 0 {
+		return nil
+	}
+	return r.limiter(baseURL, r.limit, r.burst)
+}
+
+// ForLimit returns the limiter shared by all clients of the instance at
+// baseURL, allowing requestsPerSecond with bursts of up to burst instead of the
+// registry's defaults, e.g. for an instance with its own API quota. It is nil
+// only when the registry is nil.
+func (r *RateLimiterRegistry) ForLimit(baseURL string, requestsPerSecond float64, burst int) *rate.Limiter {
+	if r == nil {
+		return nil
+	}
+	return r.limiter(baseURL, rate.Limit(requestsPerSecond), max(burst, 1))
+}
+
+// limiter returns the limiter for baseURL, creating it or updating its limit
+// and burst so a changed budget applies to requests already waiting
+func (r *RateLimiterRegistry) limiter(baseURL string, limit rate.Limit, burst int) *rate.Limiter {
+	key := instanceKey(baseURL)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limiter, ok := r.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		r.limiters[key] = limiter
+		return limiter
+	}
+	if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
+
+// instanceKey identifies the instance at baseURL regardless of case and a
+// trailing slash
+func instanceKey(baseURL string) string {
+	return strings.ToLower(strings.TrimRight(baseURL, "/"))
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRegistryKeying(t *testing.T) {
+	registry := NewRateLimiterRegistry(5, 1)
+
+	if registry.For("http://n8n:5678") != registry.For("HTTP://n8n:5678/") {
+		t.Error("expected the same limiter regardless of case and trailing slash")
+	}
+	if registry.For("http://n8n:5678") == registry.For("http://other:5678") {
+		t.Error("expected different instances to get different limiters")
+	}
+
+	if NewRateLimiterRegistry(0, 10).For("http://n8n:5678") != nil {
+		t.Error("expected no limiter when rate limiting is disabled")
+	}
+	var nilRegistry *RateLimiterRegistry
+	if nilRegistry.For("http://n8n:5678") != nil {
+		t.Error("expected no limiter from a nil registry")
+	}
+}
+
+func TestRateLimiterRegistryForLimit(t *testing.T) {
+	registry := NewRateLimiterRegistry(0, 10)
+
+	limiter := registry.ForLimit("http://n8n:5678", 0.5, 2)
+	if limiter == nil {
+		t.Fatal("expected a per-instance limit to apply even when the default is disabled")
+	}
+	if limiter.Limit() != 0.5 || limiter.Burst() != 2 {
+		t.Errorf("expected 0.5/s with burst 2, got %v/s with burst %d", limiter.Limit(), limiter.Burst())
+	}
+
+	// A changed budget updates the shared limiter in place
+	if registry.ForLimit("http://n8n:5678/", 2, 0) != limiter {
+		t.Error("expected the same limiter for the same instance")
+	}
+	if limiter.Limit() != 2 || limiter.Burst() != 1 {
+		t.Errorf("expected 2/s with burst 1, got %v/s with burst %d", limiter.Limit(), limiter.Burst())
+	}
+
+	var nilRegistry *RateLimiterRegistry
+	if nilRegistry.ForLimit("http://n8n:5678", 1, 1) != nil {
+		t.Error("expected no limiter from a nil registry")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name             string
+		retryAfter       string
+		call             func(c *Client) error
+		expectedAttempts int
+		minElapsed       time.Duration
+		expectErr        bool
+	}{
+		{
+			name:             "get waits out Retry-After seconds",
+			retryAfter:       "1",
+			call:             func(c *Client) error { _, err := c.GetWorkflow(context.Background(), "123"); return err },
+			expectedAttempts: 2,
+			minElapsed:       time.Second,
+		},
+		{
+			name:       "create is resent after a 429",
+			retryAfter: "0",
+			call: func(c *Client) error {
+				_, err := c.CreateWorkflow(context.Background(), &Workflow{Name: "New"})
+				return err
+			},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "Retry-After beyond the maximum fails immediately",
+			retryAfter:       "3600",
+			call:             func(c *Client) error { _, err := c.GetWorkflow(context.Background(), "123"); return err },
+			expectedAttempts: 1,
+			expectErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+					json.NewEncoder(w).Encode(ErrorResponse{Message: "Too many requests"})
+					return
+				}
+				json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Existing"})
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key", WithRetry(3, time.Millisecond))
+			start := time.Now()
+			err := tt.call(client)
+			if tt.expectErr && err == nil {
+				t.Fatal("expected error")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, attempts)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minElapsed {
+				t.Errorf("expected to wait at least %v, returned after %v", tt.minElapsed, elapsed)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-5", 0},
+		{"soon", 0},
+		{"Sun, 01 Jun 2025 10:00:30 GMT", 30 * time.Second},
+		{"Sun, 01 Jun 2025 09:59:00 GMT", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.expected {
+			t.Errorf("parseRetryAfter(%q) = %v, expected %v", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestSharedRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Test"})
+	}))
+	defer server.Close()
+
+	// 20 requests per second with no burst allowance: after the first request
+	// every further one waits 50ms, however many clients it is spread across
+	registry := NewRateLimiterRegistry(20, 1)
+	const clients, requestsPerClient = 3, 3
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		client := NewClient(server.URL, "test-key", WithRateLimiter(registry.For(server.URL)))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < requestsPerClient; j++ {
+				if _, err := client.GetWorkflow(context.Background(), "123"); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 9 requests at 20/s take at least 8 intervals of 50ms in total. Per-client
+	// limiters would have let the three clients finish in about 100ms.
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("expected shared throttling to take at least 350ms, took %v", elapsed)
+	}
+}
+
+func TestRateLimitHonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Workflow{ID: "123", Name: "Test"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key", WithRateLimiter(NewRateLimiterRegistry(0.1, 1).For(server.URL)))
+	if _, err := client.GetWorkflow(context.Background(), "123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The next token is 10s away, so a short deadline must fail fast
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetWorkflow(ctx, "123"); err == nil {
+		t.Error("expected an error when the context expires while waiting for the limiter")
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SourceControlPullRequest is the body of a source control pull
+type SourceControlPullRequest struct {
+	// Force overwrites changes made in n8n since the last push
+	Force bool `json:"force,omitempty"`
+}
+
+// SourceControlItem is a workflow, credential or tag imported by a pull
+type SourceControlItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// SourceControlPullResult lists what a source control pull imported
+type SourceControlPullResult struct {
+	Workflows   []SourceControlItem `json:"workflows"`
+	Credentials []SourceControlItem `json:"credentials"`
+	Variables   struct {
+		Added   []string `json:"added"`
+		Changed []string `json:"changed"`
+	} `json:"variables"`
+	Tags struct {
+		Tags []SourceControlItem `json:"tags"`
+	} `json:"tags"`
+}
+
+// SourceControlPull pulls the connected git repository into n8n. Source
+// control must be licensed and connected; see IsSourceControlUnavailable for
+// the error returned otherwise.
+func (c *Client) SourceControlPull(ctx context.Context, req *SourceControlPullRequest) (*SourceControlPullResult, error) {
+	respBody, err := c.doRequest(ctx, http.MethodPost, "/api/v1/source-control/pull", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull from source control: %w", err)
+	}
+
+	var result SourceControlPullResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal source control pull result: %w", err)
+	}
+	return &result, nil
+}
+
+// IsSourceControlUnavailable returns true if err is n8n refusing a source
+// control operation because source control isn't licensed or connected to a
+// repository, or because the instance predates the endpoint
+func IsSourceControlUnavailable(err error) bool {
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound:
+		return true
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return strings.Contains(strings.ToLower(apiErr.Message), "source control")
+	}
+	return false
+}
+
+// IsConflict returns true if err is n8n refusing a request with a 409, as a
+// source control pull does when changes made in n8n would be overwritten
+func IsConflict(err error) bool {
+	var apiErr *ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSourceControlPull(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/source-control/pull" {
+			t.Errorf("expected POST /api/v1/source-control/pull, got %s %s", r.Method, r.URL.Path)
+		}
+		var req SourceControlPullRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Force {
+			t.Errorf("expected a forced pull, got %+v (%v)", req, err)
+		}
+		w.Write([]byte(`{
+			"workflows": [{"id": "wf-1", "name": "Orders"}],
+			"credentials": [{"id": "cred-1", "name": "Partner API", "type": "httpBasicAuth"}],
+			"variables": {"added": ["REGION"], "changed": ["API_HOST"]},
+			"tags": {"tags": [{"id": "tag-1", "name": "prod"}], "mappings": [{"workflowId": "wf-1", "tagId": "tag-1"}]}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	result, err := client.SourceControlPull(context.Background(), &SourceControlPullRequest{Force: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Workflows) != 1 || result.Workflows[0].ID != "wf-1" {
+		t.Errorf("expected workflow wf-1, got %+v", result.Workflows)
+	}
+	if len(result.Credentials) != 1 || result.Credentials[0].Type != "httpBasicAuth" {
+		t.Errorf("expected an httpBasicAuth credential, got %+v", result.Credentials)
+	}
+	if len(result.Variables.Added) != 1 || len(result.Variables.Changed) != 1 {
+		t.Errorf("expected one added and one changed variable, got %+v", result.Variables)
+	}
+	if len(result.Tags.Tags) != 1 || result.Tags.Tags[0].Name != "prod" {
+		t.Errorf("expected tag prod, got %+v", result.Tags.Tags)
+	}
+}
+
+func TestSourceControlPullErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		unavailable bool
+		conflict    bool
+	}{
+		{"not connected", http.StatusUnauthorized, `{"message":"Source Control is not connected to a repository"}`, true, false},
+		{"endpoint missing", http.StatusNotFound, `{"message":"not found"}`, true, false},
+		{"invalid API key", http.StatusUnauthorized, `{"message":"unauthorized"}`, false, false},
+		{"local changes", http.StatusConflict, `[{"id":"wf-1","name":"Orders","conflict":true}]`, false, true},
+		{"server error", http.StatusInternalServerError, `{"message":"boom"}`, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key", WithRetry(1, 0))
+			_, err := client.SourceControlPull(context.Background(), &SourceControlPullRequest{})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := IsSourceControlUnavailable(err); got != tt.unavailable {
+				t.Errorf("IsSourceControlUnavailable = %v, want %v (%v)", got, tt.unavailable, err)
+			}
+			if got := IsConflict(err); got != tt.conflict {
+				t.Errorf("IsConflict = %v, want %v (%v)", got, tt.conflict, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WriteProbeTTL is how long a ProbeWriteAccess result is reused before the
+// instance is probed again
+const WriteProbeTTL = 10 * time.Minute
+
+// InstanceState holds what a client learns about an n8n instance that stays
+// true beyond a single reconcile, such as the credential data schemas, whether
+// the API key can write, whether the Idempotency-Key header is honoured or that
+// partial updates or tags on create are unsupported. Clients are built per
+// reconcile, so the state is shared through an InstanceStateRegistry to
+// survive them.
+type InstanceState struct {
+	// schemaMu guards schemas, the credential data schemas fetched so far by type
+	schemaMu sync.Mutex
+	schemas  map[string]*CredentialSchema
+
+	// patchUnsupported is set once n8n rejects a PATCH of a workflow, after
+	// which PatchWorkflow no longer tries
+	patchUnsupported atomic.Bool
+
+	// inlineTagsUnsupported is set once n8n rejects tags when creating a
+	// workflow, after which CreateWorkflow no longer sends them
+	inlineTagsUnsupported atomic.Bool
+
+	// idempotencyUnsupported is set once n8n rejects the Idempotency-Key
+	// header, after which it is no longer sent. idempotencySupported is set
+	// once a request carrying it succeeds, after which keyed creates are retried.
+	idempotencyUnsupported atomic.Bool
+	idempotencySupported   atomic.Bool
+
+	// writeProbeMu guards writeProbe, the last conclusive ProbeWriteAccess result
+	writeProbeMu sync.Mutex
+	writeProbe   *writeProbeResult
+}
+
+// writeProbeResult is a ProbeWriteAccess result for the API key with the
+// given hash, so a rotated key is probed afresh
+type writeProbeResult struct {
+	keyHash  [sha256.Size]byte
+	canWrite bool
+	at       time.Time
+}
+
+// cachedWriteAccess returns the write access last probed for apiKey, if it is
+// younger than WriteProbeTTL
+func (s *InstanceState) cachedWriteAccess(apiKey string) (canWrite, ok bool) {
+	s.writeProbeMu.Lock()
+	defer s.writeProbeMu.Unlock()
+	probe := s.writeProbe
+	if probe == nil || probe.keyHash != sha256.Sum256([]byte(apiKey)) || time.Since(probe.at) >= WriteProbeTTL {
+		return false, false
+	}
+	return probe.canWrite, true
+}
+
+// storeWriteAccess records the write access probed for apiKey
+func (s *InstanceState) storeWriteAccess(apiKey string, canWrite bool) {
+	s.writeProbeMu.Lock()
+	defer s.writeProbeMu.Unlock()
+	s.writeProbe = &writeProbeResult{keyHash: sha256.Sum256([]byte(apiKey)), canWrite: canWrite, at: time.Now()}
+}
+
+// WithInstanceState makes the client keep what it learns about the instance
+// in state, e.g. one obtained from an InstanceStateRegistry. A nil state keeps
+// a state of the client's own.
+func WithInstanceState(state *InstanceState) Option {
+	return func(c *Client) {
+		if state != nil {
+			c.state = state
+		}
+	}
+}
+
+// InstanceStateRegistry hands out one InstanceState per n8n instance URL, so
+// every client built for the same instance shares what was learned about it
+type InstanceStateRegistry struct {
+	mu     sync.Mutex
+	states map[string]*InstanceState
+}
+
+// NewInstanceStateRegistry creates an empty registry
+func NewInstanceStateRegistry() *InstanceStateRegistry {
+	return &InstanceStateRegistry{states: map[string]*InstanceState{}}
+}
+
+// For returns the state shared by all clients of the instance at baseURL. It
+// is nil when the registry is nil.
+func (r *InstanceStateRegistry) For(baseURL string) *InstanceState {
+	if r == nil {
+		return nil
+	}
+	key := instanceKey(baseURL)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, ok := r.states[key]
+	if !ok {
+		state = &InstanceState{}
+		r.states[key] = state
+	}
+	return state
+}
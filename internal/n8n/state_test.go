@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstanceStateRegistrySharesSchemas(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"type":"object","properties":{"value":{"type":"string"}},"required":["value"]}`))
+	}))
+	defer server.Close()
+
+	registry := NewInstanceStateRegistry()
+	// Clients are built per reconcile, and the URL may be spelled differently
+	for _, baseURL := range []string{server.URL, server.URL + "/"} {
+		client := NewClient(baseURL, "test-key", WithInstanceState(registry.For(baseURL)))
+		if _, err := client.GetCredentialSchema(context.Background(), "httpHeaderAuth"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the schema to be fetched once across clients, got %d requests", requests)
+	}
+
+	// Clients without a shared state each fetch it
+	if _, err := NewClient(server.URL, "test-key").GetCredentialSchema(context.Background(), "httpHeaderAuth"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected a client of its own to fetch the schema, got %d requests", requests)
+	}
+}
+
+func TestInstanceStateRegistryNil(t *testing.T) {
+	var registry *InstanceStateRegistry
+	if state := registry.For("http://n8n:5678"); state != nil {
+		t.Errorf("expected no state from a nil registry, got %v", state)
+	}
+}
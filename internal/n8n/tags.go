@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Tag represents an n8n tag
+type Tag struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// TagListResponse represents the response from listing tags
+type TagListResponse struct {
+	Data       []Tag  `json:"data"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// tagRef identifies a tag by ID when setting a workflow's tags
+type tagRef struct {
+	ID string `json:"id"`
+}
+
+// workflowTagRefs returns references to the tags of wf that carry an ID
+func workflowTagRefs(wf *Workflow) []tagRef {
+	var refs []tagRef
+	for _, tag := range wf.Tags {
+		if id, _ := tag["id"].(string); id != "" {
+			refs = append(refs, tagRef{ID: id})
+		}
+	}
+	return refs
+}
+
+// InlineTagsMinVersion is the first n8n release whose public API accepts tag
+// IDs when creating a workflow. Older releases treat tags as read-only on
+// create, so they are associated in a second call there.
+const InlineTagsMinVersion = "1.0"
+
+// tagsReadOnlyMessage is the validation error n8n answers a create request
+// carrying tags with where they are read-only
+const tagsReadOnlyMessage = "request/body/tags is read-only"
+
+// isTagsReadOnly reports whether err is n8n refusing the tags of a create
+// request because they are read-only there, rather than e.g. an unknown tag ID
+func isTagsReadOnly(err error) bool {
+	var apiErr *ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest &&
+		strings.EqualFold(strings.TrimSpace(apiErr.Message), tagsReadOnlyMessage)
+}
+
+// InlineTagsSupported reports whether tags may be attached when creating a
+// workflow on an instance running version, i.e. whether version is
+// InlineTagsMinVersion or newer. An unknown or unparsable version, and an
+// instance that rejected tags on create before, take the two-step path, which
+// works on every release.
+func (c *Client) InlineTagsSupported(version string) bool {
+	if c.state.inlineTagsUnsupported.Load() {
+		return false
+	}
+	newer, err := CompareVersions(version, InlineTagsMinVersion)
+	return err == nil && newer >= 0
+}
+
+// ListTags retrieves all tags from n8n
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	var allTags []Tag
+	cursor := ""
+
+	for {
+		path := "/api/v1/tags"
+		if cursor != "" {
+			path += "?cursor=" + cursor
+		}
+
+		respBody, err := c.doRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		var listResp TagListResponse
+		if err := json.Unmarshal(respBody, &listResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+		}
+
+		allTags = append(allTags, listResp.Data...)
+
+		if listResp.NextCursor == "" {
+			break
+		}
+		cursor = listResp.NextCursor
+	}
+
+	return allTags, nil
+}
+
+// CreateTag creates a new tag in n8n
+func (c *Client) CreateTag(ctx context.Context, name string) (*Tag, error) {
+	respBody, err := c.doRequest(ctx, http.MethodPost, "/api/v1/tags", &Tag{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+
+	var created Tag
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal created tag: %w", err)
+	}
+
+	return &created, nil
+}
+
+// UpdateTag renames an existing tag
+func (c *Client) UpdateTag(ctx context.Context, id, name string) (*Tag, error) {
+	respBody, err := c.doRequest(ctx, http.MethodPut, "/api/v1/tags/"+id, &Tag{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tag %s: %w", id, err)
+	}
+
+	var updated Tag
+	if err := json.Unmarshal(respBody, &updated); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal updated tag: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// DeleteTag deletes a tag from n8n, detaching it from every workflow
+func (c *Client) DeleteTag(ctx context.Context, id string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, "/api/v1/tags/"+id, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag %s: %w", id, err)
+	}
+	return nil
+}
+
+// UpdateWorkflowTags replaces the tags attached to a workflow with tagIDs and
+// returns the tags now attached
+func (c *Client) UpdateWorkflowTags(ctx context.Context, id string, tagIDs []string) ([]Tag, error) {
+	refs := make([]tagRef, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		refs = append(refs, tagRef{ID: tagID})
+	}
+
+	respBody, err := c.doRequest(ctx, http.MethodPut, "/api/v1/workflows/"+id+"/tags", refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tags of workflow %s: %w", id, err)
+	}
+
+	var tags []Tag
+	if err := json.Unmarshal(respBody, &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow tags: %w", err)
+	}
+
+	return tags, nil
+}
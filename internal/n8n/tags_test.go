@@ -0,0 +1,294 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListTagsPaginated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/tags" {
+			t.Errorf("expected path /api/v1/tags, got %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("cursor") == "" {
+			json.NewEncoder(w).Encode(TagListResponse{Data: []Tag{{ID: "1", Name: "prod"}}, NextCursor: "next"})
+			return
+		}
+		json.NewEncoder(w).Encode(TagListResponse{Data: []Tag{{ID: "2", Name: "billing"}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	tags, err := client.ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags across both pages, got %d", len(tags))
+	}
+	if tags[1].Name != "billing" {
+		t.Errorf("expected second tag to be billing, got %s", tags[1].Name)
+	}
+}
+
+func TestCreateTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/tags" {
+			t.Errorf("expected path /api/v1/tags, got %s", r.URL.Path)
+		}
+
+		var tag Tag
+		json.NewDecoder(r.Body).Decode(&tag)
+		json.NewEncoder(w).Encode(Tag{ID: "7", Name: tag.Name})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	tag, err := client.CreateTag(context.Background(), "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tag.ID != "7" || tag.Name != "prod" {
+		t.Errorf("expected tag 7 named prod, got %+v", tag)
+	}
+}
+
+func TestUpdateTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/tags/7" {
+			t.Errorf("expected path /api/v1/tags/7, got %s", r.URL.Path)
+		}
+
+		var tag Tag
+		json.NewDecoder(r.Body).Decode(&tag)
+		if tag.ID != "" {
+			t.Errorf("expected only the name to be sent, got ID %s", tag.ID)
+		}
+		json.NewEncoder(w).Encode(Tag{ID: "7", Name: tag.Name})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	tag, err := client.UpdateTag(context.Background(), "7", "production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tag.ID != "7" || tag.Name != "production" {
+		t.Errorf("expected tag 7 named production, got %+v", tag)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/tags/7" {
+			t.Errorf("expected path /api/v1/tags/7, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(Tag{ID: "7", Name: "prod"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if err := client.DeleteTag(context.Background(), "7"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteTagNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: "Not Found"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	err := client.DeleteTag(context.Background(), "7")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestUpdateWorkflowTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/workflows/123/tags" {
+			t.Errorf("expected path /api/v1/workflows/123/tags, got %s", r.URL.Path)
+		}
+
+		var refs []map[string]string
+		json.NewDecoder(r.Body).Decode(&refs)
+		if len(refs) != 2 || refs[0]["id"] != "1" || refs[1]["id"] != "2" {
+			t.Errorf("expected tag IDs [1 2], got %v", refs)
+		}
+		json.NewEncoder(w).Encode([]Tag{{ID: "1", Name: "prod"}, {ID: "2", Name: "billing"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	tags, err := client.UpdateWorkflowTags(context.Background(), "123", []string{"1", "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tags) != 2 {
+		t.Errorf("expected 2 tags attached, got %d", len(tags))
+	}
+}
+
+func TestUpdateWorkflowTagsEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, 16)
+		n, _ := r.Body.Read(body)
+		if string(body[:n]) != "[]" {
+			t.Errorf("expected an empty JSON array to clear the tags, got %s", body[:n])
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if _, err := client.UpdateWorkflowTags(context.Background(), "123", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateWorkflowWithInlineTags(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		json.NewEncoder(w).Encode(Workflow{ID: "wf-1", Name: "Tagged", Tags: []map[string]any{{"id": "t1", "name": "prod"}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	created, err := client.CreateWorkflow(context.Background(), &Workflow{Name: "Tagged", Tags: []map[string]any{{"id": "t1", "name": "prod"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("expected a single request, got %d", len(bodies))
+	}
+	tags, _ := bodies[0]["tags"].([]any)
+	if len(tags) != 1 || tags[0].(map[string]any)["id"] != "t1" {
+		t.Errorf("expected the tag IDs in the create request, got %v", bodies[0]["tags"])
+	}
+	if len(created.TagNames()) != 1 || !client.InlineTagsSupported("1.64.0") {
+		t.Errorf("expected the tags to be attached on create, got %v", created.TagNames())
+	}
+}
+
+func TestInlineTagsSupported(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.64.0", true},
+		{InlineTagsMinVersion, true},
+		{"0.236.3", false},
+		{"", false},
+		{"unknown", false},
+	}
+	client := NewClient("http://n8n.example", "test-key")
+	for _, tt := range tests {
+		if got := client.InlineTagsSupported(tt.version); got != tt.want {
+			t.Errorf("InlineTagsSupported(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCreateWorkflowTagsReadOnly(t *testing.T) {
+	var withTags, withoutTags int
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if _, ok := body["tags"]; ok {
+			withTags++
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Message: "request/body/tags is read-only"})
+			return
+		}
+		withoutTags++
+		json.NewEncoder(w).Encode(Workflow{ID: "wf-1", Name: "Tagged"})
+	}))
+	defer server.Close()
+
+	// Clients are built per reconcile, so the rejection must outlive the client
+	registry := NewInstanceStateRegistry()
+	for range 2 {
+		client := NewClient(server.URL, "test-key", WithInstanceState(registry.For(server.URL)))
+		ctx := WithIdempotencyKey(context.Background(), "create-key")
+		created, err := client.CreateWorkflow(ctx, &Workflow{Name: "Tagged", Tags: []map[string]any{{"id": "t1"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created.ID != "wf-1" || client.InlineTagsSupported("1.64.0") {
+			t.Errorf("expected the workflow to be created without tags, got %+v", created)
+		}
+	}
+	if withTags != 1 || withoutTags != 2 {
+		t.Errorf("expected tags to be sent once, got %d requests with and %d without", withTags, withoutTags)
+	}
+	if keys[0] == keys[1] {
+		t.Errorf("expected the create without tags to carry a new idempotency key, got %q twice", keys[0])
+	}
+}
+
+func TestCreateWorkflowOtherBadRequest(t *testing.T) {
+	for _, message := range []string{"request/body/name must be string", "request/body/tags/0/id must be string", "Tag t1 not found in tags"} {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(ErrorResponse{Message: message})
+		}))
+
+		client := NewClient(server.URL, "test-key")
+		if _, err := client.CreateWorkflow(context.Background(), &Workflow{Tags: []map[string]any{{"id": "t1"}}}); err == nil {
+			t.Fatalf("expected an error for %q", message)
+		}
+		if requests != 1 || !client.InlineTagsSupported("1.64.0") {
+			t.Errorf("expected %q not to retry or disable inline tags, got %d requests", message, requests)
+		}
+		server.Close()
+	}
+}
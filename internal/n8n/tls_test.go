@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTLSServer starts an HTTPS server answering every request with an empty
+// workflow list, and returns it with its certificate in PEM form
+func newTLSServer(t *testing.T) (*httptest.Server, []byte) {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(WorkflowListResponse{Data: []Workflow{}})
+	}))
+	t.Cleanup(server.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, caPEM
+}
+
+func TestClientTrustsCustomCA(t *testing.T) {
+	server, caPEM := newTLSServer(t)
+
+	config, err := NewTLSConfig(caPEM, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewClient(server.URL, "test-key", WithTLSConfig(config))
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Errorf("expected the health check to pass with the server's CA, got %v", err)
+	}
+}
+
+func TestClientRejectsUnknownCA(t *testing.T) {
+	server, _ := newTLSServer(t)
+
+	client := NewClient(server.URL, "test-key", WithRetry(1, 0))
+	err := client.HealthCheck(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("expected a certificate error without the CA, got %v", err)
+	}
+}
+
+func TestClientInsecureSkipVerify(t *testing.T) {
+	server, _ := newTLSServer(t)
+
+	config, err := NewTLSConfig(nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := NewClient(server.URL, "test-key", WithTLSConfig(config))
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Errorf("expected the health check to pass without verification, got %v", err)
+	}
+}
+
+func TestNewTLSConfigRejectsInvalidBundle(t *testing.T) {
+	if _, err := NewTLSConfig([]byte("not a certificate"), false); err == nil {
+		t.Error("expected an error for a bundle without certificates")
+	}
+}
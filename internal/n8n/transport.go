@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ConnectionPool tunes the idle connections a transport keeps open to n8n for
+// reuse by later requests. Zero fields keep the defaults.
+type ConnectionPool struct {
+	// MaxIdleConns caps the idle connections kept across all hosts
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost caps the idle connections kept to each host
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept before it is closed
+	IdleConnTimeout time.Duration
+}
+
+const (
+	// DefaultMaxIdleConns is the number of idle connections kept across all hosts
+	DefaultMaxIdleConns = 100
+
+	// DefaultMaxIdleConnsPerHost is the number of idle connections kept to each
+	// host. http.DefaultTransport keeps only 2, so concurrent reconciles of one
+	// instance would otherwise keep closing connections and dialing new ones.
+	DefaultMaxIdleConnsPerHost = 16
+
+	// DefaultIdleConnTimeout is how long an idle connection is kept
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// withDefaults returns the pool with unset fields filled in
+func (p ConnectionPool) withDefaults() ConnectionPool {
+	if p.MaxIdleConns <= 0 {
+		p.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if p.MaxIdleConnsPerHost <= 0 {
+		p.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if p.IdleConnTimeout <= 0 {
+		p.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	return p
+}
+
+// defaultTransport is shared by every client without its own TLS config,
+// connection pool or transport, so they reuse each other's connections
+var defaultTransport = NewTransport(ConnectionPool{}, nil)
+
+// NewTransport returns a transport based on http.DefaultTransport keeping the
+// idle connections allowed by pool. A non-nil tlsConfig verifies the server.
+func NewTransport(pool ConnectionPool, tlsConfig *tls.Config) *http.Transport {
+	pool = pool.withDefaults()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = pool.MaxIdleConns
+	transport.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = pool.IdleConnTimeout
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport
+}
+
+// WithTransport makes the client send requests through transport, e.g. one
+// obtained from a TransportRegistry, instead of building its own from
+// WithTLSConfig and WithConnectionPool. A nil transport keeps the default.
+func WithTransport(transport *http.Transport) Option {
+	return func(c *Client) {
+		if transport != nil {
+			c.transport = transport
+		}
+	}
+}
+
+// WithConnectionPool gives the client a transport keeping the idle connections
+// allowed by pool. Clients are usually short-lived, so connections are only
+// reused across them when the transport comes from a TransportRegistry.
+func WithConnectionPool(pool ConnectionPool) Option {
+	return func(c *Client) {
+		c.pool = pool
+	}
+}
+
+// roundTripper returns the transport set by WithTransport, else one built for
+// the client's TLS config and connection pool, else the shared default
+func (c *Client) roundTripper() *http.Transport {
+	switch {
+	case c.transport != nil:
+		return c.transport
+	case c.tlsConfig != nil || c.pool != ConnectionPool{}:
+		return NewTransport(c.pool, c.tlsConfig)
+	}
+	return defaultTransport
+}
+
+// TransportRegistry hands out one transport per n8n instance URL, so every
+// client built for the same instance reuses the same pool of connections
+// instead of dialing n8n afresh on each reconcile
+type TransportRegistry struct {
+	mu         sync.Mutex
+	transports map[string]*registeredTransport
+}
+
+// registeredTransport is a transport with the settings it was built for
+type registeredTransport struct {
+	pool      ConnectionPool
+	tlsConfig *tls.Config
+	transport *http.Transport
+}
+
+// NewTransportRegistry creates an empty registry
+func NewTransportRegistry() *TransportRegistry {
+	return &TransportRegistry{transports: map[string]*registeredTransport{}}
+}
+
+// For returns the transport shared by all clients of the instance at baseURL,
+// keeping the idle connections allowed by pool and verifying the server with
+// tlsConfig when not nil. A transport built for another pool or TLS config is
+// replaced and its idle connections closed. It is nil when the registry is nil.
+func (r *TransportRegistry) For(baseURL string, pool ConnectionPool, tlsConfig *tls.Config) *http.Transport {
+	if r == nil {
+		return nil
+	}
+	key := instanceKey(baseURL)
+	pool = pool.withDefaults()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	registered, ok := r.transports[key]
+	if ok && registered.pool == pool && sameTLSConfig(registered.tlsConfig, tlsConfig) {
+		return registered.transport
+	}
+	if ok {
+		registered.transport.CloseIdleConnections()
+	}
+	registered = &registeredTransport{pool: pool, tlsConfig: tlsConfig, transport: NewTransport(pool, tlsConfig)}
+	r.transports[key] = registered
+	return registered.transport
+}
+
+// sameTLSConfig reports whether a and b verify the server alike. Only the
+// fields set by NewTLSConfig are compared.
+func sameTLSConfig(a, b *tls.Config) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.MinVersion == b.MinVersion && a.InsecureSkipVerify == b.InsecureSkipVerify && a.RootCAs.Equal(b.RootCAs)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingServer starts a server answering every request with an empty
+// workflow list, and returns it with a counter of the connections opened to it
+func newCountingServer(t *testing.T, tls bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(WorkflowListResponse{Data: []Workflow{}})
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	if tls {
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	t.Cleanup(server.Close)
+	return server, &connections
+}
+
+func TestClientReusesConnections(t *testing.T) {
+	server, connections := newCountingServer(t, false)
+
+	client := NewClient(server.URL, "test-key")
+	for i := 0; i < 5; i++ {
+		if _, err := client.ListWorkflows(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// Clients are built per reconcile, so they must share connections too
+	if err := NewClient(server.URL, "test-key").HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("expected sequential requests to reuse one connection, got %d connections", got)
+	}
+}
+
+func TestTransportRegistrySharesConnections(t *testing.T) {
+	server, connections := newCountingServer(t, true)
+	registry := NewTransportRegistry()
+	pool := ConnectionPool{MaxIdleConnsPerHost: 4}
+
+	for i := 0; i < 5; i++ {
+		// NewTLSConfig builds a new config each time, as each reconcile does
+		config, err := NewTLSConfig(nil, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client := NewClient(server.URL, "test-key", WithTransport(registry.For(server.URL, pool, config)))
+		if _, err := client.ListWorkflows(context.Background(), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("expected clients of one instance to reuse one connection, got %d connections", got)
+	}
+}
+
+func TestTransportRegistryFor(t *testing.T) {
+	registry := NewTransportRegistry()
+	pool := ConnectionPool{MaxIdleConnsPerHost: 4}
+
+	transport := registry.For("http://n8n:5678", pool, nil)
+	if transport.MaxIdleConnsPerHost != 4 || transport.MaxIdleConns != DefaultMaxIdleConns ||
+		transport.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("expected the pool over the defaults, got %d/%d/%s",
+			transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if registry.For("HTTP://n8n:5678/", pool, nil) != transport {
+		t.Error("expected the same transport regardless of case and trailing slash")
+	}
+	if registry.For("http://other:5678", pool, nil) == transport {
+		t.Error("expected different instances to get different transports")
+	}
+
+	changed := registry.For("http://n8n:5678", ConnectionPool{IdleConnTimeout: time.Minute}, nil)
+	if changed == transport {
+		t.Error("expected a new transport after the pool changed")
+	}
+	config, _ := NewTLSConfig(nil, true)
+	if registry.For("http://n8n:5678", ConnectionPool{IdleConnTimeout: time.Minute}, config) == changed {
+		t.Error("expected a new transport after the TLS config changed")
+	}
+
+	var nilRegistry *TransportRegistry
+	if nilRegistry.For("http://n8n:5678", pool, nil) != nil {
+		t.Error("expected no transport from a nil registry")
+	}
+}
+
+func TestClientTransport(t *testing.T) {
+	if transport := NewClient("http://n8n:5678", "test-key").httpClient.Transport; transport != defaultTransport {
+		t.Error("expected clients without options to share the default transport")
+	}
+	if defaultTransport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost {
+		t.Errorf("expected %d idle connections per host, got %d", DefaultMaxIdleConnsPerHost, defaultTransport.MaxIdleConnsPerHost)
+	}
+
+	transport := NewClient("http://n8n:5678", "test-key",
+		WithConnectionPool(ConnectionPool{MaxIdleConns: 7})).httpClient.Transport.(*http.Transport)
+	if transport == defaultTransport || transport.MaxIdleConns != 7 {
+		t.Errorf("expected a transport of its own keeping 7 idle connections, got %d", transport.MaxIdleConns)
+	}
+
+	shared := NewTransport(ConnectionPool{}, nil)
+	if NewClient("http://n8n:5678", "test-key", WithTransport(shared),
+		WithConnectionPool(ConnectionPool{MaxIdleConns: 7})).httpClient.Transport != shared {
+		t.Error("expected WithTransport to take precedence")
+	}
+}
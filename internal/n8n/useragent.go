@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import "crypto/rand"
+
+const (
+	// userAgentProduct names the operator in the User-Agent of every request
+	userAgentProduct = "n8n-resource-operator"
+
+	// requestIDHeader carries a unique ID per request, so operator actions can be
+	// matched with n8n's access logs
+	requestIDHeader = "X-Request-ID"
+)
+
+// Version is the operator version reported in the User-Agent. It is set at build
+// time with -ldflags "-X github.com/jspanos/n8n-resource-operator/internal/n8n.Version=v1.2.3".
+var Version = "dev"
+
+// DefaultUserAgent returns the User-Agent sent unless WithUserAgent sets
+// another, e.g. "n8n-resource-operator/v1.2.3"
+func DefaultUserAgent() string {
+	return userAgentProduct + "/" + Version
+}
+
+// WithUserAgent sets the User-Agent sent with every request, telling operator
+// traffic apart from the editor UI in n8n's access logs
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// newRequestID returns a random ID for a single request
+func newRequestID() string {
+	return rand.Text()
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestHeaders(t *testing.T) {
+	var userAgents, requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		requestIDs = append(requestIDs, r.Header.Get(requestIDHeader))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"123","name":"Existing","nodes":[],"connections":{}}`))
+	}))
+	defer server.Close()
+
+	entries, opt := captureLogger()
+	client := NewClient(server.URL, testAPIKey, opt)
+	for range 2 {
+		if _, err := client.GetWorkflow(context.Background(), "123"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(requestIDs) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requestIDs))
+	}
+	for _, userAgent := range userAgents {
+		if userAgent != "n8n-resource-operator/"+Version {
+			t.Errorf("expected the operator User-Agent, got %q", userAgent)
+		}
+	}
+	if requestIDs[0] == "" || requestIDs[1] == "" {
+		t.Fatalf("expected a request ID on every request, got %q", requestIDs)
+	}
+	if requestIDs[0] == requestIDs[1] {
+		t.Errorf("expected unique request IDs, got %q twice", requestIDs[0])
+	}
+	for i, entry := range *entries {
+		if want := `"requestID"="` + requestIDs[i] + `"`; !strings.Contains(entry, want) {
+			t.Errorf("expected log entry to contain %s, got %s", want, entry)
+		}
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey, WithUserAgent("platform-sync/2.0"))
+	if _, err := client.ListWorkflows(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userAgent != "platform-sync/2.0" {
+		t.Errorf("expected the configured User-Agent, got %q", userAgent)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Variable represents an n8n instance variable. The API identifies variables
+// by ID, but keys are unique within an instance.
+type Variable struct {
+	ID    string `json:"id,omitempty"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// VariableListResponse represents the response from listing variables
+type VariableListResponse struct {
+	Data       []Variable `json:"data"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
+
+// ListVariables retrieves all variables from n8n
+func (c *Client) ListVariables(ctx context.Context) ([]Variable, error) {
+	var allVariables []Variable
+	cursor := ""
+
+	for {
+		path := "/api/v1/variables"
+		if cursor != "" {
+			path += "?cursor=" + cursor
+		}
+
+		respBody, err := c.doRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list variables: %w", err)
+		}
+
+		var listResp VariableListResponse
+		if err := json.Unmarshal(respBody, &listResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+		}
+
+		allVariables = append(allVariables, listResp.Data...)
+
+		if listResp.NextCursor == "" {
+			break
+		}
+		cursor = listResp.NextCursor
+	}
+
+	return allVariables, nil
+}
+
+// FindVariable returns the variable with the given key, or nil if n8n has none
+func (c *Client) FindVariable(ctx context.Context, key string) (*Variable, error) {
+	variables, err := c.ListVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range variables {
+		if variables[i].Key == key {
+			return &variables[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateVariable creates a new variable in n8n. n8n answers with an empty body,
+// so the created variable is looked up by key to learn its ID.
+func (c *Client) CreateVariable(ctx context.Context, variable *Variable) (*Variable, error) {
+	createReq := &Variable{Key: variable.Key, Value: variable.Value}
+
+	respBody, err := c.doRequest(ctx, http.MethodPost, "/api/v1/variables", createReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variable %s: %w", variable.Key, err)
+	}
+
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		var created Variable
+		if err := json.Unmarshal(respBody, &created); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal created variable: %w", err)
+		}
+		if created.ID != "" {
+			return &created, nil
+		}
+	}
+
+	created, err := c.FindVariable(ctx, variable.Key)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, fmt.Errorf("variable %s not found after creating it", variable.Key)
+	}
+	return created, nil
+}
+
+// UpdateVariable replaces the key and value of an existing variable
+func (c *Client) UpdateVariable(ctx context.Context, id string, variable *Variable) error {
+	updateReq := &Variable{Key: variable.Key, Value: variable.Value}
+
+	if _, err := c.doRequest(ctx, http.MethodPut, "/api/v1/variables/"+id, updateReq); err != nil {
+		return fmt.Errorf("failed to update variable %s: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteVariable deletes a variable from n8n
+func (c *Client) DeleteVariable(ctx context.Context, id string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, "/api/v1/variables/"+id, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete variable %s: %w", id, err)
+	}
+	return nil
+}
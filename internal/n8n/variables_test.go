@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListVariablesPaginated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/variables" {
+			t.Errorf("expected path /api/v1/variables, got %s", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("cursor") == "" {
+			json.NewEncoder(w).Encode(VariableListResponse{Data: []Variable{{ID: "1", Key: "REGION", Value: "eu"}}, NextCursor: "next"})
+			return
+		}
+		json.NewEncoder(w).Encode(VariableListResponse{Data: []Variable{{ID: "2", Key: "API_HOST", Value: "api.example.com"}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	variable, err := client.FindVariable(context.Background(), "API_HOST")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variable == nil || variable.ID != "2" {
+		t.Fatalf("expected variable 2 from the second page, got %+v", variable)
+	}
+
+	missing, err := client.FindVariable(context.Background(), "MISSING")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if missing != nil {
+		t.Errorf("expected no variable for an unknown key, got %+v", missing)
+	}
+}
+
+func TestCreateVariableLooksUpID(t *testing.T) {
+	var created Variable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&created)
+			created.ID = "9"
+			// n8n answers a create with 201 and no body
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(VariableListResponse{Data: []Variable{created}})
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	variable, err := client.CreateVariable(context.Background(), &Variable{Key: "REGION", Value: "eu"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if variable.ID != "9" || variable.Value != "eu" {
+		t.Errorf("expected variable 9 with value eu, got %+v", variable)
+	}
+}
+
+func TestUpdateVariable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/variables/9" {
+			t.Errorf("expected path /api/v1/variables/9, got %s", r.URL.Path)
+		}
+
+		var variable Variable
+		json.NewDecoder(r.Body).Decode(&variable)
+		if variable.Key != "REGION" || variable.Value != "us" {
+			t.Errorf("expected REGION=us, got %+v", variable)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if err := client.UpdateVariable(context.Background(), "9", &Variable{Key: "REGION", Value: "us"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteVariableNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE method, got %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{Message: "not found"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	err := client.DeleteVariable(context.Background(), "9")
+	if !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
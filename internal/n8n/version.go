@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MinSupportedVersion and MaxSupportedVersion bound the n8n releases the
+// operator is tested against. Every patch release of MaxSupportedVersion is
+// included, so it is given as major.minor.
+const (
+	MinSupportedVersion = "1.0"
+	MaxSupportedVersion = "1.123"
+)
+
+// settingsPath serves the settings the n8n editor loads before login, which
+// include the release and license. It is not part of the public API.
+const settingsPath = "/rest/settings"
+
+// ErrVersionUnavailable is returned by GetVersion when the instance doesn't
+// expose its version, e.g. because a proxy only forwards the public API
+var ErrVersionUnavailable = errors.New("n8n does not expose its version")
+
+// VersionInfo describes the n8n release an instance runs
+type VersionInfo struct {
+	// Version is the n8n release, e.g. 1.64.0
+	Version string
+
+	// Edition is the license plan, e.g. Community or Enterprise, or "" if unknown
+	Edition string
+}
+
+// settingsResponse is the part of the editor settings carrying version info
+type settingsResponse struct {
+	Data struct {
+		VersionCli string `json:"versionCli"`
+		License    struct {
+			PlanName string `json:"planName"`
+		} `json:"license"`
+		// Enterprise maps each licensed feature to whether it is enabled
+		Enterprise map[string]any `json:"enterprise"`
+	} `json:"data"`
+}
+
+// GetVersion reads the n8n release and edition from the editor settings.
+// ErrVersionUnavailable is returned if the instance doesn't serve them.
+func (c *Client) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	respBody, err := c.doRequest(ctx, http.MethodGet, settingsPath, nil)
+	if err != nil {
+		var apiErr *ErrorResponse
+		if errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError {
+			return nil, fmt.Errorf("%w: %v", ErrVersionUnavailable, err)
+		}
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	var settings settingsResponse
+	if err := json.Unmarshal(respBody, &settings); err != nil || settings.Data.VersionCli == "" {
+		return nil, ErrVersionUnavailable
+	}
+
+	info := &VersionInfo{Version: settings.Data.VersionCli, Edition: settings.Data.License.PlanName}
+	if info.Edition == "" && settings.Data.Enterprise != nil {
+		info.Edition = "Community"
+		for _, enabled := range settings.Data.Enterprise {
+			if enabled == true {
+				info.Edition = "Enterprise"
+				break
+			}
+		}
+	}
+	return info, nil
+}
+
+// IsVersionUnavailable returns true if err means the instance doesn't expose
+// its version
+func IsVersionUnavailable(err error) bool {
+	return errors.Is(err, ErrVersionUnavailable)
+}
+
+// Release is a parsed n8n release number
+type Release struct {
+	Major, Minor, Patch int
+}
+
+// ParseRelease parses a release number such as 1.64.0, v1.64 or
+// 1.65.0-rc.1. The patch defaults to 0, and pre-release and build suffixes
+// are ignored.
+func ParseRelease(version string) (Release, error) {
+	core := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Release{}, fmt.Errorf("invalid version %q: expected major.minor[.patch]", version)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Release{}, fmt.Errorf("invalid version %q: %q is not a number", version, part)
+		}
+		numbers[i] = n
+	}
+	return Release{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Compare returns -1, 0 or 1 as v is older than, the same as or newer than other
+func (v Release) Compare(other Release) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// CompareVersions parses a and b and returns -1, 0 or 1 as a is older than,
+// the same as or newer than b
+func CompareVersions(a, b string) (int, error) {
+	va, err := ParseRelease(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseRelease(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// IsSupportedVersion reports whether version lies between MinSupportedVersion
+// and any patch release of MaxSupportedVersion
+func IsSupportedVersion(version string) (bool, error) {
+	v, err := ParseRelease(version)
+	if err != nil {
+		return false, err
+	}
+	minVersion, _ := ParseRelease(MinSupportedVersion)
+	maxVersion, _ := ParseRelease(MaxSupportedVersion)
+	maxVersion.Patch = v.Patch
+	return v.Compare(minVersion) >= 0 && v.Compare(maxVersion) <= 0, nil
+}
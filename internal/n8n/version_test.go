@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package n8n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected VersionInfo
+	}{
+		{
+			name:     "license plan",
+			body:     `{"data":{"versionCli":"1.64.0","license":{"planName":"Enterprise"},"enterprise":{"sharing":true}}}`,
+			expected: VersionInfo{Version: "1.64.0", Edition: "Enterprise"},
+		},
+		{
+			name:     "no plan and no licensed features",
+			body:     `{"data":{"versionCli":"1.30.1","enterprise":{"sharing":false,"ldap":false}}}`,
+			expected: VersionInfo{Version: "1.30.1", Edition: "Community"},
+		},
+		{
+			name:     "licensed features without a plan",
+			body:     `{"data":{"versionCli":"1.30.1","enterprise":{"sharing":true}}}`,
+			expected: VersionInfo{Version: "1.30.1", Edition: "Enterprise"},
+		},
+		{
+			name:     "no license information",
+			body:     `{"data":{"versionCli":"0.236.0"}}`,
+			expected: VersionInfo{Version: "0.236.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/settings" {
+					t.Errorf("expected path /rest/settings, got %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			info, err := NewClient(server.URL, "test-key").GetVersion(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *info != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, *info)
+			}
+		})
+	}
+}
+
+func TestGetVersionUnavailable(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{name: "not found", statusCode: http.StatusNotFound, body: `{"message":"Not Found"}`},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, body: `{"message":"Unauthorized"}`},
+		{name: "no version", statusCode: http.StatusOK, body: `{"data":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL, "test-key").GetVersion(context.Background())
+			if !IsVersionUnavailable(err) {
+				t.Errorf("expected ErrVersionUnavailable, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.64.0", "1.64.0", 0},
+		{"1.64", "1.64.0", 0},
+		{"v1.64.1", "1.64.0", 1},
+		{"1.9.0", "1.10.0", -1},
+		{"2.0.0", "1.123.4", 1},
+		{"1.65.0-rc.1", "1.65.0", 0},
+		{"1.65.0+build.7", "1.64.9", 1},
+	}
+	for _, tt := range tests {
+		got, err := CompareVersions(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("CompareVersions(%q, %q): unexpected error: %v", tt.a, tt.b, err)
+		}
+		if got != tt.expected {
+			t.Errorf("CompareVersions(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", "1", "1.x.0", "1.2.3.4", "latest"} {
+		if _, err := CompareVersions(invalid, "1.0.0"); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestIsSupportedVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected bool
+	}{
+		{MinSupportedVersion, true},
+		{"1.64.0", true},
+		{MaxSupportedVersion + ".7", true},
+		{"0.236.3", false},
+		{"1.124.0", false},
+		{"2.0.0", false},
+	}
+	for _, tt := range tests {
+		got, err := IsSupportedVersion(tt.version)
+		if err != nil {
+			t.Fatalf("IsSupportedVersion(%q): unexpected error: %v", tt.version, err)
+		}
+		if got != tt.expected {
+			t.Errorf("IsSupportedVersion(%q) = %t, expected %t", tt.version, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+	"github.com/jspanos/n8n-resource-operator/internal/controller"
+)
+
+var n8nworkflowlog = logf.Log.WithName("n8nworkflow-webhook")
+
+// DefaultWorkflowSettings is the settings block given to workflows that set none,
+// unless the operator is started with other defaults
+const DefaultWorkflowSettings = `{"executionOrder":"v1","saveDataSuccessExecution":"all"}`
+
+// SetupN8nWorkflowWebhookWithManager registers the N8nWorkflow webhooks with the
+// manager. defaultSettings is the JSON object used for workflows without settings;
+// empty disables defaulting them.
+func SetupN8nWorkflowWebhookWithManager(mgr ctrl.Manager, defaultSettings string) error {
+	defaulter, err := NewN8nWorkflowCustomDefaulter(defaultSettings)
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&n8nv1alpha1.N8nWorkflow{}).
+		WithDefaulter(defaulter).
+		WithValidator(&N8nWorkflowCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-n8n-slys-dev-v1alpha1-n8nworkflow,mutating=true,failurePolicy=fail,sideEffects=None,groups=n8n.slys.dev,resources=n8nworkflows,verbs=create;update,versions=v1alpha1,name=mn8nworkflow-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// N8nWorkflowCustomDefaulter fills in the boilerplate of an N8nWorkflow so the
+// stored object already carries it: the workflow name defaults to the resource
+// name, and empty settings to the operator's default settings unless a template
+// or base workflow provides them.
+type N8nWorkflowCustomDefaulter struct {
+	// settings is the compact JSON object given to workflows without settings
+	settings []byte
+}
+
+var _ admission.CustomDefaulter = &N8nWorkflowCustomDefaulter{}
+
+// NewN8nWorkflowCustomDefaulter returns a defaulter using settings, which must
+// be empty or a JSON object
+func NewN8nWorkflowCustomDefaulter(settings string) (*N8nWorkflowCustomDefaulter, error) {
+	defaulter := &N8nWorkflowCustomDefaulter{}
+	if strings.TrimSpace(settings) == "" {
+		return defaulter, nil
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(settings), &object); err != nil {
+		return nil, fmt.Errorf("default workflow settings must be a JSON object: %w", err)
+	}
+	if len(object) > 0 {
+		defaulter.settings, _ = json.Marshal(object)
+	}
+	return defaulter, nil
+}
+
+// Default sets the workflow name and settings when they are unset
+func (d *N8nWorkflowCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	workflow, ok := obj.(*n8nv1alpha1.N8nWorkflow)
+	if !ok {
+		return fmt.Errorf("expected an N8nWorkflow object but got %T", obj)
+	}
+	n8nworkflowlog.V(1).Info("defaulting", "name", workflow.Name, "namespace", workflow.Namespace)
+
+	if workflow.Spec.Workflow.Name == "" {
+		workflow.Spec.Workflow.Name = workflow.Name
+	}
+	if d.settings != nil && workflow.Spec.TemplateRef == nil &&
+		workflow.Spec.Workflow.BaseFrom == nil && emptySettings(workflow.Spec.Workflow.Settings) {
+		workflow.Spec.Workflow.Settings = &runtime.RawExtension{Raw: append([]byte(nil), d.settings...)}
+	}
+	return nil
+}
+
+// emptySettings reports whether settings is unset, null or an empty object
+func emptySettings(settings *runtime.RawExtension) bool {
+	if settings == nil || len(settings.Raw) == 0 {
+		return true
+	}
+	var object map[string]any
+	if err := json.Unmarshal(settings.Raw, &object); err != nil {
+		// Leave malformed settings for validation to report
+		return false
+	}
+	return len(object) == 0
+}
+
+// +kubebuilder:webhook:path=/validate-n8n-slys-dev-v1alpha1-n8nworkflow,mutating=false,failurePolicy=fail,sideEffects=None,groups=n8n.slys.dev,resources=n8nworkflows,verbs=create;update,versions=v1alpha1,name=vn8nworkflow-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// N8nWorkflowCustomValidator rejects N8nWorkflows the controller could never
+// sync, such as nodes that aren't JSON objects or connections to missing nodes,
+// at apply time instead of leaving a conversion error in status. It runs the
+// same checks as controller.ValidateWorkflow; lint warnings are not reported.
+type N8nWorkflowCustomValidator struct{}
+
+var _ admission.CustomValidator = &N8nWorkflowCustomValidator{}
+
+// ValidateCreate validates a new N8nWorkflow
+func (v *N8nWorkflowCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	workflow, ok := obj.(*n8nv1alpha1.N8nWorkflow)
+	if !ok {
+		return nil, fmt.Errorf("expected an N8nWorkflow object but got %T", obj)
+	}
+	n8nworkflowlog.V(1).Info("validating create", "name", workflow.Name, "namespace", workflow.Namespace)
+
+	return nil, validate(workflow)
+}
+
+// ValidateUpdate validates a changed N8nWorkflow. Updates that leave the spec
+// alone, such as adding a finalizer or deleting, are always allowed so that
+// workflows created before the webhook can still be managed and removed.
+func (v *N8nWorkflowCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	workflow, ok := newObj.(*n8nv1alpha1.N8nWorkflow)
+	if !ok {
+		return nil, fmt.Errorf("expected an N8nWorkflow object for the new object but got %T", newObj)
+	}
+	old, ok := oldObj.(*n8nv1alpha1.N8nWorkflow)
+	if !ok {
+		return nil, fmt.Errorf("expected an N8nWorkflow object for the old object but got %T", oldObj)
+	}
+	if !workflow.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(old.Spec, workflow.Spec) {
+		return nil, nil
+	}
+	n8nworkflowlog.V(1).Info("validating update", "name", workflow.Name, "namespace", workflow.Namespace)
+
+	return nil, validate(workflow)
+}
+
+// ValidateDelete allows every deletion
+func (v *N8nWorkflowCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate turns the errors found by controller.ValidateWorkflow into an
+// Invalid API error with one cause per offending field
+func validate(workflow *n8nv1alpha1.N8nWorkflow) error {
+	var errs field.ErrorList
+	for _, issue := range controller.ValidateWorkflow(workflow).Issues {
+		if issue.Severity != controller.ValidationError {
+			continue
+		}
+		errs = append(errs, &field.Error{
+			Type:     field.ErrorTypeInvalid,
+			Field:    issue.Field,
+			BadValue: field.OmitValueType{},
+			Detail:   issue.Message,
+		})
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(n8nv1alpha1.GroupVersion.WithKind("N8nWorkflow").GroupKind(), workflow.Name, errs)
+}
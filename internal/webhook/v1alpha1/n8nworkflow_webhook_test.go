@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	n8nv1alpha1 "github.com/jspanos/n8n-resource-operator/api/v1alpha1"
+)
+
+var _ = Describe("N8nWorkflow validating webhook", func() {
+	var validator *N8nWorkflowCustomValidator
+	ctx := context.Background()
+
+	raw := func(value string) runtime.RawExtension {
+		return runtime.RawExtension{Raw: []byte(value)}
+	}
+
+	newWorkflow := func() *n8nv1alpha1.N8nWorkflow {
+		return &n8nv1alpha1.N8nWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default"},
+			Spec: n8nv1alpha1.N8nWorkflowSpec{
+				InstanceRef: "n8n",
+				Workflow: n8nv1alpha1.WorkflowSpec{
+					Name: "Orders",
+					Nodes: []runtime.RawExtension{
+						raw(`{"name":"Hook","type":"n8n-nodes-base.webhook","parameters":{"path":"orders"}}`),
+						raw(`{"name":"Set","type":"n8n-nodes-base.set"}`),
+					},
+					Connections: &runtime.RawExtension{
+						Raw: []byte(`{"Hook":{"main":[[{"node":"Set","type":"main","index":0}]]}}`),
+					},
+				},
+			},
+		}
+	}
+
+	// causeFields returns the field of every cause of an Invalid error
+	causeFields := func(err error) []string {
+		Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+		var fields []string
+		for _, cause := range err.(apierrors.APIStatus).Status().Details.Causes {
+			fields = append(fields, cause.Field)
+		}
+		return fields
+	}
+
+	BeforeEach(func() {
+		validator = &N8nWorkflowCustomValidator{}
+	})
+
+	It("should admit a valid workflow", func() {
+		warnings, err := validator.ValidateCreate(ctx, newWorkflow())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	DescribeTable("should reject malformed workflows on create",
+		func(mutate func(*n8nv1alpha1.N8nWorkflow), field string) {
+			workflow := newWorkflow()
+			mutate(workflow)
+
+			_, err := validator.ValidateCreate(ctx, workflow)
+			Expect(causeFields(err)).To(ContainElement(field))
+		},
+		Entry("node that is not a JSON object", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[1] = raw(`"Set"`)
+		}, "spec.workflow.nodes[1]"),
+		Entry("connection to a missing node", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Connections = &runtime.RawExtension{
+				Raw: []byte(`{"Hook":{"main":[[{"node":"Missing","type":"main","index":0}]]}}`),
+			}
+		}, `spec.workflow.connections["Hook"]`),
+		Entry("webhook node with an empty path", func(w *n8nv1alpha1.N8nWorkflow) {
+			w.Spec.Workflow.Nodes[0] = raw(`{"name":"Hook","type":"n8n-nodes-base.webhook","parameters":{"path":""}}`)
+		}, "spec.workflow.nodes[0].parameters.path"),
+	)
+
+	It("should name the offending node index in the message", func() {
+		workflow := newWorkflow()
+		workflow.Spec.Workflow.Nodes[1] = raw(`[1, 2]`)
+
+		_, err := validator.ValidateCreate(ctx, workflow)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.workflow.nodes[1]"))
+	})
+
+	It("should reject updates that break the spec", func() {
+		old := newWorkflow()
+		updated := newWorkflow()
+		updated.Spec.Workflow.Nodes = updated.Spec.Workflow.Nodes[1:]
+
+		_, err := validator.ValidateUpdate(ctx, old, updated)
+		Expect(causeFields(err)).To(ContainElement(`spec.workflow.connections["Hook"]`))
+	})
+
+	It("should allow updates that leave an invalid spec unchanged", func() {
+		old := newWorkflow()
+		old.Spec.Workflow.Nodes[1] = raw(`"Set"`)
+		updated := old.DeepCopy()
+		updated.Finalizers = []string{"n8n.slys.dev/workflow-cleanup"}
+
+		_, err := validator.ValidateUpdate(ctx, old, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should allow deletes", func() {
+		_, err := validator.ValidateDelete(ctx, newWorkflow())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should admit a workflow rendered from a template", func() {
+		workflow := newWorkflow()
+		workflow.Spec.Workflow.Nodes = nil
+		workflow.Spec.Workflow.Connections = nil
+		workflow.Spec.TemplateRef = &n8nv1alpha1.WorkflowTemplateReference{Name: "alert-to-slack"}
+		workflow.Spec.Parameters = map[string]string{"channel": "#team-a"}
+		_, err := validator.ValidateCreate(ctx, workflow)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject nodes alongside a template", func() {
+		workflow := newWorkflow()
+		workflow.Spec.TemplateRef = &n8nv1alpha1.WorkflowTemplateReference{Name: "alert-to-slack"}
+		_, err := validator.ValidateCreate(ctx, workflow)
+		Expect(causeFields(err)).To(ConsistOf("spec.workflow.nodes", "spec.workflow.connections"))
+	})
+
+	It("should reject parameters without a template", func() {
+		workflow := newWorkflow()
+		workflow.Spec.Parameters = map[string]string{"channel": "#team-a"}
+		_, err := validator.ValidateCreate(ctx, workflow)
+		Expect(causeFields(err)).To(ConsistOf("spec.parameters"))
+	})
+})
+
+var _ = Describe("N8nWorkflow defaulting webhook", func() {
+	ctx := context.Background()
+
+	// minimalWorkflow is the least a user can apply: an instance and some nodes
+	minimalWorkflow := func() *n8nv1alpha1.N8nWorkflow {
+		return &n8nv1alpha1.N8nWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default"},
+			Spec: n8nv1alpha1.N8nWorkflowSpec{
+				InstanceRef: "n8n",
+				Workflow: n8nv1alpha1.WorkflowSpec{
+					Nodes: []runtime.RawExtension{
+						{Raw: []byte(`{"name":"Hook","type":"n8n-nodes-base.webhook","parameters":{"path":"orders"}}`)},
+					},
+				},
+			},
+		}
+	}
+
+	It("should populate the name and settings of a minimal workflow", func() {
+		defaulter, err := NewN8nWorkflowCustomDefaulter(DefaultWorkflowSettings)
+		Expect(err).NotTo(HaveOccurred())
+
+		workflow := minimalWorkflow()
+		Expect(defaulter.Default(ctx, workflow)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Name).To(Equal("orders"))
+		Expect(workflow.Spec.Workflow.Settings).NotTo(BeNil())
+		Expect(workflow.Spec.Workflow.Settings.Raw).To(MatchJSON(`{"executionOrder":"v1","saveDataSuccessExecution":"all"}`))
+	})
+
+	It("should treat empty settings as unset", func() {
+		defaulter, err := NewN8nWorkflowCustomDefaulter(DefaultWorkflowSettings)
+		Expect(err).NotTo(HaveOccurred())
+
+		workflow := minimalWorkflow()
+		workflow.Spec.Workflow.Settings = &runtime.RawExtension{Raw: []byte(`{}`)}
+		Expect(defaulter.Default(ctx, workflow)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Settings.Raw).To(MatchJSON(DefaultWorkflowSettings))
+	})
+
+	It("should keep the name and settings a workflow sets", func() {
+		defaulter, err := NewN8nWorkflowCustomDefaulter(DefaultWorkflowSettings)
+		Expect(err).NotTo(HaveOccurred())
+
+		workflow := minimalWorkflow()
+		workflow.Spec.Workflow.Name = "Order Intake"
+		workflow.Spec.Workflow.Settings = &runtime.RawExtension{Raw: []byte(`{"timezone":"UTC"}`)}
+		Expect(defaulter.Default(ctx, workflow)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Name).To(Equal("Order Intake"))
+		Expect(workflow.Spec.Workflow.Settings.Raw).To(MatchJSON(`{"timezone":"UTC"}`))
+	})
+
+	It("should use the operator's default settings", func() {
+		defaulter, err := NewN8nWorkflowCustomDefaulter(`{"executionOrder":"v0","saveManualExecutions":false}`)
+		Expect(err).NotTo(HaveOccurred())
+
+		workflow := minimalWorkflow()
+		Expect(defaulter.Default(ctx, workflow)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Settings.Raw).To(MatchJSON(`{"executionOrder":"v0","saveManualExecutions":false}`))
+	})
+
+	It("should leave settings unset when defaulting them is disabled", func() {
+		defaulter, err := NewN8nWorkflowCustomDefaulter("")
+		Expect(err).NotTo(HaveOccurred())
+
+		workflow := minimalWorkflow()
+		Expect(defaulter.Default(ctx, workflow)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Settings).To(BeNil())
+		Expect(workflow.Spec.Workflow.Name).To(Equal("orders"))
+	})
+
+	It("should leave the settings of a templated workflow to its template", func() {
+		defaulter, err := NewN8nWorkflowCustomDefaulter(DefaultWorkflowSettings)
+		Expect(err).NotTo(HaveOccurred())
+
+		workflow := minimalWorkflow()
+		workflow.Spec.Workflow.Nodes = nil
+		workflow.Spec.TemplateRef = &n8nv1alpha1.WorkflowTemplateReference{Name: "alert-to-slack"}
+		Expect(defaulter.Default(ctx, workflow)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Settings).To(BeNil())
+		Expect(workflow.Spec.Workflow.Name).To(Equal("orders"))
+	})
+
+	It("should leave the settings of a workflow built on a base to the base", func() {
+		defaulter, err := NewN8nWorkflowCustomDefaulter(DefaultWorkflowSettings)
+		Expect(err).NotTo(HaveOccurred())
+
+		workflow := minimalWorkflow()
+		workflow.Spec.Workflow.Nodes = nil
+		workflow.Spec.Workflow.BaseFrom = &n8nv1alpha1.BaseWorkflowSource{}
+		Expect(defaulter.Default(ctx, workflow)).To(Succeed())
+		Expect(workflow.Spec.Workflow.Settings).To(BeNil())
+	})
+
+	It("should reject default settings that aren't a JSON object", func() {
+		_, err := NewN8nWorkflowCustomDefaulter(`["executionOrder"]`)
+		Expect(err).To(HaveOccurred())
+	})
+})